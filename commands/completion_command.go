@@ -119,9 +119,15 @@ func (c *CompletionCommand) Run(args []string) int {
 		return 1
 	}
 
+	mi, err := w.ModuleIndex(fh.Dir())
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("failed to index module: %s", err.Error()))
+		return 1
+	}
+
 	pos := fPos.Position()
 
-	candidates, err := p.CompletionCandidatesAtPos(hclFile, pos)
+	candidates, err := p.CompletionCandidatesAtPos(hclFile, mi, pos)
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("failed to find candidates: %s", err.Error()))
 		return 1
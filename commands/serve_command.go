@@ -14,6 +14,7 @@ import (
 	"time"
 
 	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/terraform/schema"
 	"github.com/hashicorp/terraform-ls/langserver"
 	"github.com/hashicorp/terraform-ls/langserver/handlers"
 	"github.com/hashicorp/terraform-ls/logging"
@@ -25,13 +26,14 @@ type ServeCommand struct {
 	Version string
 
 	// flags
-	port          int
-	logFilePath   string
-	tfExecPath    string
-	tfExecLogPath string
-	tfExecTimeout string
-	cpuProfile    string
-	memProfile    string
+	port           int
+	logFilePath    string
+	tfExecPath     string
+	tfExecLogPath  string
+	tfExecTimeout  string
+	schemaCacheDir string
+	cpuProfile     string
+	memProfile     string
 }
 
 func (c *ServeCommand) flags() *flag.FlagSet {
@@ -45,6 +47,8 @@ func (c *ServeCommand) flags() *flag.FlagSet {
 	fs.StringVar(&c.tfExecLogPath, "tf-log-file", "", "path to a file for Terraform executions"+
 		" to be logged into with support for variables (e.g. Timestamp, Pid, Ppid) via Go template"+
 		" syntax {{.VarName}}")
+	fs.StringVar(&c.schemaCacheDir, "schema-cache-dir", "", "directory to cache provider schemas in"+
+		" (defaults to terraform-ls/schemas within the user cache directory)")
 	fs.StringVar(&c.cpuProfile, "cpuprofile", "", "file into which to write CPU profile (if not empty)")
 	fs.StringVar(&c.memProfile, "memprofile", "", "file into which to write memory profile (if not empty)")
 
@@ -134,6 +138,19 @@ func (c *ServeCommand) Run(args []string) int {
 		logger.Printf("Terraform exec path set to %q", path)
 	}
 
+	schemaCacheDir := c.schemaCacheDir
+	if schemaCacheDir == "" {
+		dir, err := schema.DefaultCacheDir()
+		if err != nil {
+			logger.Printf("Schemas will not be cached: %s", err)
+		}
+		schemaCacheDir = dir
+	}
+	if schemaCacheDir != "" {
+		ctx = lsctx.WithSchemaCacheDir(schemaCacheDir, ctx)
+		logger.Printf("Schemas will be cached in %s", schemaCacheDir)
+	}
+
 	logger.Printf("Starting terraform-ls %s", c.Version)
 
 	srv := langserver.NewLangServer(ctx, handlers.NewSession)
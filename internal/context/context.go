@@ -4,7 +4,9 @@ import (
 	"context"
 	"time"
 
+	"github.com/hashicorp/terraform-ls/internal/diagnostics"
 	"github.com/hashicorp/terraform-ls/internal/filesystem"
+	"github.com/hashicorp/terraform-ls/internal/progress"
 	"github.com/hashicorp/terraform-ls/internal/terraform/rootmodule"
 	"github.com/hashicorp/terraform-ls/internal/watcher"
 	"github.com/sourcegraph/go-lsp"
@@ -25,14 +27,20 @@ var (
 	ctxTfExecPath        = &contextKey{"terraform executable path"}
 	ctxTfExecLogPath     = &contextKey{"terraform executor log path"}
 	ctxTfExecTimeout     = &contextKey{"terraform execution timeout"}
+	ctxSchemaCacheDir    = &contextKey{"schema cache directory"}
 	ctxWatcher           = &contextKey{"watcher"}
 	ctxRootModuleMngr    = &contextKey{"root module manager"}
 	ctxParserFinder      = &contextKey{"parser finder"}
+	ctxModuleIndexFinder = &contextKey{"module index finder"}
 	ctxTfFormatterFinder = &contextKey{"terraform formatter finder"}
 	ctxRootModuleCaFi    = &contextKey{"root module candidate finder"}
 	ctxRootModuleWalker  = &contextKey{"root module walker"}
 	ctxRootModuleLoader  = &contextKey{"root module loader"}
 	ctxRootDir           = &contextKey{"root directory"}
+	ctxDiagsNotifier     = &contextKey{"diagnostics notifier"}
+	ctxValidateScheduler = &contextKey{"validation scheduler"}
+	ctxProgressClient    = &contextKey{"progress client"}
+	ctxProgressNotifier  = &contextKey{"progress notifier"}
 )
 
 func missingContextErr(ctxKey *contextKey) *MissingContextErr {
@@ -88,6 +96,15 @@ func TerraformExecLogPath(ctx context.Context) (string, bool) {
 	return path, ok
 }
 
+func WithSchemaCacheDir(dir string, ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxSchemaCacheDir, dir)
+}
+
+func SchemaCacheDir(ctx context.Context) (string, bool) {
+	dir, ok := ctx.Value(ctxSchemaCacheDir).(string)
+	return dir, ok
+}
+
 func WithTerraformExecTimeout(timeout time.Duration, ctx context.Context) context.Context {
 	return context.WithValue(ctx, ctxTfExecTimeout, timeout)
 }
@@ -133,6 +150,18 @@ func ParserFinder(ctx context.Context) (rootmodule.ParserFinder, error) {
 	return pf, nil
 }
 
+func WithModuleIndexFinder(mif rootmodule.ModuleIndexFinder, ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxModuleIndexFinder, mif)
+}
+
+func ModuleIndexFinder(ctx context.Context) (rootmodule.ModuleIndexFinder, error) {
+	mif, ok := ctx.Value(ctxModuleIndexFinder).(rootmodule.ModuleIndexFinder)
+	if !ok {
+		return nil, missingContextErr(ctxModuleIndexFinder)
+	}
+	return mif, nil
+}
+
 func WithTerraformFormatterFinder(tef rootmodule.TerraformFormatterFinder, ctx context.Context) context.Context {
 	return context.WithValue(ctx, ctxTfFormatterFinder, tef)
 }
@@ -211,3 +240,48 @@ func RootModuleLoader(ctx context.Context) (rootmodule.RootModuleLoader, error)
 	}
 	return w, nil
 }
+
+func WithDiagnosticsNotifier(n *diagnostics.Notifier, ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxDiagsNotifier, n)
+}
+
+func DiagnosticsNotifier(ctx context.Context) (*diagnostics.Notifier, error) {
+	n, ok := ctx.Value(ctxDiagsNotifier).(*diagnostics.Notifier)
+	if !ok {
+		return nil, missingContextErr(ctxDiagsNotifier)
+	}
+	return n, nil
+}
+
+func WithValidationScheduler(s *diagnostics.Scheduler, ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxValidateScheduler, s)
+}
+
+func ValidationScheduler(ctx context.Context) (*diagnostics.Scheduler, error) {
+	s, ok := ctx.Value(ctxValidateScheduler).(*diagnostics.Scheduler)
+	if !ok {
+		return nil, missingContextErr(ctxValidateScheduler)
+	}
+	return s, nil
+}
+
+func WithProgressClient(c progress.Client, ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxProgressClient, c)
+}
+
+func ProgressClient(ctx context.Context) (progress.Client, bool) {
+	c, ok := ctx.Value(ctxProgressClient).(progress.Client)
+	return c, ok
+}
+
+func WithProgressNotifier(n *progress.Notifier, ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxProgressNotifier, n)
+}
+
+func ProgressNotifier(ctx context.Context) (*progress.Notifier, error) {
+	n, ok := ctx.Value(ctxProgressNotifier).(*progress.Notifier)
+	if !ok {
+		return nil, missingContextErr(ctxProgressNotifier)
+	}
+	return n, nil
+}
@@ -0,0 +1,139 @@
+package diagnostics
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"sort"
+	"sync"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/hashicorp/hcl/v2"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/sourcegraph/go-lsp"
+)
+
+// Notifier keeps track of diagnostics per document and source
+// and publishes them to the client via textDocument/publishDiagnostics
+type Notifier struct {
+	logger *log.Logger
+
+	diags   map[lsp.DocumentURI]map[string][]lsp.Diagnostic
+	diagsMu *sync.Mutex
+
+	// dirDiags tracks documents with diagnostics
+	// published for a directory via PublishForDir
+	dirDiags map[dirSource]map[lsp.DocumentURI]bool
+}
+
+type dirSource struct {
+	dir    string
+	source string
+}
+
+func NewNotifier() *Notifier {
+	return &Notifier{
+		logger:   log.New(ioutil.Discard, "", 0),
+		diags:    make(map[lsp.DocumentURI]map[string][]lsp.Diagnostic, 0),
+		diagsMu:  &sync.Mutex{},
+		dirDiags: make(map[dirSource]map[lsp.DocumentURI]bool, 0),
+	}
+}
+
+func (n *Notifier) SetLogger(logger *log.Logger) {
+	n.logger = logger
+}
+
+// Publish replaces any diagnostics previously published
+// for the given document and sources (keys of the map)
+// and sends all known diagnostics for the document to the client
+func (n *Notifier) Publish(ctx context.Context, uri lsp.DocumentURI, diags map[string]hcl.Diagnostics) error {
+	n.diagsMu.Lock()
+	sources, ok := n.diags[uri]
+	if !ok {
+		sources = make(map[string][]lsp.Diagnostic, 0)
+		n.diags[uri] = sources
+	}
+	for source, sourceDiags := range diags {
+		sources[source] = ilsp.HCLDiagsToLSP(sourceDiags, source)
+	}
+	allDiags := mergeDiags(sources)
+	n.diagsMu.Unlock()
+
+	n.logger.Printf("publishing %d diagnostics for %s", len(allDiags), uri)
+	return jrpc2.ServerPush(ctx, "textDocument/publishDiagnostics", lsp.PublishDiagnosticsParams{
+		URI:         uri,
+		Diagnostics: allDiags,
+	})
+}
+
+// PublishForDir replaces diagnostics of the given source
+// for documents within the directory (e.g. root module),
+// clearing diagnostics previously published for the directory
+// for documents which are no longer affected
+func (n *Notifier) PublishForDir(ctx context.Context, dir string, source string,
+	diags map[lsp.DocumentURI]hcl.Diagnostics) error {
+	key := dirSource{dir, source}
+
+	n.diagsMu.Lock()
+	stale := make([]lsp.DocumentURI, 0)
+	for uri := range n.dirDiags[key] {
+		if _, ok := diags[uri]; !ok {
+			stale = append(stale, uri)
+		}
+	}
+	uris := make(map[lsp.DocumentURI]bool, len(diags))
+	for uri := range diags {
+		uris[uri] = true
+	}
+	n.dirDiags[key] = uris
+	n.diagsMu.Unlock()
+
+	for _, uri := range stale {
+		err := n.Publish(ctx, uri, map[string]hcl.Diagnostics{
+			source: {},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for uri, uriDiags := range diags {
+		err := n.Publish(ctx, uri, map[string]hcl.Diagnostics{
+			source: uriDiags,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Clear forgets all diagnostics for the given document
+// and clears them on the client side
+func (n *Notifier) Clear(ctx context.Context, uri lsp.DocumentURI) error {
+	n.diagsMu.Lock()
+	delete(n.diags, uri)
+	n.diagsMu.Unlock()
+
+	return jrpc2.ServerPush(ctx, "textDocument/publishDiagnostics", lsp.PublishDiagnosticsParams{
+		URI:         uri,
+		Diagnostics: []lsp.Diagnostic{},
+	})
+}
+
+func mergeDiags(sources map[string][]lsp.Diagnostic) []lsp.Diagnostic {
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	// keep the order stable between publications
+	sort.Strings(names)
+
+	diags := make([]lsp.Diagnostic, 0)
+	for _, name := range names {
+		diags = append(diags, sources[name]...)
+	}
+	return diags
+}
@@ -0,0 +1,87 @@
+package diagnostics
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"sync"
+	"time"
+)
+
+// Scheduler runs jobs per key (e.g. root module path) after a delay,
+// such that jobs scheduled in quick succession are debounced
+// and any pending or running job is cancelled when a newer one
+// is scheduled for the same key
+type Scheduler struct {
+	delay  time.Duration
+	logger *log.Logger
+
+	jobs   map[string]*scheduledJob
+	jobsMu *sync.Mutex
+}
+
+type scheduledJob struct {
+	cancel context.CancelFunc
+}
+
+func NewScheduler(delay time.Duration) *Scheduler {
+	return &Scheduler{
+		delay:  delay,
+		logger: log.New(ioutil.Discard, "", 0),
+		jobs:   make(map[string]*scheduledJob, 0),
+		jobsMu: &sync.Mutex{},
+	}
+}
+
+func (s *Scheduler) SetLogger(logger *log.Logger) {
+	s.logger = logger
+}
+
+// Schedule runs the job for the given key after the delay
+// unless another job is scheduled for the same key meanwhile,
+// in which case the context passed to the job is cancelled
+func (s *Scheduler) Schedule(ctx context.Context, key string, job func(context.Context)) {
+	ctx, cancel := context.WithCancel(ctx)
+	sj := &scheduledJob{cancel: cancel}
+
+	s.jobsMu.Lock()
+	if previous, ok := s.jobs[key]; ok {
+		s.logger.Printf("cancelling previous job for %s", key)
+		previous.cancel()
+	}
+	s.jobs[key] = sj
+	s.jobsMu.Unlock()
+
+	go func() {
+		defer s.done(key, sj)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.delay):
+		}
+
+		job(ctx)
+	}()
+}
+
+func (s *Scheduler) done(key string, sj *scheduledJob) {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+
+	sj.cancel()
+	if s.jobs[key] == sj {
+		delete(s.jobs, key)
+	}
+}
+
+// Stop cancels all pending and running jobs
+func (s *Scheduler) Stop() {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+
+	for key, sj := range s.jobs {
+		sj.cancel()
+		delete(s.jobs, key)
+	}
+}
@@ -0,0 +1,92 @@
+package diagnostics
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestScheduler_debounce(t *testing.T) {
+	s := NewScheduler(50 * time.Millisecond)
+	defer s.Stop()
+
+	var mu sync.Mutex
+	runs := make([]int, 0)
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	for i := 0; i < 3; i++ {
+		i := i
+		s.Schedule(context.Background(), "/rootmodule", func(ctx context.Context) {
+			mu.Lock()
+			runs = append(runs, i)
+			mu.Unlock()
+			wg.Done()
+		})
+	}
+
+	wg.Wait()
+	// give any other (unexpected) jobs a chance to run
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(runs) != 1 || runs[0] != 2 {
+		t.Fatalf("expected only the last job to run, given: %v", runs)
+	}
+}
+
+func TestScheduler_cancelRunning(t *testing.T) {
+	s := NewScheduler(0)
+	defer s.Stop()
+
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+	s.Schedule(context.Background(), "/rootmodule", func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+		close(cancelled)
+	})
+	<-started
+
+	done := make(chan struct{})
+	s.Schedule(context.Background(), "/rootmodule", func(ctx context.Context) {
+		close(done)
+	})
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("expected running job to be cancelled")
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected newer job to run")
+	}
+}
+
+func TestScheduler_keys(t *testing.T) {
+	s := NewScheduler(0)
+	defer s.Stop()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	for _, key := range []string{"/first", "/second"} {
+		s.Schedule(context.Background(), key, func(ctx context.Context) {
+			wg.Done()
+		})
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected jobs for different keys to run")
+	}
+}
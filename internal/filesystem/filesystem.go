@@ -3,6 +3,9 @@ package filesystem
 import (
 	"io/ioutil"
 	"log"
+	"os"
+	"path/filepath"
+	"sort"
 	"sync"
 )
 
@@ -82,6 +85,63 @@ func (fs *fsystem) GetFile(fh FileHandler) (File, error) {
 	return f, nil
 }
 
+// ReadFile returns content of the file at the given path,
+// preferring the in-memory content of an open document
+// over what is saved on disk
+func (fs *fsystem) ReadFile(path string) ([]byte, error) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+
+	d, ok := fs.dirs[filepath.Dir(path)]
+	if ok {
+		f, ok := d.files[filepath.Base(path)]
+		if ok && f.open {
+			return f.content, nil
+		}
+	}
+
+	return ioutil.ReadFile(path)
+}
+
+// ReadDir returns sorted names of files in the given directory,
+// including open documents which may not be saved on disk yet
+func (fs *fsystem) ReadDir(path string) ([]string, error) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+
+	names := make([]string, 0)
+	seen := make(map[string]bool, 0)
+
+	infos, err := ioutil.ReadDir(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, fi := range infos {
+		if fi.IsDir() {
+			continue
+		}
+		names = append(names, fi.Name())
+		seen[fi.Name()] = true
+	}
+
+	d, ok := fs.dirs[filepath.Clean(path)]
+	if ok {
+		for name, f := range d.files {
+			if f.open && !seen[name] {
+				names = append(names, name)
+			}
+		}
+	}
+
+	if err != nil && len(names) == 0 {
+		return nil, err
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
 func (fs *fsystem) file(fh FileHandler) *file {
 	d, ok := fs.dirs[fh.Dir()]
 	if !ok {
@@ -1,6 +1,9 @@
 package filesystem
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -160,6 +163,69 @@ func TestFilesystem_GetFile_unopenedFile(t *testing.T) {
 	}
 }
 
+func TestFilesystem_ReadFile_ReadDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tfls-fs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+
+	err = ioutil.WriteFile(filepath.Join(dir, "saved.tf"), []byte("saved"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "modified.tf"), []byte("original"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Mkdir(filepath.Join(dir, "subdir"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := NewFilesystem()
+	err = fs.Open(NewFile(filepath.Join(dir, "modified.tf"), []byte("modified")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = fs.Open(NewFile(filepath.Join(dir, "unsaved.tf"), []byte("unsaved")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names, err := fs.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedNames := []string{"modified.tf", "saved.tf", "unsaved.tf"}
+	if diff := cmp.Diff(expectedNames, names); diff != "" {
+		t.Fatalf("Names don't match: %s", diff)
+	}
+
+	expectedContent := map[string]string{
+		"modified.tf": "modified",
+		"saved.tf":    "saved",
+		"unsaved.tf":  "unsaved",
+	}
+	for name, expected := range expectedContent {
+		b, err := fs.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != expected {
+			t.Fatalf("Content of %s doesn't match.\nexpected: %q\ngiven: %q",
+				name, expected, string(b))
+		}
+	}
+
+	_, err = fs.ReadDir(filepath.Join(dir, "doesnotexist"))
+	if !os.IsNotExist(err) {
+		t.Fatalf("Expected not exist error, given: %#v", err)
+	}
+}
+
 type testFile struct {
 	*testHandler
 	text string
@@ -41,4 +41,6 @@ type Filesystem interface {
 	Change(VersionedFileHandler, FileChanges) error
 	Close(FileHandler) error
 	GetFile(FileHandler) (File, error)
+	ReadFile(path string) ([]byte, error)
+	ReadDir(path string) ([]string, error)
 }
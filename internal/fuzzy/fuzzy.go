@@ -0,0 +1,73 @@
+// Package fuzzy implements fuzzy matching of queries
+// against symbol names, such as module.vpc.aws_subnet.private
+package fuzzy
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// bonus for a character matching right after
+	// a previously matched character
+	consecutiveBonus = 5
+
+	// bonus for a character matching at the start
+	// of a segment (after one of the separators)
+	segmentStartBonus = 3
+
+	// penalty for each character skipped between
+	// two matched characters
+	gapPenalty = 1
+)
+
+const separators = "._-/"
+
+// Match reports whether all characters of the pattern appear
+// in the target in the same order (case-insensitively)
+// and returns a score which is higher for better matches.
+//
+// Matches of consecutive characters and matches at the start
+// of address segments (e.g. "avpc" in aws_vpc.main) are preferred.
+// Empty pattern matches everything with zero score.
+func Match(pattern, target string) (int, bool) {
+	if pattern == "" {
+		return 0, true
+	}
+
+	p := []rune(strings.ToLower(pattern))
+	t := []rune(strings.ToLower(target))
+
+	score := 0
+	pi := 0
+	lastMatch := -1
+	for ti := 0; ti < len(t) && pi < len(p); ti++ {
+		if t[ti] != p[pi] {
+			continue
+		}
+
+		score++
+		if lastMatch >= 0 {
+			if lastMatch == ti-1 {
+				score += consecutiveBonus
+			} else {
+				score -= (ti - lastMatch - 1) * gapPenalty
+			}
+		}
+		if ti == 0 || strings.ContainsRune(separators, t[ti-1]) {
+			score += segmentStartBonus
+		}
+
+		lastMatch = ti
+		pi++
+	}
+
+	if pi < len(p) {
+		return 0, false
+	}
+
+	// prefer shorter targets among otherwise equal matches
+	score -= utf8.RuneCountInString(target) - len(p)
+
+	return score, true
+}
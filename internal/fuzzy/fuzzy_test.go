@@ -0,0 +1,61 @@
+package fuzzy
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	testCases := []struct {
+		pattern, target string
+		expectMatch     bool
+	}{
+		{"", "aws_vpc.main", true},
+		{"vpc", "aws_vpc.main", true},
+		{"VPC", "aws_vpc.main", true},
+		{"mvpcpriv", "module.vpc.aws_subnet.private", true},
+		{"subnet.private", "module.vpc.aws_subnet.private", true},
+		{"privatesubnet", "module.vpc.aws_subnet.private", false},
+		{"vpcs", "aws_vpc.main", false},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.pattern), func(t *testing.T) {
+			_, ok := Match(tc.pattern, tc.target)
+			if ok != tc.expectMatch {
+				t.Fatalf("expected match: %t, given: %t", tc.expectMatch, ok)
+			}
+		})
+	}
+}
+
+func TestMatch_ranking(t *testing.T) {
+	testCases := []struct {
+		pattern       string
+		better, worse string
+	}{
+		// consecutive characters
+		{"private", "aws_subnet.private", "aws_instance.pri_vpc_gate"},
+		// start of segments
+		{"asp", "aws_subnet.private", "data.aws_caller_identity.peer"},
+		// shorter target
+		{"vpc", "aws_vpc.main", "module.vpc.aws_vpc.main"},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.pattern), func(t *testing.T) {
+			betterScore, ok := Match(tc.pattern, tc.better)
+			if !ok {
+				t.Fatalf("expected %q to match %q", tc.pattern, tc.better)
+			}
+			worseScore, ok := Match(tc.pattern, tc.worse)
+			if !ok {
+				t.Fatalf("expected %q to match %q", tc.pattern, tc.worse)
+			}
+			if betterScore <= worseScore {
+				t.Fatalf("expected %q (%d) to score higher than %q (%d)",
+					tc.better, betterScore, tc.worse, worseScore)
+			}
+		})
+	}
+}
@@ -16,8 +16,9 @@ type file struct {
 }
 
 type parsedFile struct {
-	Body   hcllib.Body
-	Tokens hclsyntax.Tokens
+	Body        hcllib.Body
+	Tokens      hclsyntax.Tokens
+	Diagnostics hcllib.Diagnostics
 }
 
 type parsedBlock struct {
@@ -65,18 +66,15 @@ func (f *file) parse() (*parsedFile, error) {
 	var parseDiags hcllib.Diagnostics
 
 	tokens, diags := hclsyntax.LexConfig(f.content, f.filename, hcllib.InitialPos)
-	if diags.HasErrors() {
-		parseDiags = append(parseDiags, diags...)
-	}
+	parseDiags = append(parseDiags, diags...)
 
 	body, diags := hclsyntax.ParseBodyFromTokens(tokens, hclsyntax.TokenEOF)
-	if diags.HasErrors() {
-		parseDiags = append(parseDiags, diags...)
-	}
+	parseDiags = append(parseDiags, diags...)
 
 	f.pf = &parsedFile{
-		Tokens: tokens,
-		Body:   body,
+		Tokens:      tokens,
+		Body:        body,
+		Diagnostics: parseDiags,
 	}
 
 	if parseDiags.HasErrors() {
@@ -85,6 +83,13 @@ func (f *file) parse() (*parsedFile, error) {
 	return f.pf, nil
 }
 
+// Diagnostics returns all diagnostics (errors and warnings)
+// produced while parsing the file
+func (f *file) Diagnostics() hcllib.Diagnostics {
+	pf, _ := f.parse()
+	return pf.Diagnostics
+}
+
 func (f *file) PosInBlock(pos hcl.Pos) bool {
 	_, err := f.BlockAtPosition(pos)
 	if IsNoBlockFoundErr(err) {
@@ -123,6 +128,24 @@ func (f *file) BlockAtPosition(pos hcllib.Pos) (TokenizedBlock, error) {
 	return nil, &NoBlockFoundErr{pos}
 }
 
+// Blocks returns all top-level blocks declared in the file
+func (f *file) Blocks() ([]TokenizedBlock, error) {
+	pf, _ := f.parse()
+
+	body, ok := pf.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, fmt.Errorf("unexpected body type (%T)", body)
+	}
+
+	blocks := make([]TokenizedBlock, len(body.Blocks))
+	for i, block := range body.Blocks {
+		dt := definitionTokens(tokensInRange(pf.Tokens, block.Range()))
+		blocks[i] = &parsedBlock{dt}
+	}
+
+	return blocks, nil
+}
+
 func (f *file) TokenAtPosition(pos hcllib.Pos) (hclsyntax.Token, error) {
 	pf, _ := f.parse()
 
@@ -261,6 +261,64 @@ provider "aws" {
 	}
 }
 
+func TestFile_Diagnostics(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		content       string
+		expectedDiags hcl.Diagnostics
+	}{
+		{
+			"valid config",
+			`provider "aws" {
+}
+`,
+			hcl.Diagnostics{},
+		},
+		{
+			"unclosed block",
+			`provider "aws" {`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Argument or block definition required",
+					Detail:   "An argument or block definition is required here.",
+				},
+			},
+		},
+		{
+			"invalid attribute",
+			`provider "aws" {
+  region =
+}
+`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid expression",
+					Detail:   "Expected the start of an expression, but found an invalid expression token.",
+				},
+			},
+		},
+	}
+
+	opts := cmp.Options{
+		cmpopts.IgnoreFields(hcl.Diagnostic{}, "Subject", "Context"),
+		cmpopts.EquateEmpty(),
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i+1, tc.name), func(t *testing.T) {
+			f := NewTestFile([]byte(tc.content))
+
+			diags := f.Diagnostics()
+			if diff := cmp.Diff(tc.expectedDiags, diags, opts...); diff != "" {
+				t.Fatalf("Unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
+
 type testPosition struct {
 	filesystem.FileHandler
 	pos hcl.Pos
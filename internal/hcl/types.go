@@ -7,8 +7,10 @@ import (
 
 type TokenizedFile interface {
 	BlockAtPosition(hcl.Pos) (TokenizedBlock, error)
+	Blocks() ([]TokenizedBlock, error)
 	TokenAtPosition(hcl.Pos) (hclsyntax.Token, error)
 	PosInBlock(hcl.Pos) bool
+	Diagnostics() hcl.Diagnostics
 }
 
 type TokenizedBlock interface {
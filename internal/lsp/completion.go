@@ -6,9 +6,29 @@ import (
 	lsp "github.com/sourcegraph/go-lsp"
 )
 
-func CompletionList(candidates lang.CompletionCandidates, pos hcl.Pos, caps lsp.TextDocumentClientCapabilities) lsp.CompletionList {
+// CompletionListResult represents lsp.CompletionList
+// with items documented via MarkupContent
+type CompletionListResult struct {
+	IsIncomplete bool                   `json:"isIncomplete"`
+	Items        []CompletionItemResult `json:"items"`
+}
+
+// CompletionItemResult represents lsp.CompletionItem with documentation
+// as either MarkupContent or string (for older clients),
+// as the former is not available in go-lsp
+type CompletionItemResult struct {
+	Label            string                 `json:"label"`
+	Kind             lsp.CompletionItemKind `json:"kind,omitempty"`
+	Detail           string                 `json:"detail,omitempty"`
+	Documentation    interface{}            `json:"documentation,omitempty"`
+	InsertTextFormat lsp.InsertTextFormat   `json:"insertTextFormat,omitempty"`
+	TextEdit         *lsp.TextEdit          `json:"textEdit,omitempty"`
+}
+
+func CompletionList(candidates lang.CompletionCandidates, pos hcl.Pos, caps lsp.TextDocumentClientCapabilities) CompletionListResult {
 	snippetSupport := caps.Completion.CompletionItem.SnippetSupport
-	list := lsp.CompletionList{}
+	docFormats := caps.Completion.CompletionItem.DocumentationFormat
+	list := CompletionListResult{}
 
 	if candidates == nil {
 		return list
@@ -17,42 +37,49 @@ func CompletionList(candidates lang.CompletionCandidates, pos hcl.Pos, caps lsp.
 	cList := candidates.List()
 
 	list.IsIncomplete = !candidates.IsComplete()
-	list.Items = make([]lsp.CompletionItem, len(cList))
+	list.Items = make([]CompletionItemResult, len(cList))
 	for i, c := range cList {
-		list.Items[i] = CompletionItem(c, pos, snippetSupport)
+		list.Items[i] = CompletionItem(c, pos, snippetSupport, docFormats)
 	}
 
 	return list
 }
 
-func CompletionItem(candidate lang.CompletionCandidate, pos hcl.Pos, snippetSupport bool) lsp.CompletionItem {
+func CompletionItem(candidate lang.CompletionCandidate, pos hcl.Pos, snippetSupport bool,
+	docFormats []lsp.DocumentationFormat) CompletionItemResult {
 	// TODO: deprecated / tags?
 
-	doc := ""
-	if c := candidate.Documentation(); c != nil {
-		// TODO: markdown handling
-		doc = c.Value()
-	}
-
-	if snippetSupport {
-		return lsp.CompletionItem{
-			Label:            candidate.Label(),
-			Kind:             lsp.CIKField,
-			InsertTextFormat: lsp.ITFSnippet,
-			Detail:           candidate.Detail(),
-			Documentation:    doc,
-			TextEdit:         textEdit(candidate.Snippet(), pos),
-		}
-	}
-
-	return lsp.CompletionItem{
+	item := CompletionItemResult{
 		Label:            candidate.Label(),
 		Kind:             lsp.CIKField,
 		InsertTextFormat: lsp.ITFPlainText,
 		Detail:           candidate.Detail(),
-		Documentation:    doc,
 		TextEdit:         textEdit(candidate.PlainText(), pos),
 	}
+	if snippetSupport {
+		item.InsertTextFormat = lsp.ITFSnippet
+		item.TextEdit = textEdit(candidate.Snippet(), pos)
+	}
+
+	if c := candidate.Documentation(); c != nil && c.Value() != "" {
+		item.Documentation = completionDocumentation(c, docFormats)
+	}
+
+	return item
+}
+
+// completionDocumentation converts the documentation into MarkupContent
+// if the client declares supported formats, or into a string otherwise
+func completionDocumentation(doc lang.MarkupContent, docFormats []lsp.DocumentationFormat) interface{} {
+	if len(docFormats) == 0 {
+		return plainText(doc)
+	}
+
+	formats := make([]string, len(docFormats))
+	for i, f := range docFormats {
+		formats[i] = string(f)
+	}
+	return markupContent(doc, formats)
 }
 
 func textEdit(te lang.TextEdit, pos hcl.Pos) *lsp.TextEdit {
@@ -0,0 +1,39 @@
+package lsp
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	lsp "github.com/sourcegraph/go-lsp"
+)
+
+// HCLSeverityToLSP converts the severity, treating any unknown
+// severity (e.g. zero value hcl.DiagInvalid) as an error
+func HCLSeverityToLSP(severity hcl.DiagnosticSeverity) lsp.DiagnosticSeverity {
+	switch severity {
+	case hcl.DiagWarning:
+		return lsp.Warning
+	default:
+		return lsp.Error
+	}
+}
+
+func HCLDiagsToLSP(hclDiags hcl.Diagnostics, source string) []lsp.Diagnostic {
+	diags := []lsp.Diagnostic{}
+
+	for _, hclDiag := range hclDiags {
+		msg := hclDiag.Summary
+		if hclDiag.Detail != "" {
+			msg += ": " + hclDiag.Detail
+		}
+		var rnge lsp.Range
+		if hclDiag.Subject != nil {
+			rnge = hclRangeToLSP(*hclDiag.Subject)
+		}
+		diags = append(diags, lsp.Diagnostic{
+			Range:    rnge,
+			Severity: HCLSeverityToLSP(hclDiag.Severity),
+			Source:   source,
+			Message:  msg,
+		})
+	}
+	return diags
+}
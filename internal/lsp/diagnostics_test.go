@@ -0,0 +1,64 @@
+package lsp
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	lsp "github.com/sourcegraph/go-lsp"
+)
+
+func TestHCLDiagsToLSP_NeverReturnsNil(t *testing.T) {
+	diags := HCLDiagsToLSP(nil, "test")
+	if diags == nil {
+		t.Fatal("HCLDiagsToLSP should never return nil")
+	}
+}
+
+func TestHCLDiagsToLSP(t *testing.T) {
+	hclDiags := hcl.Diagnostics{
+		{
+			Severity: hcl.DiagError,
+			Summary:  "Argument or block definition required",
+			Detail:   "An argument or block definition is required here.",
+			Subject: &hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 2, Column: 3, Byte: 20},
+				End:      hcl.Pos{Line: 2, Column: 4, Byte: 21},
+			},
+		},
+		{
+			Severity: hcl.DiagWarning,
+			Summary:  "Something is off",
+		},
+	}
+
+	expectedDiags := []lsp.Diagnostic{
+		{
+			Range: lsp.Range{
+				Start: lsp.Position{Line: 1, Character: 2},
+				End:   lsp.Position{Line: 1, Character: 3},
+			},
+			Severity: lsp.Error,
+			Source:   "HCL",
+			Message:  "Argument or block definition required: An argument or block definition is required here.",
+		},
+		{
+			Severity: lsp.Warning,
+			Source:   "HCL",
+			Message:  "Something is off",
+		},
+	}
+
+	diags := HCLDiagsToLSP(hclDiags, "HCL")
+	if diff := cmp.Diff(expectedDiags, diags); diff != "" {
+		t.Fatalf("Diagnostics don't match.\n%s", diff)
+	}
+}
+
+func TestHCLSeverityToLSP_invalid(t *testing.T) {
+	sev := HCLSeverityToLSP(hcl.DiagInvalid)
+	if sev != lsp.Error {
+		t.Fatalf("expected invalid severity to be an error, given: %v", sev)
+	}
+}
@@ -0,0 +1,37 @@
+package lsp
+
+import (
+	"github.com/hashicorp/terraform-ls/internal/terraform/lang"
+	lsp "github.com/sourcegraph/go-lsp"
+)
+
+// HoverResult represents lsp.Hover with contents as either
+// MarkupContent or marked strings (for older clients),
+// as the former is not available in go-lsp
+type HoverResult struct {
+	Contents interface{} `json:"contents"`
+	Range    *lsp.Range  `json:"range,omitempty"`
+}
+
+// Hover converts the hover data into MarkupContent if the client
+// declares supported content formats, or into a marked string otherwise
+func Hover(hd *lang.HoverData, caps lsp.TextDocumentClientCapabilities) HoverResult {
+	if hd == nil || hd.Content == nil {
+		return HoverResult{}
+	}
+
+	hover := HoverResult{}
+	if caps.Hover != nil && len(caps.Hover.ContentFormat) > 0 {
+		hover.Contents = markupContent(hd.Content, caps.Hover.ContentFormat)
+	} else {
+		hover.Contents = []lsp.MarkedString{
+			lsp.RawMarkedString(plainText(hd.Content)),
+		}
+	}
+	if hd.Range != nil {
+		rng := hclRangeToLSP(*hd.Range)
+		hover.Range = &rng
+	}
+
+	return hover
+}
@@ -0,0 +1,60 @@
+package lsp
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-ls/internal/terraform/lang"
+	lsp "github.com/sourcegraph/go-lsp"
+)
+
+func TestHover(t *testing.T) {
+	testCases := []struct {
+		content        lang.MarkupContent
+		contentFormats []string
+		expectedJSON   string
+	}{
+		{
+			lang.Markdown("Size in **GB**"),
+			nil,
+			`{"contents":["Size in GB"]}`,
+		},
+		{
+			lang.Markdown("Size in **GB**"),
+			[]string{"plaintext"},
+			`{"contents":{"kind":"plaintext","value":"Size in GB"}}`,
+		},
+		{
+			lang.Markdown("Size in **GB**"),
+			[]string{"markdown", "plaintext"},
+			`{"contents":{"kind":"markdown","value":"Size in **GB**"}}`,
+		},
+		{
+			lang.PlainText("Size in GB"),
+			[]string{"markdown", "plaintext"},
+			`{"contents":{"kind":"plaintext","value":"Size in GB"}}`,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			caps := lsp.TextDocumentClientCapabilities{}
+			if tc.contentFormats != nil {
+				caps.Hover = &struct {
+					ContentFormat []string `json:"contentFormat,omitempty"`
+				}{ContentFormat: tc.contentFormats}
+			}
+
+			hover := Hover(&lang.HoverData{Content: tc.content}, caps)
+			b, err := json.Marshal(hover)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedJSON, string(b)); diff != "" {
+				t.Fatalf("Hover doesn't match.\n%s", diff)
+			}
+		})
+	}
+}
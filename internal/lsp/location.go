@@ -0,0 +1,16 @@
+package lsp
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
+	lsp "github.com/sourcegraph/go-lsp"
+)
+
+// HCLRangeToLocation converts range to location, assuming
+// the range refers to a file via absolute path
+func HCLRangeToLocation(rng hcl.Range) lsp.Location {
+	return lsp.Location{
+		URI:   lsp.DocumentURI(filesystem.URIFromPath(rng.Filename)),
+		Range: hclRangeToLSP(rng),
+	}
+}
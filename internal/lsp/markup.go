@@ -0,0 +1,53 @@
+package lsp
+
+import (
+	"github.com/hashicorp/terraform-ls/internal/mdplain"
+	"github.com/hashicorp/terraform-ls/internal/terraform/lang"
+)
+
+// MarkupContent represents formatted content (e.g. documentation)
+// which is not available in go-lsp
+type MarkupContent struct {
+	Kind  lang.MarkupKind `json:"kind"`
+	Value string          `json:"value"`
+}
+
+// markupContent converts the content into MarkupContent,
+// turning markdown into plain text unless the client supports it
+func markupContent(content lang.MarkupContent, formats []string) MarkupContent {
+	if content.Kind() == lang.MarkdownKind {
+		if supportsMarkdown(formats) {
+			return MarkupContent{
+				Kind:  lang.MarkdownKind,
+				Value: content.Value(),
+			}
+		}
+		return MarkupContent{
+			Kind:  lang.PlainTextKind,
+			Value: mdplain.Clean(content.Value()),
+		}
+	}
+
+	return MarkupContent{
+		Kind:  lang.PlainTextKind,
+		Value: content.Value(),
+	}
+}
+
+// plainText returns the content as plain text for clients
+// which don't support MarkupContent at all
+func plainText(content lang.MarkupContent) string {
+	if content.Kind() == lang.MarkdownKind {
+		return mdplain.Clean(content.Value())
+	}
+	return content.Value()
+}
+
+func supportsMarkdown(formats []string) bool {
+	for _, f := range formats {
+		if f == string(lang.MarkdownKind) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package lsp
+
+import (
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
+	lsp "github.com/sourcegraph/go-lsp"
+)
+
+// PrepareRenameResult represents the result of textDocument/prepareRename
+// which is not available in go-lsp
+type PrepareRenameResult struct {
+	Range       lsp.Range `json:"range"`
+	Placeholder string    `json:"placeholder"`
+}
+
+func PrepareRename(rng hcl.Range, placeholder string) *PrepareRenameResult {
+	return &PrepareRenameResult{
+		Range:       hclRangeToLSP(rng),
+		Placeholder: placeholder,
+	}
+}
+
+// RenameEdit converts ranges to a workspace edit replacing text
+// of each range with newText, assuming the ranges refer to files
+// via absolute paths
+func RenameEdit(rngs []hcl.Range, newText string) lsp.WorkspaceEdit {
+	edit := lsp.WorkspaceEdit{
+		Changes: make(map[string][]lsp.TextEdit, 0),
+	}
+
+	sorted := make([]hcl.Range, len(rngs))
+	copy(sorted, rngs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Start.Byte < sorted[j].Start.Byte
+	})
+
+	for _, rng := range sorted {
+		uri := filesystem.URIFromPath(rng.Filename)
+		edit.Changes[uri] = append(edit.Changes[uri], lsp.TextEdit{
+			Range:   hclRangeToLSP(rng),
+			NewText: newText,
+		})
+	}
+
+	return edit
+}
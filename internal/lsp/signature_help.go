@@ -0,0 +1,32 @@
+package lsp
+
+import (
+	"github.com/hashicorp/terraform-ls/internal/terraform/lang"
+	lsp "github.com/sourcegraph/go-lsp"
+)
+
+func SignatureHelp(sd *lang.SignatureData) *lsp.SignatureHelp {
+	if sd == nil || sd.Function == nil {
+		return nil
+	}
+
+	labels := sd.Function.ParamLabels()
+	params := make([]lsp.ParameterInformation, len(labels))
+	for i, label := range labels {
+		params[i] = lsp.ParameterInformation{
+			Label: label,
+		}
+	}
+
+	return &lsp.SignatureHelp{
+		Signatures: []lsp.SignatureInformation{
+			{
+				Label:         sd.Function.Label(),
+				Documentation: sd.Function.Description,
+				Parameters:    params,
+			},
+		},
+		ActiveSignature: 0,
+		ActiveParameter: sd.ActiveParameter,
+	}
+}
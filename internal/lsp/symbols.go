@@ -0,0 +1,72 @@
+package lsp
+
+import (
+	"github.com/hashicorp/terraform-ls/internal/terraform/lang"
+	lsp "github.com/sourcegraph/go-lsp"
+)
+
+// DocumentSymbol represents a hierarchical symbol
+// which is not available in go-lsp
+type DocumentSymbol struct {
+	Name           string           `json:"name"`
+	Detail         string           `json:"detail,omitempty"`
+	Kind           lsp.SymbolKind   `json:"kind"`
+	Range          lsp.Range        `json:"range"`
+	SelectionRange lsp.Range        `json:"selectionRange"`
+	Children       []DocumentSymbol `json:"children"`
+}
+
+var symbolKinds = map[lang.SymbolKind]lsp.SymbolKind{
+	lang.SymbolKindBlock:      lsp.SKStruct,
+	lang.SymbolKindResource:   lsp.SKClass,
+	lang.SymbolKindDataSource: lsp.SKClass,
+	lang.SymbolKindProvider:   lsp.SKPackage,
+	lang.SymbolKindVariable:   lsp.SKVariable,
+	lang.SymbolKindOutput:     lsp.SKProperty,
+	lang.SymbolKindLocals:     lsp.SKNamespace,
+	lang.SymbolKindLocal:      lsp.SKVariable,
+	lang.SymbolKindModule:     lsp.SKModule,
+	lang.SymbolKindTerraform:  lsp.SKNamespace,
+}
+
+func SymbolKind(kind lang.SymbolKind) lsp.SymbolKind {
+	return symbolKinds[kind]
+}
+
+func DocumentSymbols(symbols []*lang.Symbol) []DocumentSymbol {
+	docSymbols := make([]DocumentSymbol, len(symbols))
+	for i, s := range symbols {
+		docSymbols[i] = DocumentSymbol{
+			Name:           s.Name,
+			Detail:         s.Detail,
+			Kind:           symbolKinds[s.Kind],
+			Range:          hclRangeToLSP(s.Range),
+			SelectionRange: hclRangeToLSP(s.SelectionRange),
+			Children:       DocumentSymbols(s.Children),
+		}
+	}
+	return docSymbols
+}
+
+// SymbolInformation flattens symbols for clients which
+// do not support hierarchical document symbols
+func SymbolInformation(symbols []*lang.Symbol, uri lsp.DocumentURI) []lsp.SymbolInformation {
+	return appendSymbolInformation(make([]lsp.SymbolInformation, 0), symbols, uri, "")
+}
+
+func appendSymbolInformation(infos []lsp.SymbolInformation, symbols []*lang.Symbol,
+	uri lsp.DocumentURI, containerName string) []lsp.SymbolInformation {
+	for _, s := range symbols {
+		infos = append(infos, lsp.SymbolInformation{
+			Name: s.Name,
+			Kind: symbolKinds[s.Kind],
+			Location: lsp.Location{
+				URI:   uri,
+				Range: hclRangeToLSP(s.Range),
+			},
+			ContainerName: containerName,
+		})
+		infos = appendSymbolInformation(infos, s.Children, uri, s.Name)
+	}
+	return infos
+}
@@ -0,0 +1,233 @@
+package progress
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"sync"
+	"time"
+)
+
+// Client represents the ability to make requests to
+// and send notifications to the language client
+type Client interface {
+	Call(ctx context.Context, method string, params interface{}) (json.RawMessage, error)
+	Notify(method string, params interface{}) error
+}
+
+// createTimeout is how long to wait for the client
+// to acknowledge creation of a progress token
+var createTimeout = 5 * time.Second
+
+// Notifier reports progress of long-running operations to the client
+// via window/workDoneProgress/create and $/progress notifications.
+//
+// Progress is only reported if enabled (i.e. if the client supports it)
+// and notifications are held back until the notifier is started,
+// as the server may not send requests before initialization.
+type Notifier struct {
+	client Client
+	logger *log.Logger
+
+	enabled   bool
+	enabledMu *sync.RWMutex
+
+	nextID   int
+	nextIDMu *sync.Mutex
+
+	readyCh   chan struct{}
+	readyOnce *sync.Once
+	stopCh    chan struct{}
+	stopOnce  *sync.Once
+}
+
+func NewNotifier(client Client) *Notifier {
+	return &Notifier{
+		client:    client,
+		logger:    log.New(ioutil.Discard, "", 0),
+		enabledMu: &sync.RWMutex{},
+		nextIDMu:  &sync.Mutex{},
+		readyCh:   make(chan struct{}),
+		readyOnce: &sync.Once{},
+		stopCh:    make(chan struct{}),
+		stopOnce:  &sync.Once{},
+	}
+}
+
+func (n *Notifier) SetLogger(logger *log.Logger) {
+	n.logger = logger
+}
+
+// SetEnabled enables or disables progress reporting,
+// typically based on client capabilities
+func (n *Notifier) SetEnabled(enabled bool) {
+	n.enabledMu.Lock()
+	defer n.enabledMu.Unlock()
+	n.enabled = enabled
+}
+
+func (n *Notifier) isEnabled() bool {
+	n.enabledMu.RLock()
+	defer n.enabledMu.RUnlock()
+	return n.enabled && n.client != nil
+}
+
+// Start allows progress reported so far (and any
+// reported afterwards) to be sent to the client
+func (n *Notifier) Start() {
+	n.readyOnce.Do(func() {
+		close(n.readyCh)
+	})
+}
+
+// Stop discards any progress which was not sent yet
+func (n *Notifier) Stop() {
+	n.stopOnce.Do(func() {
+		close(n.stopCh)
+	})
+}
+
+// Begin starts reporting progress of an operation with the given title.
+// It returns nil if progress reporting is disabled,
+// which is safe to use (no progress will be reported).
+func (n *Notifier) Begin(title, message string) *Progress {
+	if !n.isEnabled() {
+		return nil
+	}
+
+	p := &Progress{
+		token:   n.newToken(),
+		queueMu: &sync.Mutex{},
+		wakeCh:  make(chan struct{}, 1),
+	}
+	p.push(&workDoneProgressBegin{
+		Kind:    "begin",
+		Title:   title,
+		Message: message,
+	})
+
+	go n.run(p)
+
+	return p
+}
+
+func (n *Notifier) newToken() string {
+	n.nextIDMu.Lock()
+	defer n.nextIDMu.Unlock()
+	n.nextID++
+	return fmt.Sprintf("terraform-ls-%d", n.nextID)
+}
+
+func (n *Notifier) run(p *Progress) {
+	select {
+	case <-n.readyCh:
+	case <-n.stopCh:
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), createTimeout)
+	_, err := n.client.Call(ctx, "window/workDoneProgress/create",
+		workDoneProgressCreateParams{Token: p.token})
+	cancel()
+	if err != nil {
+		n.logger.Printf("failed to create progress %s: %s", p.token, err)
+		return
+	}
+
+	for {
+		select {
+		case <-p.wakeCh:
+		case <-n.stopCh:
+			return
+		}
+
+		for _, value := range p.take() {
+			err := n.client.Notify("$/progress", progressParams{
+				Token: p.token,
+				Value: value,
+			})
+			if err != nil {
+				n.logger.Printf("failed to report progress %s: %s", p.token, err)
+			}
+			if _, ok := value.(*workDoneProgressEnd); ok {
+				return
+			}
+		}
+	}
+}
+
+// Progress represents progress of a single operation
+type Progress struct {
+	token string
+
+	queue   []interface{}
+	queueMu *sync.Mutex
+	wakeCh  chan struct{}
+}
+
+// Report reports the current state of the operation
+func (p *Progress) Report(message string) {
+	p.push(&workDoneProgressReport{
+		Kind:    "report",
+		Message: message,
+	})
+}
+
+// End reports the operation as finished, with the message
+// typically describing the result (e.g. an error)
+func (p *Progress) End(message string) {
+	p.push(&workDoneProgressEnd{
+		Kind:    "end",
+		Message: message,
+	})
+}
+
+func (p *Progress) push(value interface{}) {
+	if p == nil {
+		return
+	}
+
+	p.queueMu.Lock()
+	p.queue = append(p.queue, value)
+	p.queueMu.Unlock()
+
+	select {
+	case p.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+func (p *Progress) take() []interface{} {
+	p.queueMu.Lock()
+	defer p.queueMu.Unlock()
+	values := p.queue
+	p.queue = nil
+	return values
+}
+
+type workDoneProgressCreateParams struct {
+	Token string `json:"token"`
+}
+
+type progressParams struct {
+	Token string      `json:"token"`
+	Value interface{} `json:"value"`
+}
+
+type workDoneProgressBegin struct {
+	Kind    string `json:"kind"`
+	Title   string `json:"title"`
+	Message string `json:"message,omitempty"`
+}
+
+type workDoneProgressReport struct {
+	Kind    string `json:"kind"`
+	Message string `json:"message,omitempty"`
+}
+
+type workDoneProgressEnd struct {
+	Kind    string `json:"kind"`
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,125 @@
+package progress
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type mockClient struct {
+	mu       sync.Mutex
+	calls    []string
+	callErr  error
+	messages []string
+	doneCh   chan struct{}
+}
+
+func newMockClient() *mockClient {
+	return &mockClient{doneCh: make(chan struct{}, 10)}
+}
+
+func (c *mockClient) Call(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
+	b, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.calls = append(c.calls, method+" "+string(b))
+	c.mu.Unlock()
+	if c.callErr != nil {
+		c.doneCh <- struct{}{}
+		return nil, c.callErr
+	}
+	return json.RawMessage("null"), nil
+}
+
+func (c *mockClient) Notify(method string, params interface{}) error {
+	b, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.messages = append(c.messages, method+" "+string(b))
+	c.mu.Unlock()
+	if _, ok := params.(progressParams).Value.(*workDoneProgressEnd); ok {
+		c.doneCh <- struct{}{}
+	}
+	return nil
+}
+
+func (c *mockClient) waitForDone(t *testing.T) {
+	select {
+	case <-c.doneCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for progress")
+	}
+}
+
+func TestNotifier_beginReportEnd(t *testing.T) {
+	client := newMockClient()
+	n := NewNotifier(client)
+	n.SetEnabled(true)
+	defer n.Stop()
+
+	p := n.Begin("Loading", "first")
+	p.Report("second")
+	p.End("done")
+
+	n.Start()
+	client.waitForDone(t)
+
+	expectedCalls := []string{
+		`window/workDoneProgress/create {"token":"terraform-ls-1"}`,
+	}
+	if diff := cmp.Diff(expectedCalls, client.calls); diff != "" {
+		t.Fatalf("calls don't match: %s", diff)
+	}
+
+	expectedMessages := []string{
+		`$/progress {"token":"terraform-ls-1","value":{"kind":"begin","title":"Loading","message":"first"}}`,
+		`$/progress {"token":"terraform-ls-1","value":{"kind":"report","message":"second"}}`,
+		`$/progress {"token":"terraform-ls-1","value":{"kind":"end","message":"done"}}`,
+	}
+	if diff := cmp.Diff(expectedMessages, client.messages); diff != "" {
+		t.Fatalf("messages don't match: %s", diff)
+	}
+}
+
+func TestNotifier_disabled(t *testing.T) {
+	client := newMockClient()
+	n := NewNotifier(client)
+	n.Start()
+	defer n.Stop()
+
+	p := n.Begin("Loading", "")
+	if p != nil {
+		t.Fatalf("expected no progress when disabled, given: %#v", p)
+	}
+	// reporting via nil progress is a no-op
+	p.Report("")
+	p.End("")
+}
+
+func TestNotifier_createFailed(t *testing.T) {
+	client := newMockClient()
+	client.callErr = errors.New("unsupported")
+	n := NewNotifier(client)
+	n.SetEnabled(true)
+	n.Start()
+	defer n.Stop()
+
+	p := n.Begin("Loading", "")
+	p.End("done")
+	client.waitForDone(t)
+
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if len(client.messages) != 0 {
+		t.Fatalf("expected no progress messages, given: %q", client.messages)
+	}
+}
@@ -12,6 +12,11 @@ type Options struct {
 	// RootModulePaths describes a list of absolute paths to root modules
 	RootModulePaths []string `mapstructure:"rootModulePaths"`
 
+	// ProviderSchemaFiles describes a list of absolute paths to files
+	// with provider schemas (as generated via terraform providers schema -json)
+	// to use for root modules which have no schemas available otherwise
+	ProviderSchemaFiles []string `mapstructure:"providerSchemaFiles"`
+
 	// TODO: Need to check for conflict with CLI flags
 	// TerraformExecPath string
 	// TerraformExecTimeout time.Duration
@@ -27,6 +32,12 @@ func (o *Options) Validate() error {
 		}
 	}
 
+	for _, p := range o.ProviderSchemaFiles {
+		if !filepath.IsAbs(p) {
+			result = multierror.Append(result, fmt.Errorf("%q is not an absolute path", p))
+		}
+	}
+
 	return result.ErrorOrNil()
 }
 
@@ -52,3 +52,15 @@ func TestDecodedOptions_Validate(t *testing.T) {
 		t.Fatal("expected relative path to fail validation")
 	}
 }
+
+func TestDecodedOptions_Validate_providerSchemaFiles(t *testing.T) {
+	opts := &Options{
+		ProviderSchemaFiles: []string{
+			"./relative/schemas.json",
+		},
+	}
+	err := opts.Validate()
+	if err == nil {
+		t.Fatal("expected relative path to fail validation")
+	}
+}
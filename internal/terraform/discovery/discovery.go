@@ -9,10 +9,25 @@ type DiscoveryFunc func() (string, error)
 
 type Discovery struct{}
 
+// ExecutableNotFoundErr is returned when Terraform
+// cannot be found in any of the directories in PATH
+type ExecutableNotFoundErr struct {
+	Name string
+	Err  error
+}
+
+func (e *ExecutableNotFoundErr) Error() string {
+	return fmt.Sprintf("unable to find %s: %s", e.Name, e.Err)
+}
+
+func (e *ExecutableNotFoundErr) Unwrap() error {
+	return e.Err
+}
+
 func (d *Discovery) LookPath() (string, error) {
 	path, err := exec.LookPath(executableName)
 	if err != nil {
-		return "", fmt.Errorf("unable to find %s: %s", executableName, err)
+		return "", &ExecutableNotFoundErr{Name: executableName, Err: err}
 	}
 	return path, nil
 }
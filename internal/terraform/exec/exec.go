@@ -21,6 +21,10 @@ import (
 
 var defaultExecTimeout = 30 * time.Second
 
+// minInitTimeout is the minimum time given to terraform init
+// as it may need to download providers and modules
+var minInitTimeout = 10 * time.Minute
+
 // We pass through all variables, but longer term we'll need to reflect
 // that some variables might be workspace/directory specific
 // and passing through these may be dangerous once the LS
@@ -48,6 +52,7 @@ type Executor struct {
 
 type command struct {
 	Cmd          *exec.Cmd
+	Timeout      time.Duration
 	Context      context.Context
 	CancelFunc   context.CancelFunc
 	StdoutBuffer *bytes.Buffer
@@ -86,13 +91,17 @@ func (e *Executor) GetExecPath() string {
 }
 
 func (e *Executor) cmd(ctx context.Context, args ...string) (*command, error) {
+	return e.cmdWithTimeout(ctx, e.timeout, args...)
+}
+
+func (e *Executor) cmdWithTimeout(ctx context.Context, timeout time.Duration, args ...string) (*command, error) {
 	if e.workDir == "" {
 		return nil, fmt.Errorf("no work directory set")
 	}
 
 	cancel := func() {}
-	if e.timeout > 0 {
-		ctx, cancel = context.WithTimeout(ctx, e.timeout)
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
 	}
 
 	var outBuf bytes.Buffer
@@ -118,6 +127,7 @@ func (e *Executor) cmd(ctx context.Context, args ...string) (*command, error) {
 		if err != nil {
 			return &command{
 				Cmd:          cmd,
+				Timeout:      timeout,
 				Context:      ctx,
 				CancelFunc:   cancel,
 				StdoutBuffer: &outBuf,
@@ -131,6 +141,7 @@ func (e *Executor) cmd(ctx context.Context, args ...string) (*command, error) {
 	}
 	return &command{
 		Cmd:          cmd,
+		Timeout:      timeout,
 		Context:      ctx,
 		CancelFunc:   cancel,
 		StdoutBuffer: &outBuf,
@@ -153,7 +164,7 @@ func (e *Executor) waitCmd(command *command) ([]byte, error) {
 
 			ctxErr := command.Context.Err()
 			if errors.Is(ctxErr, context.DeadlineExceeded) {
-				exitErr.CtxErr = ExecTimeoutError(args, e.timeout)
+				exitErr.CtxErr = ExecTimeoutError(args, command.Timeout)
 			}
 			if errors.Is(ctxErr, context.Canceled) {
 				exitErr.CtxErr = ExecCanceledError(args)
@@ -184,8 +195,12 @@ func (e *Executor) runCmd(command *command) ([]byte, error) {
 }
 
 func (e *Executor) run(ctx context.Context, args ...string) ([]byte, error) {
-	cmd, err := e.cmd(ctx, args...)
-	e.logger.Printf("running with timeout %s", e.timeout)
+	return e.runWithTimeout(ctx, e.timeout, args...)
+}
+
+func (e *Executor) runWithTimeout(ctx context.Context, timeout time.Duration, args ...string) ([]byte, error) {
+	cmd, err := e.cmdWithTimeout(ctx, timeout, args...)
+	e.logger.Printf("running with timeout %s", timeout)
 	defer cmd.CancelFunc()
 	if err != nil {
 		return nil, err
@@ -292,9 +307,9 @@ func (e *Executor) VersionIsSupported(ctx context.Context, c version.Constraints
 }
 
 func (e *Executor) ProviderSchemas(ctx context.Context) (*tfjson.ProviderSchemas, error) {
-	outBytes, err := e.run(ctx, "providers", "schema", "-json")
+	outBytes, err := e.ProviderSchemasJSON(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get schemas: %w", err)
+		return nil, err
 	}
 
 	var schemas tfjson.ProviderSchemas
@@ -305,3 +320,13 @@ func (e *Executor) ProviderSchemas(ctx context.Context) (*tfjson.ProviderSchemas
 
 	return &schemas, nil
 }
+
+// ProviderSchemasJSON returns schemas as JSON, such that
+// these can be decoded per provider when needed
+func (e *Executor) ProviderSchemasJSON(ctx context.Context) ([]byte, error) {
+	outBytes, err := e.run(ctx, "providers", "schema", "-json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schemas: %w", err)
+	}
+	return outBytes, nil
+}
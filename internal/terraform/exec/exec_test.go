@@ -7,6 +7,8 @@ import (
 	"os"
 	"testing"
 	"time"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestExec_timeout(t *testing.T) {
@@ -87,3 +89,62 @@ func TestExec_ProviderSchemas(t *testing.T) {
 			expectedVersion, ps.FormatVersion)
 	}
 }
+
+func TestExec_Validate(t *testing.T) {
+	e := MockExecutor(&MockCall{
+		Args: []string{"validate", "-json"},
+		Stdout: `{"valid":false,"error_count":1,"warning_count":0,"diagnostics":[` +
+			`{"severity":"error","summary":"Missing required argument","detail":"The argument \"ami\" is required.",` +
+			`"range":{"filename":"main.tf","start":{"line":1,"column":28,"byte":27},"end":{"line":1,"column":29,"byte":28}}}]}`,
+		ExitCode: 1,
+	})("")
+	e.SetWorkdir(os.TempDir())
+
+	diags, err := e.Validate(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedDiags := []Diagnostic{
+		{
+			Severity: "error",
+			Summary:  "Missing required argument",
+			Detail:   `The argument "ami" is required.`,
+			Range: &DiagnosticRange{
+				Filename: "main.tf",
+				Start:    DiagnosticPos{Line: 1, Column: 28, Byte: 27},
+				End:      DiagnosticPos{Line: 1, Column: 29, Byte: 28},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedDiags, diags); diff != "" {
+		t.Fatalf("diagnostics don't match: %s", diff)
+	}
+}
+
+func TestExec_Validate_failure(t *testing.T) {
+	e := MockExecutor(&MockCall{
+		Args:     []string{"validate", "-json"},
+		Stderr:   "unknown flag: -json",
+		ExitCode: 1,
+	})("")
+	e.SetWorkdir(os.TempDir())
+
+	_, err := e.Validate(context.Background())
+	if err == nil {
+		t.Fatal("expected validation to fail without output")
+	}
+}
+
+func TestExec_Init(t *testing.T) {
+	e := MockExecutor(&MockCall{
+		Args:   []string{"init", "-input=false", "-no-color"},
+		Stdout: "Terraform has been successfully initialized!\n",
+	})("")
+	e.SetWorkdir(os.TempDir())
+
+	err := e.Init(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+}
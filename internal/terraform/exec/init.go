@@ -0,0 +1,21 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+)
+
+// Init runs terraform init in the working directory,
+// installing any providers and modules required
+func (e *Executor) Init(ctx context.Context) error {
+	timeout := e.timeout
+	if timeout > 0 && timeout < minInitTimeout {
+		timeout = minInitTimeout
+	}
+
+	_, err := e.runWithTimeout(ctx, timeout, "init", "-input=false", "-no-color")
+	if err != nil {
+		return fmt.Errorf("failed to init: %w", err)
+	}
+	return nil
+}
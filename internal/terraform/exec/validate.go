@@ -0,0 +1,62 @@
+package exec
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ValidateOutput represents output of terraform validate -json
+type ValidateOutput struct {
+	Valid        bool         `json:"valid"`
+	ErrorCount   int          `json:"error_count"`
+	WarningCount int          `json:"warning_count"`
+	Diagnostics  []Diagnostic `json:"diagnostics"`
+}
+
+// Diagnostic represents a diagnostic as reported by Terraform
+type Diagnostic struct {
+	// Severity is either "error" or "warning"
+	Severity string           `json:"severity"`
+	Summary  string           `json:"summary"`
+	Detail   string           `json:"detail"`
+	Range    *DiagnosticRange `json:"range,omitempty"`
+}
+
+// DiagnosticRange represents a range within a file,
+// whose name is relative to the working directory
+type DiagnosticRange struct {
+	Filename string        `json:"filename"`
+	Start    DiagnosticPos `json:"start"`
+	End      DiagnosticPos `json:"end"`
+}
+
+type DiagnosticPos struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+	Byte   int `json:"byte"`
+}
+
+// Validate runs terraform validate -json in the working directory
+// and returns diagnostics found in the configuration
+func (e *Executor) Validate(ctx context.Context) ([]Diagnostic, error) {
+	outBytes, err := e.run(ctx, "validate", "-json")
+	if err != nil {
+		// invalid configuration results in non-zero exit code
+		// with diagnostics still reported via stdout
+		var exitErr *ExitError
+		if !errors.As(err, &exitErr) || exitErr.CtxErr != nil || exitErr.Stdout == "" {
+			return nil, fmt.Errorf("failed to validate: %w", err)
+		}
+		outBytes = []byte(exitErr.Stdout)
+	}
+
+	var out ValidateOutput
+	err = json.Unmarshal(outBytes, &out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return out.Diagnostics, nil
+}
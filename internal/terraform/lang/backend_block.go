@@ -0,0 +1,413 @@
+package lang
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/go-version"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// backendBlockType describes backend blocks in general,
+// schema of each block depends on its type (first label)
+var backendBlockType = &tfjson.SchemaBlockType{
+	NestingMode: tfjson.SchemaNestingModeSingle,
+}
+
+var backendLabelSchema = LabelSchema{
+	Label{Name: "type", IsCompletable: true},
+}
+
+// backendsSince maps backend types to the first Terraform version
+// supporting them, backends not listed here are supported
+// by all versions the parser supports
+var backendsSince = map[string]string{
+	"oss":        "0.12.2",
+	"cos":        "0.13.0",
+	"kubernetes": "0.13.0",
+}
+
+// backendsUntil maps backend types to the Terraform version
+// which removed them, backends not listed here are supported
+// by all versions since their introduction
+var backendsUntil = map[string]string{
+	"artifactory": "1.3.0",
+	"etcd":        "1.3.0",
+	"manta":       "1.3.0",
+	"swift":       "1.3.0",
+}
+
+// backendSchemas maps backend types to their schemas.
+// All arguments are optional, as backends support partial
+// configuration, where the rest is provided via -backend-config.
+//
+// The catalogue is maintained by hand and may lag behind Terraform,
+// so unknown backend types and arguments are reported as warnings.
+var backendSchemas = map[string]*tfjson.SchemaBlock{
+	"artifactory": {
+		Description: "Stores the state as an artifact in a given repository in Artifactory.",
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"url":      {AttributeType: cty.String, Optional: true, Description: "The URL of Artifactory, e.g. https://artifactory.example.com/artifactory."},
+			"repo":     {AttributeType: cty.String, Optional: true, Description: "The repository name."},
+			"subpath":  {AttributeType: cty.String, Optional: true, Description: "Path within the repository."},
+			"username": {AttributeType: cty.String, Optional: true, Description: "The username."},
+			"password": {AttributeType: cty.String, Optional: true, Description: "The password."},
+		},
+	},
+	"azurerm": {
+		Description: "Stores the state as a blob with the given key within a blob container on Azure Blob Storage.",
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"storage_account_name": {AttributeType: cty.String, Optional: true, Description: "The name of the storage account."},
+			"container_name":       {AttributeType: cty.String, Optional: true, Description: "The name of the storage container within the storage account."},
+			"key":                  {AttributeType: cty.String, Optional: true, Description: "The name of the blob used to store the state file."},
+			"resource_group_name":  {AttributeType: cty.String, Optional: true, Description: "The name of the resource group containing the storage account."},
+			"environment":          {AttributeType: cty.String, Optional: true, Description: "The Azure environment, e.g. public (default), china, german or usgovernment."},
+			"endpoint":             {AttributeType: cty.String, Optional: true, Description: "A custom endpoint for Azure Resource Manager."},
+			"access_key":           {AttributeType: cty.String, Optional: true, Description: "The access key of the storage account."},
+			"sas_token":            {AttributeType: cty.String, Optional: true, Description: "The SAS token used to access the storage account."},
+			"use_msi":              {AttributeType: cty.Bool, Optional: true, Description: "Whether to authenticate using Managed Service Identity."},
+			"msi_endpoint":         {AttributeType: cty.String, Optional: true, Description: "The path to a custom Managed Service Identity endpoint."},
+			"subscription_id":      {AttributeType: cty.String, Optional: true, Description: "The subscription ID in which the storage account exists."},
+			"tenant_id":            {AttributeType: cty.String, Optional: true, Description: "The tenant ID in which the subscription exists."},
+			"client_id":            {AttributeType: cty.String, Optional: true, Description: "The client ID of the service principal."},
+			"client_secret":        {AttributeType: cty.String, Optional: true, Description: "The client secret of the service principal."},
+			"snapshot":             {AttributeType: cty.Bool, Optional: true, Description: "Whether to snapshot the blob before each state write."},
+		},
+	},
+	"consul": {
+		Description: "Stores the state in the Consul KV store at a given path.",
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"path":         {AttributeType: cty.String, Optional: true, Description: "Path in the Consul KV store."},
+			"address":      {AttributeType: cty.String, Optional: true, Description: "DNS name and port of the Consul endpoint, e.g. localhost:8500."},
+			"scheme":       {AttributeType: cty.String, Optional: true, Description: "Scheme to communicate with the Consul agent, http or https."},
+			"datacenter":   {AttributeType: cty.String, Optional: true, Description: "The datacenter to use."},
+			"http_auth":    {AttributeType: cty.String, Optional: true, Description: "HTTP basic authentication credentials, e.g. user:pass."},
+			"access_token": {AttributeType: cty.String, Optional: true, Description: "Access token for the Consul ACL."},
+			"gzip":         {AttributeType: cty.Bool, Optional: true, Description: "Whether to compress the state data using gzip."},
+			"lock":         {AttributeType: cty.Bool, Optional: true, Description: "Whether to lock the state (enabled by default)."},
+			"ca_file":      {AttributeType: cty.String, Optional: true, Description: "Path to a PEM-encoded CA certificate file."},
+			"cert_file":    {AttributeType: cty.String, Optional: true, Description: "Path to a PEM-encoded client certificate file."},
+			"key_file":     {AttributeType: cty.String, Optional: true, Description: "Path to a PEM-encoded private key file."},
+		},
+	},
+	"cos": {
+		Description: "Stores the state as an object in a given bucket on Tencent Cloud Object Storage.",
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"bucket":     {AttributeType: cty.String, Optional: true, Description: "The name of the COS bucket."},
+			"prefix":     {AttributeType: cty.String, Optional: true, Description: "The directory for saving the state file in the bucket."},
+			"key":        {AttributeType: cty.String, Optional: true, Description: "The path for saving the state file in the bucket."},
+			"region":     {AttributeType: cty.String, Optional: true, Description: "The region of the COS bucket."},
+			"secret_id":  {AttributeType: cty.String, Optional: true, Description: "Secret ID of Tencent Cloud."},
+			"secret_key": {AttributeType: cty.String, Optional: true, Description: "Secret key of Tencent Cloud."},
+			"encrypt":    {AttributeType: cty.Bool, Optional: true, Description: "Whether to enable server side encryption of the state file."},
+			"acl":        {AttributeType: cty.String, Optional: true, Description: "Object ACL to be applied to the state file, private (default) or public-read."},
+		},
+	},
+	"etcd": {
+		Description: "Stores the state in etcd 2.x at a given path.",
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"path":      {AttributeType: cty.String, Optional: true, Description: "The path where to store the state."},
+			"endpoints": {AttributeType: cty.String, Optional: true, Description: "A space-separated list of the etcd endpoints."},
+			"username":  {AttributeType: cty.String, Optional: true, Description: "The username."},
+			"password":  {AttributeType: cty.String, Optional: true, Description: "The password."},
+		},
+	},
+	"etcdv3": {
+		Description: "Stores the state in the etcd KV store with a given prefix.",
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"endpoints":   {AttributeType: cty.List(cty.String), Optional: true, Description: "The list of etcd endpoints to connect to."},
+			"username":    {AttributeType: cty.String, Optional: true, Description: "Username used to connect to the etcd cluster."},
+			"password":    {AttributeType: cty.String, Optional: true, Description: "Password used to connect to the etcd cluster."},
+			"prefix":      {AttributeType: cty.String, Optional: true, Description: "An optional prefix to be added to keys when storing state."},
+			"lock":        {AttributeType: cty.Bool, Optional: true, Description: "Whether to lock the state (enabled by default)."},
+			"cacert_path": {AttributeType: cty.String, Optional: true, Description: "The path to a PEM-encoded CA bundle with which to verify certificates of TLS-enabled etcd servers."},
+			"cert_path":   {AttributeType: cty.String, Optional: true, Description: "The path to a PEM-encoded certificate to provide to etcd for secure client identification."},
+			"key_path":    {AttributeType: cty.String, Optional: true, Description: "The path to a PEM-encoded key to provide to etcd for secure client identification."},
+		},
+	},
+	"gcs": {
+		Description: "Stores the state as an object in a configurable prefix in a given bucket on Google Cloud Storage.",
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"bucket":         {AttributeType: cty.String, Optional: true, Description: "The name of the GCS bucket."},
+			"prefix":         {AttributeType: cty.String, Optional: true, Description: "GCS prefix inside the bucket, named states are stored in objects called <prefix>/<name>.tfstate."},
+			"credentials":    {AttributeType: cty.String, Optional: true, Description: "Local path to Google Cloud Platform account credentials in JSON format."},
+			"access_token":   {AttributeType: cty.String, Optional: true, Description: "A temporary OAuth 2.0 access token obtained from the Google Authorization server."},
+			"encryption_key": {AttributeType: cty.String, Optional: true, Description: "A 32 byte base64 encoded customer-supplied encryption key used to encrypt all state."},
+			"path":           {AttributeType: cty.String, Optional: true, Description: "Deprecated, use prefix instead."},
+		},
+	},
+	"http": {
+		Description: "Stores the state using a simple REST client.",
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"address":                {AttributeType: cty.String, Optional: true, Description: "The address of the REST endpoint."},
+			"update_method":          {AttributeType: cty.String, Optional: true, Description: "HTTP method to use when updating state, POST (default)."},
+			"lock_address":           {AttributeType: cty.String, Optional: true, Description: "The address of the lock REST endpoint."},
+			"lock_method":            {AttributeType: cty.String, Optional: true, Description: "The HTTP method to use when locking, LOCK (default)."},
+			"unlock_address":         {AttributeType: cty.String, Optional: true, Description: "The address of the unlock REST endpoint."},
+			"unlock_method":          {AttributeType: cty.String, Optional: true, Description: "The HTTP method to use when unlocking, UNLOCK (default)."},
+			"username":               {AttributeType: cty.String, Optional: true, Description: "The username for HTTP basic authentication."},
+			"password":               {AttributeType: cty.String, Optional: true, Description: "The password for HTTP basic authentication."},
+			"skip_cert_verification": {AttributeType: cty.Bool, Optional: true, Description: "Whether to skip TLS verification."},
+		},
+	},
+	"kubernetes": {
+		Description: "Stores the state in a Kubernetes secret.",
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"secret_suffix":          {AttributeType: cty.String, Optional: true, Description: "Suffix used when creating the secret, e.g. tfstate-{workspace}-{secret_suffix}."},
+			"labels":                 {AttributeType: cty.Map(cty.String), Optional: true, Description: "Map of additional labels to be applied to the secret."},
+			"namespace":              {AttributeType: cty.String, Optional: true, Description: "Namespace to store the secret in."},
+			"in_cluster_config":      {AttributeType: cty.Bool, Optional: true, Description: "Whether to use the service account Kubernetes gives to pods."},
+			"load_config_file":       {AttributeType: cty.Bool, Optional: true, Description: "Whether to load the local kubeconfig file."},
+			"host":                   {AttributeType: cty.String, Optional: true, Description: "The hostname (in form of URI) of the Kubernetes master."},
+			"username":               {AttributeType: cty.String, Optional: true, Description: "The username for HTTP basic authentication."},
+			"password":               {AttributeType: cty.String, Optional: true, Description: "The password for HTTP basic authentication."},
+			"insecure":               {AttributeType: cty.Bool, Optional: true, Description: "Whether the server should be accessed without verifying the TLS certificate."},
+			"client_certificate":     {AttributeType: cty.String, Optional: true, Description: "PEM-encoded client certificate for TLS authentication."},
+			"client_key":             {AttributeType: cty.String, Optional: true, Description: "PEM-encoded client certificate key for TLS authentication."},
+			"cluster_ca_certificate": {AttributeType: cty.String, Optional: true, Description: "PEM-encoded root certificates bundle for TLS authentication."},
+			"config_path":            {AttributeType: cty.String, Optional: true, Description: "Path to the kube config file."},
+			"config_context":         {AttributeType: cty.String, Optional: true, Description: "Context to choose from the config file."},
+			"token":                  {AttributeType: cty.String, Optional: true, Description: "Token of your service account."},
+		},
+	},
+	"local": {
+		Description: "Stores the state on the local filesystem.",
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"path":          {AttributeType: cty.String, Optional: true, Description: "The path to the tfstate file, terraform.tfstate (default)."},
+			"workspace_dir": {AttributeType: cty.String, Optional: true, Description: "The path to non-default workspaces."},
+		},
+	},
+	"manta": {
+		Description: "Stores the state as an artifact in Manta.",
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"account":                  {AttributeType: cty.String, Optional: true, Description: "The name of the Manta account."},
+			"key_id":                   {AttributeType: cty.String, Optional: true, Description: "The fingerprint of the public key matching the key material."},
+			"path":                     {AttributeType: cty.String, Optional: true, Description: "The path relative to your private storage directory to store the state file."},
+			"url":                      {AttributeType: cty.String, Optional: true, Description: "The Manta API endpoint."},
+			"user":                     {AttributeType: cty.String, Optional: true, Description: "The username of the Manta account."},
+			"key_material":             {AttributeType: cty.String, Optional: true, Description: "The private key of an SSH key associated with the account."},
+			"insecure_skip_tls_verify": {AttributeType: cty.Bool, Optional: true, Description: "Whether to skip verification of the TLS certificate."},
+			"object_name":              {AttributeType: cty.String, Optional: true, Description: "The name of the state file, terraform.tfstate (default)."},
+		},
+	},
+	"oss": {
+		Description: "Stores the state as an object in a given bucket on Alibaba Cloud OSS.",
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"bucket":              {AttributeType: cty.String, Optional: true, Description: "The name of the OSS bucket."},
+			"prefix":              {AttributeType: cty.String, Optional: true, Description: "The path directory of the state file in the bucket."},
+			"key":                 {AttributeType: cty.String, Optional: true, Description: "The name of the state file, terraform.tfstate (default)."},
+			"region":              {AttributeType: cty.String, Optional: true, Description: "The region of the OSS bucket."},
+			"endpoint":            {AttributeType: cty.String, Optional: true, Description: "A custom endpoint for the OSS API."},
+			"access_key":          {AttributeType: cty.String, Optional: true, Description: "Alibaba Cloud access key."},
+			"secret_key":          {AttributeType: cty.String, Optional: true, Description: "Alibaba Cloud secret access key."},
+			"security_token":      {AttributeType: cty.String, Optional: true, Description: "STS access token."},
+			"profile":             {AttributeType: cty.String, Optional: true, Description: "The profile from the shared credentials file."},
+			"encrypt":             {AttributeType: cty.Bool, Optional: true, Description: "Whether to enable server side encryption of the state file."},
+			"acl":                 {AttributeType: cty.String, Optional: true, Description: "Object ACL to be applied to the state file."},
+			"tablestore_endpoint": {AttributeType: cty.String, Optional: true, Description: "A custom endpoint for the TableStore API used for locking."},
+			"tablestore_table":    {AttributeType: cty.String, Optional: true, Description: "A TableStore table for state locking and consistency."},
+		},
+	},
+	"pg": {
+		Description: "Stores the state in a Postgres database.",
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"conn_str":             {AttributeType: cty.String, Optional: true, Description: "Postgres connection string, a postgres:// URL."},
+			"schema_name":          {AttributeType: cty.String, Optional: true, Description: "Name of the automatically-managed Postgres schema, terraform_remote_state (default)."},
+			"skip_schema_creation": {AttributeType: cty.Bool, Optional: true, Description: "Whether to skip creation of the Postgres schema."},
+		},
+	},
+	"remote": {
+		Description: "Stores the state and may run operations in Terraform Cloud or Terraform Enterprise.",
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"hostname":     {AttributeType: cty.String, Optional: true, Description: "The remote backend hostname to connect to, app.terraform.io (default)."},
+			"organization": {AttributeType: cty.String, Optional: true, Description: "The name of the organization containing the targeted workspace(s)."},
+			"token":        {AttributeType: cty.String, Optional: true, Description: "The token used to authenticate with the remote backend."},
+		},
+		NestedBlocks: map[string]*tfjson.SchemaBlockType{
+			"workspaces": {
+				NestingMode: tfjson.SchemaNestingModeSingle,
+				Block: &tfjson.SchemaBlock{
+					Attributes: map[string]*tfjson.SchemaAttribute{
+						"name":   {AttributeType: cty.String, Optional: true, Description: "The full name of one remote workspace."},
+						"prefix": {AttributeType: cty.String, Optional: true, Description: "A prefix used in the names of one or more remote workspaces."},
+					},
+				},
+			},
+		},
+	},
+	"s3": {
+		Description: "Stores the state as a given key in a given bucket on Amazon S3, with optional locking via DynamoDB.",
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"bucket":                          {AttributeType: cty.String, Optional: true, Description: "The name of the S3 bucket."},
+			"key":                             {AttributeType: cty.String, Optional: true, Description: "The path to the state file inside the bucket."},
+			"region":                          {AttributeType: cty.String, Optional: true, Description: "The region of the S3 bucket."},
+			"dynamodb_table":                  {AttributeType: cty.String, Optional: true, Description: "The name of a DynamoDB table to use for state locking and consistency."},
+			"dynamodb_endpoint":               {AttributeType: cty.String, Optional: true, Description: "A custom endpoint for the DynamoDB API."},
+			"lock_table":                      {AttributeType: cty.String, Optional: true, Description: "Deprecated, use dynamodb_table instead."},
+			"encrypt":                         {AttributeType: cty.Bool, Optional: true, Description: "Whether to enable server side encryption of the state file."},
+			"acl":                             {AttributeType: cty.String, Optional: true, Description: "Canned ACL to be applied to the state file."},
+			"kms_key_id":                      {AttributeType: cty.String, Optional: true, Description: "The ARN of a KMS key to use for encrypting the state."},
+			"sse_customer_key":                {AttributeType: cty.String, Optional: true, Description: "The key to use for encrypting state with Server-Side Encryption with Customer-Provided Keys."},
+			"workspace_key_prefix":            {AttributeType: cty.String, Optional: true, Description: "The prefix applied to the state path inside the bucket for non-default workspaces, env: (default)."},
+			"endpoint":                        {AttributeType: cty.String, Optional: true, Description: "A custom endpoint for the S3 API."},
+			"iam_endpoint":                    {AttributeType: cty.String, Optional: true, Description: "A custom endpoint for the AWS IAM API."},
+			"sts_endpoint":                    {AttributeType: cty.String, Optional: true, Description: "A custom endpoint for the AWS STS API."},
+			"access_key":                      {AttributeType: cty.String, Optional: true, Description: "AWS access key."},
+			"secret_key":                      {AttributeType: cty.String, Optional: true, Description: "AWS secret access key."},
+			"token":                           {AttributeType: cty.String, Optional: true, Description: "Multi-Factor Authentication (MFA) token."},
+			"profile":                         {AttributeType: cty.String, Optional: true, Description: "Name of AWS profile in AWS shared credentials file."},
+			"shared_credentials_file":         {AttributeType: cty.String, Optional: true, Description: "The path to the AWS shared credentials file."},
+			"role_arn":                        {AttributeType: cty.String, Optional: true, Description: "The role to be assumed."},
+			"session_name":                    {AttributeType: cty.String, Optional: true, Description: "The session name to use when assuming the role."},
+			"external_id":                     {AttributeType: cty.String, Optional: true, Description: "The external ID to use when assuming the role."},
+			"assume_role_policy":              {AttributeType: cty.String, Optional: true, Description: "The permissions applied when assuming a role."},
+			"assume_role_duration_seconds":    {AttributeType: cty.Number, Optional: true, Description: "Number of seconds to restrict the assume role session duration (0.13+)."},
+			"assume_role_policy_arns":         {AttributeType: cty.Set(cty.String), Optional: true, Description: "Set of ARNs of IAM policies to further restrict permissions when assuming the role (0.13+)."},
+			"assume_role_tags":                {AttributeType: cty.Map(cty.String), Optional: true, Description: "Map of assume role session tags (0.13+)."},
+			"assume_role_transitive_tag_keys": {AttributeType: cty.Set(cty.String), Optional: true, Description: "Set of assume role session tag keys to pass to any subsequent sessions (0.13+)."},
+			"max_retries":                     {AttributeType: cty.Number, Optional: true, Description: "The maximum number of times an AWS API request is retried on retryable failure."},
+			"force_path_style":                {AttributeType: cty.Bool, Optional: true, Description: "Whether to enable path-style S3 URLs."},
+			"skip_credentials_validation":     {AttributeType: cty.Bool, Optional: true, Description: "Whether to skip the credentials validation via the STS API."},
+			"skip_region_validation":          {AttributeType: cty.Bool, Optional: true, Description: "Whether to skip validation of provided region name."},
+			"skip_metadata_api_check":         {AttributeType: cty.Bool, Optional: true, Description: "Whether to skip the AWS Metadata API check."},
+		},
+	},
+	"swift": {
+		Description: "Stores the state as an artifact in Swift.",
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"container":         {AttributeType: cty.String, Optional: true, Description: "The name of the container to create for storing the state."},
+			"auth_url":          {AttributeType: cty.String, Optional: true, Description: "The Identity authentication URL."},
+			"region_name":       {AttributeType: cty.String, Optional: true, Description: "The region in which to store the state."},
+			"user_name":         {AttributeType: cty.String, Optional: true, Description: "The username to login with."},
+			"password":          {AttributeType: cty.String, Optional: true, Description: "The password to login with."},
+			"tenant_name":       {AttributeType: cty.String, Optional: true, Description: "The name of the tenant."},
+			"domain_name":       {AttributeType: cty.String, Optional: true, Description: "The name of the domain to scope to."},
+			"archive_container": {AttributeType: cty.String, Optional: true, Description: "The name of the container to archive previous versions of the state in."},
+			"expire_after":      {AttributeType: cty.String, Optional: true, Description: "How long the state should be kept, e.g. 30d."},
+		},
+	},
+}
+
+// backendSupportedBy reports whether the given backend type
+// is known and supported by the given Terraform version
+func backendSupportedBy(name string, v *version.Version) bool {
+	if _, ok := backendSchemas[name]; !ok {
+		return false
+	}
+	return versionSupports(v, backendsSince[name]) &&
+		versionPrecedes(v, backendsUntil[name])
+}
+
+// backendSchemaBlocks contains all schema blocks of the catalogue,
+// incl. nested ones, to recognize them during validation
+var backendSchemaBlocks = func() map[*tfjson.SchemaBlock]bool {
+	blocks := make(map[*tfjson.SchemaBlock]bool, 0)
+	var add func(b *tfjson.SchemaBlock)
+	add = func(b *tfjson.SchemaBlock) {
+		if b == nil {
+			return
+		}
+		blocks[b] = true
+		for _, bType := range b.NestedBlocks {
+			add(bType.Block)
+		}
+	}
+	for _, b := range backendSchemas {
+		add(b)
+	}
+	return blocks
+}()
+
+// isBackendSchema reports whether the given schema
+// comes from the (hand-written) backend catalogue
+func isBackendSchema(b *tfjson.SchemaBlock) bool {
+	return backendSchemaBlocks[b]
+}
+
+// backendCandidates returns candidates for the type label
+// of backend blocks, as supported by the given Terraform version
+func backendCandidates(v *version.Version) []*labelCandidate {
+	names := make([]string, 0, len(backendSchemas))
+	for name := range backendSchemas {
+		if !backendSupportedBy(name, v) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	candidates := make([]*labelCandidate, len(names))
+	for i, name := range names {
+		candidates[i] = &labelCandidate{
+			label:         name,
+			detail:        "Backend",
+			documentation: PlainText(backendSchemas[name].Description),
+		}
+	}
+	return candidates
+}
+
+// backendBlockAtLabelPos returns a backend block within the given
+// terraform block, whose label is at the given position
+func backendBlockAtLabelPos(block *hclsyntax.Block, pos hcl.Pos) (*hclsyntax.Block, bool) {
+	if block == nil || block.Body == nil {
+		return nil, false
+	}
+	for _, b := range block.Body.Blocks {
+		if b.Type == "backend" && PosInLabels(b, pos) {
+			return b, true
+		}
+	}
+	return nil, false
+}
+
+// validateBackendTypes reports backend blocks within the given
+// terraform block whose type is unknown (as a warning)
+// or not supported by the given Terraform version
+func validateBackendTypes(block *hclsyntax.Block, v *version.Version) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	if block == nil || block.Body == nil {
+		return diags
+	}
+
+	for _, b := range block.Body.Blocks {
+		if b.Type != "backend" || len(b.Labels) == 0 {
+			continue
+		}
+		name := b.Labels[0]
+		if _, ok := backendSchemas[name]; !ok {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagWarning,
+				Summary:  "Unknown backend type",
+				Detail: fmt.Sprintf("Backend type %q is not known to the language server, "+
+					"so its configuration cannot be validated.", name),
+				Subject: b.LabelRanges[0].Ptr(),
+			})
+			continue
+		}
+		if !versionPrecedes(v, backendsUntil[name]) {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Unsupported backend type",
+				Detail: fmt.Sprintf("Backend type %q was removed in Terraform %s.",
+					name, backendsUntil[name]),
+				Subject: b.LabelRanges[0].Ptr(),
+			})
+			continue
+		}
+		if !backendSupportedBy(name, v) {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Unsupported backend type",
+				Detail: fmt.Sprintf("Backend type %q requires Terraform %s or later.",
+					name, backendsSince[name]),
+				Subject: b.LabelRanges[0].Ptr(),
+			})
+		}
+	}
+
+	return diags
+}
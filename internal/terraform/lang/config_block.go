@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/hashicorp/go-version"
 	hcl "github.com/hashicorp/hcl/v2"
 	tfjson "github.com/hashicorp/terraform-json"
 	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
@@ -77,6 +78,7 @@ type completableBlock struct {
 	parsedLabels  []*ParsedLabel
 	tBlock        ihcl.TokenizedBlock
 	schema        *tfjson.SchemaBlock
+	tfVersion     *version.Version
 }
 
 func (cl *completableBlock) maxCompletionCandidates() int {
@@ -144,6 +146,7 @@ func (cb *completableBlock) completionCandidatesAtPos(pos hcl.Pos) (CompletionCa
 		nbc := &nestedBlockCandidate{
 			Name:      name,
 			BlockType: block,
+			TFVersion: cb.tfVersion,
 		}
 		if prefixRng != nil {
 			nbc.PrefixRange = prefixRng
@@ -156,6 +159,43 @@ func (cb *completableBlock) completionCandidatesAtPos(pos hcl.Pos) (CompletionCa
 	return list, nil
 }
 
+func (cb *completableBlock) hoverAtPos(pos hcl.Pos) (*HoverData, error) {
+	block := ParseBlock(cb.tBlock, cb.schema)
+
+	b, ok := block.BlockAtPos(pos)
+	if !ok {
+		cb.logger.Printf("block not found at %#v", pos)
+		return nil, nil
+	}
+
+	name, attr, ok := b.AttributeAtPos(pos)
+	if !ok {
+		cb.logger.Printf("attribute not found at %#v", pos)
+		return nil, nil
+	}
+
+	rng := attr.NameRange()
+
+	return &HoverData{
+		Content: attributeHoverContent(name, attr.Schema()),
+		Range:   &rng,
+	}, nil
+}
+
+func attributeHoverContent(name string, schema *tfjson.SchemaAttribute) MarkupContent {
+	content := fmt.Sprintf("%s (%s)", name, schemaAttributeDetail(schema))
+
+	desc := schemaAttributeDescription(schema)
+	if desc.Value() != "" {
+		content += "\n\n" + desc.Value()
+	}
+
+	if desc.Kind() == MarkdownKind {
+		return Markdown(content)
+	}
+	return PlainText(content)
+}
+
 type candidateList struct {
 	candidates   []CompletionCandidate
 	isIncomplete bool
@@ -185,6 +225,10 @@ type labelCandidate struct {
 	detail        string
 	documentation MarkupContent
 	prefixRng     *hcl.Range
+
+	// resolveDocumentation allows documentation to be resolved
+	// lazily, i.e. only for candidates which end up being returned
+	resolveDocumentation func() MarkupContent
 }
 
 func (c *labelCandidate) Label() string {
@@ -196,6 +240,9 @@ func (c *labelCandidate) Detail() string {
 }
 
 func (c *labelCandidate) Documentation() MarkupContent {
+	if c.documentation == nil && c.resolveDocumentation != nil {
+		return c.resolveDocumentation()
+	}
 	return c.documentation
 }
 
@@ -232,10 +279,7 @@ func (c *attributeCandidate) Documentation() MarkupContent {
 		return PlainText("")
 	}
 	if schema := c.Attr.Schema(); schema != nil {
-		if schema.DescriptionKind == tfjson.SchemaDescriptionKindMarkdown {
-			return Markdown(schema.Description)
-		}
-		return PlainText(schema.Description)
+		return schemaAttributeDescription(schema)
 	}
 	return PlainText("")
 }
@@ -258,6 +302,7 @@ type nestedBlockCandidate struct {
 	Name        string
 	BlockType   *BlockType
 	PrefixRange *hcl.Range
+	TFVersion   *version.Version
 }
 
 func (c *nestedBlockCandidate) Label() string {
@@ -279,6 +324,15 @@ func (c *nestedBlockCandidate) Documentation() MarkupContent {
 }
 
 func (c *nestedBlockCandidate) Snippet() TextEdit {
+	if _, ok := labeledBlockSchemas[c.BlockType.Schema()]; ok {
+		types := labeledBlockTypes(c.BlockType.Schema(), c.TFVersion)
+
+		return &textEdit{
+			newText: snippetForLabeledNestedBlock(c.Name, types),
+			rng:     c.PrefixRange,
+		}
+	}
+
 	return &textEdit{
 		newText: snippetForNestedBlock(c.Name),
 		rng:     c.PrefixRange,
@@ -286,6 +286,119 @@ func TestCompletableBlock_CompletionCandidatesAtPos(t *testing.T) {
 	}
 }
 
+func TestCompletableBlock_hoverAtPos(t *testing.T) {
+	schema := &tfjson.SchemaBlock{
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"instance_type": {
+				AttributeType: cty.String,
+				Required:      true,
+				Description:   "Type of the instance",
+			},
+			"arn": {
+				AttributeType: cty.String,
+				Computed:      true,
+			},
+		},
+		NestedBlocks: map[string]*tfjson.SchemaBlockType{
+			"ebs": {
+				NestingMode: tfjson.SchemaNestingModeList,
+				Block: &tfjson.SchemaBlock{
+					Attributes: map[string]*tfjson.SchemaAttribute{
+						"size": {
+							AttributeType:   cty.Number,
+							Optional:        true,
+							Description:     "Size in **GB**",
+							DescriptionKind: tfjson.SchemaDescriptionKindMarkdown,
+						},
+					},
+				},
+			},
+		},
+	}
+	testCases := []struct {
+		name string
+		src  string
+		pos  hcl.Pos
+
+		expectedData *HoverData
+	}{
+		{
+			"required attribute",
+			`resource "aws_instance" "web" {
+  instance_type = "t2.micro"
+}`,
+			hcl.Pos{Line: 2, Column: 5, Byte: 36},
+			&HoverData{
+				Content: PlainText("instance_type (Required, string)\n\nType of the instance"),
+				Range: &hcl.Range{
+					Filename: "/test.tf",
+					Start:    hcl.Pos{Line: 2, Column: 3, Byte: 34},
+					End:      hcl.Pos{Line: 2, Column: 16, Byte: 47},
+				},
+			},
+		},
+		{
+			"computed attribute",
+			`resource "aws_instance" "web" {
+  arn = "x"
+}`,
+			hcl.Pos{Line: 2, Column: 4, Byte: 35},
+			&HoverData{
+				Content: PlainText("arn (Computed, string)"),
+				Range: &hcl.Range{
+					Filename: "/test.tf",
+					Start:    hcl.Pos{Line: 2, Column: 3, Byte: 34},
+					End:      hcl.Pos{Line: 2, Column: 6, Byte: 37},
+				},
+			},
+		},
+		{
+			"nested block attribute",
+			`resource "aws_instance" "web" {
+  ebs {
+    size = 42
+  }
+}`,
+			hcl.Pos{Line: 3, Column: 6, Byte: 45},
+			&HoverData{
+				Content: Markdown("size (Optional, number)\n\nSize in **GB**"),
+				Range: &hcl.Range{
+					Filename: "/test.tf",
+					Start:    hcl.Pos{Line: 3, Column: 5, Byte: 44},
+					End:      hcl.Pos{Line: 3, Column: 9, Byte: 48},
+				},
+			},
+		},
+		{
+			"outside of attribute",
+			`resource "aws_instance" "web" {
+
+}`,
+			hcl.Pos{Line: 2, Column: 1, Byte: 32},
+			nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			cb := &completableBlock{
+				logger: testLogger(),
+				tBlock: newTestBlock(t, tc.src),
+				schema: schema,
+			}
+
+			data, err := cb.hoverAtPos(tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedData, data); diff != "" {
+				t.Fatalf("Hover data doesn't match.\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestCompletableLabels_CompletionCandidatesAtPos_overLimit(t *testing.T) {
 	tBlock := newTestBlock(t, `provider "" {
 }`)
@@ -4,9 +4,9 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/hashicorp/go-version"
 	hcl "github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
-	tfjson "github.com/hashicorp/terraform-json"
 	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
 	"github.com/hashicorp/terraform-ls/internal/terraform/schema"
 )
@@ -15,6 +15,7 @@ type datasourceBlockFactory struct {
 	logger *log.Logger
 
 	schemaReader schema.Reader
+	tfVersion    *version.Version
 }
 
 func (f *datasourceBlockFactory) New(tBlock ihcl.TokenizedBlock) (ConfigBlock, error) {
@@ -28,6 +29,7 @@ func (f *datasourceBlockFactory) New(tBlock ihcl.TokenizedBlock) (ConfigBlock, e
 		labelSchema: f.LabelSchema(),
 		tBlock:      tBlock,
 		sr:          f.schemaReader,
+		tfVersion:   f.tfVersion,
 	}, nil
 }
 
@@ -55,6 +57,7 @@ type datasourceBlock struct {
 	labels      []*ParsedLabel
 	tBlock      ihcl.TokenizedBlock
 	sr          schema.Reader
+	tfVersion   *version.Version
 }
 
 func (r *datasourceBlock) Type() string {
@@ -113,7 +116,7 @@ func (r *datasourceBlock) CompletionCandidatesAtPos(pos hcl.Pos) (CompletionCand
 			parsedLabels: r.Labels(),
 			tBlock:       r.tBlock,
 			labels: labelCandidates{
-				"type": dataSourceCandidates(dataSources),
+				"type": dataSourceCandidates(r.sr, dataSources),
 			},
 		}
 
@@ -127,24 +130,59 @@ func (r *datasourceBlock) CompletionCandidatesAtPos(pos hcl.Pos) (CompletionCand
 	cb := &completableBlock{
 		logger:       r.logger,
 		parsedLabels: r.Labels(),
-		schema:       rSchema.Block,
+		schema:       withDataSourceMetaArguments(rSchema.Block, r.tfVersion),
 		tBlock:       r.tBlock,
 	}
 	return cb.completionCandidatesAtPos(pos)
 }
 
-func dataSourceCandidates(dataSources []schema.DataSource) []*labelCandidate {
+func (r *datasourceBlock) HoverAtPos(pos hcl.Pos) (*HoverData, error) {
+	if r.sr == nil {
+		return nil, &noSchemaReaderErr{r.BlockType()}
+	}
+
+	rSchema, err := r.sr.DataSourceSchema(r.Type())
+	if err != nil {
+		return nil, err
+	}
+	cb := &completableBlock{
+		logger:       r.logger,
+		parsedLabels: r.Labels(),
+		schema:       withDataSourceMetaArguments(rSchema.Block, r.tfVersion),
+		tBlock:       r.tBlock,
+	}
+	return cb.hoverAtPos(pos)
+}
+
+func (r *datasourceBlock) Validate() (hcl.Diagnostics, error) {
+	if r.sr == nil {
+		return nil, &noSchemaReaderErr{r.BlockType()}
+	}
+
+	rSchema, err := r.sr.DataSourceSchema(r.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	block := ParseBlock(r.tBlock, withDataSourceMetaArguments(rSchema.Block, r.tfVersion))
+	return block.Validate(), nil
+}
+
+func dataSourceCandidates(sr schema.Reader, dataSources []schema.DataSource) []*labelCandidate {
 	candidates := []*labelCandidate{}
 	for _, ds := range dataSources {
-		var desc MarkupContent = PlainText(ds.Description)
-		if ds.DescriptionKind == tfjson.SchemaDescriptionKindMarkdown {
-			desc = Markdown(ds.Description)
-		}
-
+		name := ds.Name
 		candidates = append(candidates, &labelCandidate{
-			label:         ds.Name,
-			detail:        fmt.Sprintf("Data Source (%s)", ds.Provider),
-			documentation: desc,
+			label:  name,
+			detail: fmt.Sprintf("Data Source (%s)", ds.Provider),
+			// schema is only decoded for candidates which are returned
+			resolveDocumentation: func() MarkupContent {
+				s, err := sr.DataSourceSchema(name)
+				if err != nil {
+					return PlainText("")
+				}
+				return schemaBlockDescription(s.Block)
+			},
 		})
 	}
 	return candidates
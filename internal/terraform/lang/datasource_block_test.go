@@ -142,6 +142,42 @@ func TestDataSourceBlock_completionCandidatesAtPos(t *testing.T) {
 						Text: `attr_required = "${0:value}"`,
 					},
 				},
+				{
+					Label:         "count",
+					Detail:        "Optional, number",
+					Documentation: "The number of instances to create.",
+					Snippet: renderedSnippet{
+						Pos:  hcl.Pos{Line: 2, Column: 1, Byte: 26},
+						Text: "count = ${0:42}",
+					},
+				},
+				{
+					Label:         "depends_on",
+					Detail:        "Optional, dynamic",
+					Documentation: "A list of hidden dependencies which Terraform cannot infer from references.",
+					Snippet: renderedSnippet{
+						Pos:  hcl.Pos{Line: 2, Column: 1, Byte: 26},
+						Text: "depends_on = ${0}",
+					},
+				},
+				{
+					Label:         "for_each",
+					Detail:        "Optional, dynamic",
+					Documentation: "A map or a set of strings to create an instance for each item of.",
+					Snippet: renderedSnippet{
+						Pos:  hcl.Pos{Line: 2, Column: 1, Byte: 26},
+						Text: "for_each = ${0}",
+					},
+				},
+				{
+					Label:         "provider",
+					Detail:        "Optional, dynamic",
+					Documentation: "Reference to a non-default provider configuration, e.g. aws.west.",
+					Snippet: renderedSnippet{
+						Pos:  hcl.Pos{Line: 2, Column: 1, Byte: 26},
+						Text: "provider = ${0}",
+					},
+				},
 			},
 			nil,
 		},
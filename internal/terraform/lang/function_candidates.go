@@ -0,0 +1,74 @@
+package lang
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+// functionCandidates returns candidates for all built-in functions
+// available in the Terraform version, sorted by name
+func (p *parser) functionCandidates(prefixRng *hcl.Range) []CompletionCandidate {
+	names := make([]string, 0, len(p.functions))
+	for name := range p.functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	candidates := make([]CompletionCandidate, len(names))
+	for i, name := range names {
+		candidates[i] = &functionCandidate{
+			Function:    p.functions[name],
+			PrefixRange: prefixRng,
+		}
+	}
+	return candidates
+}
+
+type functionCandidate struct {
+	Function    *FunctionSignature
+	PrefixRange *hcl.Range
+}
+
+func (c *functionCandidate) Label() string {
+	return c.Function.Name
+}
+
+func (c *functionCandidate) Detail() string {
+	return c.Function.Label()
+}
+
+func (c *functionCandidate) Documentation() MarkupContent {
+	return PlainText(c.Function.Description)
+}
+
+func (c *functionCandidate) Snippet() TextEdit {
+	return &textEdit{
+		newText: snippetForFunction(c.Function),
+		rng:     c.PrefixRange,
+	}
+}
+
+func (c *functionCandidate) PlainText() TextEdit {
+	return &textEdit{
+		newText: c.Function.Name,
+		rng:     c.PrefixRange,
+	}
+}
+
+// snippetForFunction returns a snippet of a function call
+// with placeholders for all required parameters
+// (or the first variadic argument if there's no required one)
+func snippetForFunction(fs *FunctionSignature) string {
+	placeholders := make([]string, 0, len(fs.Params))
+	for i, p := range fs.Params {
+		placeholders = append(placeholders, fmt.Sprintf("${%d:%s}", i+1, p.Name))
+	}
+	if len(placeholders) == 0 && fs.VariadicParam != nil {
+		placeholders = append(placeholders, fmt.Sprintf("${1:%s}", fs.VariadicParam.Name))
+	}
+
+	return fmt.Sprintf("%s(%s)", fs.Name, strings.Join(placeholders, ", "))
+}
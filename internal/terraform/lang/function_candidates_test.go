@@ -0,0 +1,155 @@
+package lang
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+)
+
+func TestParser_CompletionCandidatesAtPos_functions(t *testing.T) {
+	testCases := []struct {
+		tfVersion          string
+		cfg                string
+		expectedCandidates []string
+	}{
+		{
+			"0.12.0",
+			`resource "aws_instance" "web" {
+  ami = tr|
+}
+`,
+			[]string{"transpose", "trimspace"},
+		},
+		{
+			"0.12.20",
+			`resource "aws_instance" "web" {
+  ami = tr|
+}
+`,
+			[]string{"transpose", "trim", "trimprefix", "trimspace", "trimsuffix", "try"},
+		},
+		{
+			"0.12.20-beta1",
+			`resource "aws_instance" "web" {
+  ami = upper(tr|)
+}
+`,
+			[]string{"transpose", "trim", "trimprefix", "trimspace", "trimsuffix", "try"},
+		},
+		{
+			"0.13.0",
+			`resource "aws_instance" "web" {
+  ami = su|
+}
+`,
+			[]string{"substr", "sum"},
+		},
+		{
+			"0.14.0",
+			`resource "aws_instance" "web" {
+  ami = a|
+}
+`,
+			[]string{"abs", "abspath", "alltrue", "anytrue"},
+		},
+		{
+			"0.14.0",
+			`resource "aws_instance" "web" {
+  ami = o|
+}
+`,
+			[]string{},
+		},
+		{
+			"0.15.0",
+			`resource "aws_instance" "web" {
+  ami = no|
+}
+`,
+			[]string{"nonsensitive"},
+		},
+		{
+			"0.12.20",
+			`resource "aws_instance" "web" {
+  ami = var.tr|
+}
+`,
+			[]string{},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.tfVersion), func(t *testing.T) {
+			src, pos := splitCursor(t, tc.cfg)
+
+			p, err := FindCompatibleParser(tc.tfVersion)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			candidates, err := p.CompletionCandidatesAtPos(ihcl.NewTestFile(src), NewModuleIndex(), pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			labels := make([]string, 0)
+			for _, c := range candidates.List() {
+				labels = append(labels, c.Label())
+			}
+			if diff := cmp.Diff(tc.expectedCandidates, labels); diff != "" {
+				t.Fatalf("Candidates don't match.\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFunctionCandidate(t *testing.T) {
+	testCases := []struct {
+		name            string
+		expectedDetail  string
+		expectedSnippet string
+	}{
+		{
+			"cidrsubnet",
+			"cidrsubnet(prefix string, newbits number, netnum number) string",
+			"cidrsubnet(${1:prefix}, ${2:newbits}, ${3:netnum})",
+		},
+		{
+			"lookup",
+			"lookup(inputMap map of any, key string, default ...any) any",
+			"lookup(${1:inputMap}, ${2:key})",
+		},
+		{
+			"max",
+			"max(numbers ...number) number",
+			"max(${1:numbers})",
+		},
+		{
+			"timestamp",
+			"timestamp() string",
+			"timestamp()",
+		},
+	}
+
+	functions := functionsForVersion(nil)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &functionCandidate{Function: functions[tc.name]}
+
+			if c.Detail() != tc.expectedDetail {
+				t.Fatalf("Detail doesn't match.\nexpected: %q\ngiven: %q",
+					tc.expectedDetail, c.Detail())
+			}
+			if c.Snippet().NewText() != tc.expectedSnippet {
+				t.Fatalf("Snippet doesn't match.\nexpected: %q\ngiven: %q",
+					tc.expectedSnippet, c.Snippet().NewText())
+			}
+			if c.PlainText().NewText() != tc.name {
+				t.Fatalf("Plain text doesn't match.\nexpected: %q\ngiven: %q",
+					tc.name, c.PlainText().NewText())
+			}
+		})
+	}
+}
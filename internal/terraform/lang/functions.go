@@ -0,0 +1,242 @@
+package lang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+// FunctionSignature describes a Terraform built-in function
+type FunctionSignature struct {
+	Name          string
+	Description   string
+	Params        []FunctionParam
+	VariadicParam *FunctionParam
+	ReturnType    string
+}
+
+type FunctionParam struct {
+	Name string
+	Type string
+}
+
+// Label returns the signature as shown to the user,
+// e.g. cidrhost(prefix string, hostnum number) string
+func (fs *FunctionSignature) Label() string {
+	return fmt.Sprintf("%s(%s) %s", fs.Name,
+		strings.Join(fs.ParamLabels(), ", "), fs.ReturnType)
+}
+
+// ParamLabels returns labels of all parameters (including the variadic one)
+// as they appear in the signature label
+func (fs *FunctionSignature) ParamLabels() []string {
+	labels := make([]string, 0, len(fs.Params)+1)
+	for _, p := range fs.Params {
+		labels = append(labels, fmt.Sprintf("%s %s", p.Name, p.Type))
+	}
+	if fs.VariadicParam != nil {
+		labels = append(labels, fmt.Sprintf("%s ...%s",
+			fs.VariadicParam.Name, fs.VariadicParam.Type))
+	}
+	return labels
+}
+
+// ActiveParameter maps index of an argument to index of a parameter
+// to account for any number of arguments passed as the variadic parameter
+func (fs *FunctionSignature) ActiveParameter(argIdx int) int {
+	if fs.VariadicParam != nil && argIdx > len(fs.Params) {
+		return len(fs.Params)
+	}
+	return argIdx
+}
+
+// functionsSince maps Terraform versions to built-in functions
+// which were first introduced in that version
+//
+// Functions which are available in 0.12.0 (i.e. first version
+// supported by the parser) are listed under that version
+var functionsSince = map[string][]*FunctionSignature{
+	"0.12.0": {
+		// Numeric functions
+		function("abs", "Returns the absolute value of the given number.", "number", param("num", "number")),
+		function("ceil", "Returns the closest whole number that is greater than or equal to the given value.", "number", param("num", "number")),
+		function("floor", "Returns the closest whole number that is less than or equal to the given value.", "number", param("num", "number")),
+		function("log", "Returns the logarithm of a given number in a given base.", "number", param("num", "number"), param("base", "number")),
+		withVariadicParam(function("max", "Takes one or more numbers and returns the greatest number from the set.", "number"), param("numbers", "number")),
+		withVariadicParam(function("min", "Takes one or more numbers and returns the smallest number from the set.", "number"), param("numbers", "number")),
+		function("pow", "Calculates an exponent, by raising its first argument to the power of the second argument.", "number", param("num", "number"), param("power", "number")),
+		function("signum", "Determines the sign of a number, returning -1, 0 or 1.", "number", param("num", "number")),
+
+		// String functions
+		function("chomp", "Removes newline characters at the end of a string.", "string", param("str", "string")),
+		withVariadicParam(function("format", "Produces a string by formatting a number of other values according to a specification string.", "string", param("format", "string")), param("args", "any")),
+		withVariadicParam(function("formatlist", "Produces a list of strings by formatting a number of other values according to a specification string.", "list of string", param("format", "string")), param("args", "any")),
+		function("indent", "Adds a given number of spaces to the beginnings of all but the first line in a given multi-line string.", "string", param("spaces", "number"), param("str", "string")),
+		withVariadicParam(function("join", "Produces a string by concatenating together all elements of a given list of strings with the given delimiter.", "string", param("separator", "string")), param("lists", "list of string")),
+		function("lower", "Converts all cased letters in the given string to lowercase.", "string", param("str", "string")),
+		function("replace", "Searches a given string for another given substring, and replaces each occurrence with a given replacement string.", "string", param("str", "string"), param("substr", "string"), param("replace", "string")),
+		function("split", "Produces a list by dividing a given string at all occurrences of a given separator.", "list of string", param("separator", "string"), param("str", "string")),
+		function("strrev", "Reverses the characters in a string.", "string", param("str", "string")),
+		function("substr", "Extracts a substring from a given string by offset and length.", "string", param("str", "string"), param("offset", "number"), param("length", "number")),
+		function("title", "Converts the first letter of each word in the given string to uppercase.", "string", param("str", "string")),
+		function("trimspace", "Removes any space characters from the start and end of the given string.", "string", param("str", "string")),
+		function("upper", "Converts all cased letters in the given string to uppercase.", "string", param("str", "string")),
+
+		// Collection functions
+		function("chunklist", "Splits a single list into fixed-size chunks, returning a list of lists.", "list of list of any", param("list", "list of any"), param("size", "number")),
+		withVariadicParam(function("coalesce", "Takes any number of arguments and returns the first one that isn't null or an empty string.", "any"), param("vals", "any")),
+		withVariadicParam(function("coalescelist", "Takes any number of list arguments and returns the first one that isn't empty.", "list of any"), param("vals", "list of any")),
+		function("compact", "Takes a list of strings and returns a new list with any empty string elements removed.", "list of string", param("list", "list of string")),
+		withVariadicParam(function("concat", "Takes two or more lists and combines them into a single list.", "list of any"), param("seqs", "list of any")),
+		function("contains", "Determines whether a given list or set contains a given single value as one of its elements.", "bool", param("list", "list of any"), param("value", "any")),
+		function("distinct", "Takes a list and returns a new list with any duplicate elements removed.", "list of any", param("list", "list of any")),
+		function("element", "Retrieves a single element from a list.", "any", param("list", "list of any"), param("index", "number")),
+		function("flatten", "Takes a list and replaces any elements that are lists with a flattened sequence of the list contents.", "list of any", param("list", "list of any")),
+		function("index", "Finds the element index for a given value in a list.", "number", param("list", "list of any"), param("value", "any")),
+		function("keys", "Takes a map and returns a list containing the keys from that map.", "list of string", param("inputMap", "map of any")),
+		function("length", "Determines the length of a given list, map, or string.", "number", param("value", "any")),
+		withVariadicParam(function("list", "Takes an arbitrary number of arguments and returns a list containing those values in the same order.", "list of any"), param("vals", "any")),
+		withVariadicParam(function("lookup", "Retrieves the value of a single element from a map, given its key. If the given key does not exist, the given default value is returned instead.", "any", param("inputMap", "map of any"), param("key", "string")), param("default", "any")),
+		withVariadicParam(function("map", "Takes an even number of arguments and returns a map whose elements are constructed from consecutive pairs of arguments.", "map of any"), param("vals", "any")),
+		function("matchkeys", "Constructs a new list by taking a subset of elements from one list whose indexes match the corresponding indexes of values in another list.", "list of any", param("values", "list of any"), param("keys", "list of any"), param("searchset", "list of any")),
+		withVariadicParam(function("merge", "Takes an arbitrary number of maps or objects, and returns a single map or object that contains a merged set of elements from all arguments.", "any"), param("maps", "any")),
+		function("reverse", "Takes a sequence and produces a new sequence of the same length with all of the same elements as the given sequence but in reverse order.", "list of any", param("list", "any")),
+		withVariadicParam(function("setintersection", "Takes multiple sets and produces a single set containing only the elements that all of the given sets have in common.", "set of any", param("first_set", "set of any")), param("other_sets", "set of any")),
+		withVariadicParam(function("setproduct", "Finds all of the possible combinations of elements from all of the given sets by computing the Cartesian product.", "any"), param("sets", "any")),
+		withVariadicParam(function("setunion", "Takes multiple sets and produces a single set containing the elements from all of the given sets.", "set of any", param("first_set", "set of any")), param("other_sets", "set of any")),
+		function("slice", "Extracts some consecutive elements from within a list.", "list of any", param("list", "list of any"), param("start_index", "number"), param("end_index", "number")),
+		function("sort", "Takes a list of strings and returns a new list with those strings sorted lexicographically.", "list of string", param("list", "list of string")),
+		function("transpose", "Takes a map of lists of strings and swaps the keys and values to produce a new map of lists of strings.", "map of list of string", param("values", "map of list of string")),
+		function("values", "Takes a map and returns a list containing the values of the elements in that map.", "list of any", param("mapping", "map of any")),
+		function("zipmap", "Constructs a map from a list of keys and a corresponding list of values.", "map of any", param("keys", "list of string"), param("values", "list of any")),
+
+		// Encoding functions
+		function("base64decode", "Takes a string containing a Base64 character sequence and returns the original string.", "string", param("str", "string")),
+		function("base64encode", "Applies Base64 encoding to a string.", "string", param("str", "string")),
+		function("base64gzip", "Compresses a string with gzip and then encodes the result in Base64 encoding.", "string", param("str", "string")),
+		function("csvdecode", "Decodes a string containing CSV-formatted data and produces a list of maps representing that data.", "list of map of string", param("str", "string")),
+		function("jsondecode", "Interprets a given string as JSON, returning a representation of the result of decoding that string.", "any", param("str", "string")),
+		function("jsonencode", "Encodes a given value to a string using JSON syntax.", "string", param("val", "any")),
+		function("urlencode", "Applies URL encoding to a given string.", "string", param("str", "string")),
+
+		// Filesystem functions
+		function("abspath", "Takes a string containing a filesystem path and converts it to an absolute path.", "string", param("path", "string")),
+		function("basename", "Takes a string containing a filesystem path and removes all except the last portion from it.", "string", param("path", "string")),
+		function("dirname", "Takes a string containing a filesystem path and removes the last portion from it.", "string", param("path", "string")),
+		function("file", "Reads the contents of a file at the given path and returns them as a string.", "string", param("path", "string")),
+		function("filebase64", "Reads the contents of a file at the given path and returns them as a base64-encoded string.", "string", param("path", "string")),
+		function("fileexists", "Determines whether a file exists at a given path.", "bool", param("path", "string")),
+		function("pathexpand", "Takes a filesystem path that might begin with a ~ segment, and if so it replaces that segment with the current user's home directory path.", "string", param("path", "string")),
+		function("templatefile", "Reads the file at the given path and renders its content as a template using a supplied set of template variables.", "string", param("path", "string"), param("vars", "any")),
+
+		// Date and time functions
+		function("formatdate", "Converts a timestamp into a different time format.", "string", param("format", "string"), param("time", "string")),
+		function("timeadd", "Adds a duration to a timestamp, returning a new timestamp.", "string", param("timestamp", "string"), param("duration", "string")),
+		function("timestamp", "Returns a UTC timestamp string in RFC 3339 format.", "string"),
+
+		// Hash and crypto functions
+		function("base64sha256", "Computes the SHA256 hash of a given string and encodes it with Base64.", "string", param("str", "string")),
+		function("base64sha512", "Computes the SHA512 hash of a given string and encodes it with Base64.", "string", param("str", "string")),
+		withVariadicParam(function("bcrypt", "Computes a hash of the given string using the Blowfish cipher, returning a string in the Modular Crypt Format.", "string", param("str", "string")), param("cost", "number")),
+		function("filebase64sha256", "A variant of base64sha256 that hashes the contents of a given file rather than a literal string.", "string", param("path", "string")),
+		function("filebase64sha512", "A variant of base64sha512 that hashes the contents of a given file rather than a literal string.", "string", param("path", "string")),
+		function("filemd5", "A variant of md5 that hashes the contents of a given file rather than a literal string.", "string", param("path", "string")),
+		function("filesha1", "A variant of sha1 that hashes the contents of a given file rather than a literal string.", "string", param("path", "string")),
+		function("filesha256", "A variant of sha256 that hashes the contents of a given file rather than a literal string.", "string", param("path", "string")),
+		function("filesha512", "A variant of sha512 that hashes the contents of a given file rather than a literal string.", "string", param("path", "string")),
+		function("md5", "Computes the MD5 hash of a given string and encodes it with hexadecimal digits.", "string", param("str", "string")),
+		function("rsadecrypt", "Decrypts an RSA-encrypted ciphertext, returning the corresponding cleartext.", "string", param("ciphertext", "string"), param("privatekey", "string")),
+		function("sha1", "Computes the SHA1 hash of a given string and encodes it with hexadecimal digits.", "string", param("str", "string")),
+		function("sha256", "Computes the SHA256 hash of a given string and encodes it with hexadecimal digits.", "string", param("str", "string")),
+		function("sha512", "Computes the SHA512 hash of a given string and encodes it with hexadecimal digits.", "string", param("str", "string")),
+		function("uuid", "Generates a unique identifier string.", "string"),
+		function("uuidv5", "Generates a unique identifier string based on a namespace and a name.", "string", param("namespace", "string"), param("name", "string")),
+
+		// IP network functions
+		function("cidrhost", "Calculates a full host IP address for a given host number within a given IP network address prefix.", "string", param("prefix", "string"), param("hostnum", "number")),
+		function("cidrnetmask", "Converts an IPv4 address prefix given in CIDR notation into a subnet mask address.", "string", param("prefix", "string")),
+		function("cidrsubnet", "Calculates a subnet address within given IP network address prefix.", "string", param("prefix", "string"), param("newbits", "number"), param("netnum", "number")),
+
+		// Type conversion functions
+		function("tobool", "Converts its argument to a boolean value.", "bool", param("v", "any")),
+		function("tolist", "Converts its argument to a list value.", "list of any", param("v", "any")),
+		function("tomap", "Converts its argument to a map value.", "map of any", param("v", "any")),
+		function("tonumber", "Converts its argument to a number value.", "number", param("v", "any")),
+		function("toset", "Converts its argument to a set value.", "set of any", param("v", "any")),
+		function("tostring", "Converts its argument to a string value.", "string", param("v", "any")),
+	},
+	"0.12.2": {
+		withVariadicParam(function("range", "Generates a list of numbers using a start value, a limit value, and a step value.", "list of number"), param("params", "number")),
+		function("yamldecode", "Parses a string as a subset of YAML, and produces a representation of its value.", "any", param("src", "string")),
+		function("yamlencode", "Encodes a given value to a string using YAML 1.2 block syntax.", "string", param("value", "any")),
+	},
+	"0.12.7": {
+		function("regex", "Applies a regular expression to a string and returns the matching substrings.", "any", param("pattern", "string"), param("string", "string")),
+		function("regexall", "Applies a regular expression to a string and returns a list of all matches.", "list of any", param("pattern", "string"), param("string", "string")),
+	},
+	"0.12.8": {
+		function("fileset", "Enumerates a set of regular file names given a path and pattern.", "set of string", param("path", "string"), param("pattern", "string")),
+	},
+	"0.12.10": {
+		withVariadicParam(function("cidrsubnets", "Calculates a sequence of consecutive IP address ranges within a particular CIDR prefix.", "list of string", param("prefix", "string")), param("newbits", "number")),
+		function("parseint", "Parses the given string as a representation of an integer in the specified base and returns the resulting number.", "number", param("number", "string"), param("base", "number")),
+	},
+	"0.12.17": {
+		function("trim", "Removes the specified characters from the start and end of the given string.", "string", param("str", "string"), param("cutset", "string")),
+		function("trimprefix", "Removes the specified prefix from the start of the given string.", "string", param("str", "string"), param("prefix", "string")),
+		function("trimsuffix", "Removes the specified suffix from the end of the given string.", "string", param("str", "string"), param("suffix", "string")),
+	},
+	"0.12.20": {
+		function("can", "Evaluates the given expression and returns a boolean value indicating whether the expression produced a result without any errors.", "bool", param("expression", "any")),
+		withVariadicParam(function("try", "Evaluates all of its argument expressions in turn and returns the result of the first one that does not produce any errors.", "any"), param("expressions", "any")),
+	},
+	"0.13.0": {
+		function("sum", "Takes a list or set of numbers and returns the sum of those numbers.", "number", param("list", "list of number")),
+	},
+	"0.14.0": {
+		function("alltrue", "Returns true if all elements in a given collection are true or \"true\". It also returns true if the collection is empty.", "bool", param("list", "list of bool")),
+		function("anytrue", "Returns true if any element in a given collection is true or \"true\". It also returns false if the collection is empty.", "bool", param("list", "list of bool")),
+		function("textdecodebase64", "Decodes a string that was previously Base64-encoded, and then interprets the result as characters in a specified character encoding.", "string", param("source", "string"), param("encoding", "string")),
+		function("textencodebase64", "Encodes the unicode characters in a given string using a specified character encoding, returning the result Base64-encoded.", "string", param("string", "string"), param("encoding", "string")),
+	},
+	"0.15.0": {
+		function("nonsensitive", "Takes a sensitive value and returns a copy of that value with the sensitive marking removed, thereby exposing the sensitive value.", "any", param("value", "any")),
+		function("one", "Takes a list, set, or tuple value with either zero or one elements. If the collection is empty, returns null. Otherwise, returns the first element.", "any", param("list", "list of any")),
+		function("sensitive", "Takes any value and returns a copy of it marked so that Terraform will treat it as sensitive, with the same meaning and behavior as for sensitive input variables.", "any", param("value", "any")),
+	},
+}
+
+// functionsForVersion returns all built-in functions
+// available in the given Terraform version,
+// or all known functions if version is not known
+func functionsForVersion(v *version.Version) map[string]*FunctionSignature {
+	functions := make(map[string]*FunctionSignature, 0)
+	for since, signatures := range functionsSince {
+		if !versionSupports(v, since) {
+			continue
+		}
+		for _, fs := range signatures {
+			functions[fs.Name] = fs
+		}
+	}
+	return functions
+}
+
+func function(name, description, returnType string, params ...FunctionParam) *FunctionSignature {
+	return &FunctionSignature{
+		Name:        name,
+		Description: description,
+		Params:      params,
+		ReturnType:  returnType,
+	}
+}
+
+func withVariadicParam(fs *FunctionSignature, param FunctionParam) *FunctionSignature {
+	fs.VariadicParam = &param
+	return fs
+}
+
+func param(name, typeName string) FunctionParam {
+	return FunctionParam{Name: name, Type: typeName}
+}
@@ -14,6 +14,10 @@ func (a *Attribute) Range() hcl.Range {
 	return a.hclAttribute.Range
 }
 
+func (a *Attribute) NameRange() hcl.Range {
+	return a.hclAttribute.NameRange
+}
+
 func (a *Attribute) IsDeclared() bool {
 	return a.hclAttribute != nil
 }
@@ -3,10 +3,12 @@ package lang
 import (
 	hcl "github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfjson "github.com/hashicorp/terraform-json"
 )
 
 type parsedBlock struct {
 	hclBlock      *hclsyntax.Block
+	schema        *tfjson.SchemaBlock
 	AttributesMap map[string]*Attribute
 	BlockTypesMap map[string]*BlockType
 
@@ -48,6 +50,10 @@ func (b *parsedBlock) Range() hcl.Range {
 	return b.hclBlock.Range()
 }
 
+func (b *parsedBlock) DefRange() hcl.Range {
+	return b.hclBlock.DefRange()
+}
+
 func (b *parsedBlock) PosInBody(pos hcl.Pos) bool {
 	for _, blockType := range b.BlockTypesMap {
 		for _, b := range blockType.BlockList {
@@ -76,6 +82,21 @@ func posInBodyOfBlock(block *hclsyntax.Block, pos hcl.Pos) bool {
 		!block.CloseBraceRange.ContainsPos(pos)
 }
 
+func (b *parsedBlock) AttributeAtPos(pos hcl.Pos) (string, *Attribute, bool) {
+	for name, attr := range b.AttributesMap {
+		if !attr.IsDeclared() {
+			continue
+		}
+
+		// Account for the last character
+		if rangeContainsOffset(attr.Range(), pos.Byte) {
+			return name, attr, true
+		}
+	}
+
+	return "", nil, false
+}
+
 func (b *parsedBlock) PosInAttribute(pos hcl.Pos) bool {
 	for _, attr := range b.AttributesMap {
 		if !attr.IsDeclared() {
@@ -61,11 +61,25 @@ func (bt BlockTypes) AddBlock(name string, block *hclsyntax.Block, typeSchema *t
 	}
 
 	if block != nil {
-		// SDK doesn't support named blocks yet, so we expect no labels here for now
-		bt[name].BlockList = append(bt[name].BlockList, parseBlock(block, typeSchema.Block))
+		bt[name].BlockList = append(bt[name].BlockList, parseBlock(block, blockSchema(block, typeSchema)))
 	}
 }
 
+// blockSchema returns schema for the given nested block
+//
+// SDK doesn't support named blocks yet, so labels only matter for
+// built-in block types, such as provisioner "local-exec"
+func blockSchema(block *hclsyntax.Block, typeSchema *tfjson.SchemaBlockType) *tfjson.SchemaBlock {
+	schemas, ok := labeledBlockSchemas[typeSchema]
+	if !ok {
+		return typeSchema.Block
+	}
+	if len(block.Labels) == 0 {
+		return nil
+	}
+	return schemas[block.Labels[0]]
+}
+
 func parseBlockTypes(blocks hclsyntax.Blocks, schemas map[string]*tfjson.SchemaBlockType) (BlockTypes, hclsyntax.Blocks) {
 	var blockTypes BlockTypes = make(map[string]*BlockType, 0)
 	remainingBlocks := blocks
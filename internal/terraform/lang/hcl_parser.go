@@ -21,6 +21,7 @@ func ParseBlock(tBlock ihcl.TokenizedBlock, schema *tfjson.SchemaBlock) Block {
 func parseBlock(block *hclsyntax.Block, schema *tfjson.SchemaBlock) Block {
 	b := &parsedBlock{
 		hclBlock: block,
+		schema:   schema,
 	}
 	if block == nil {
 		return b
@@ -0,0 +1,69 @@
+package lang
+
+import (
+	"log"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+)
+
+type localsBlockFactory struct {
+	logger *log.Logger
+}
+
+func (f *localsBlockFactory) New(tBlock ihcl.TokenizedBlock) (ConfigBlock, error) {
+	if f.logger == nil {
+		f.logger = discardLog()
+	}
+
+	return &localsBlock{
+		logger: f.logger,
+		tBlock: tBlock,
+	}, nil
+}
+
+func (f *localsBlockFactory) LabelSchema() LabelSchema {
+	return LabelSchema{}
+}
+
+func (f *localsBlockFactory) BlockType() string {
+	return "locals"
+}
+
+func (f *localsBlockFactory) Documentation() MarkupContent {
+	return PlainText("A locals block assigns names to expressions, so they can be used multiple times " +
+		"within the module without repeating them.")
+}
+
+// localsBlock represents a block whose attributes are all declared
+// by the user, so there is nothing to complete or validate
+// (values are completed as expressions)
+type localsBlock struct {
+	logger *log.Logger
+
+	tBlock ihcl.TokenizedBlock
+}
+
+func (l *localsBlock) Name() string {
+	return "locals"
+}
+
+func (l *localsBlock) Labels() []*ParsedLabel {
+	return []*ParsedLabel{}
+}
+
+func (l *localsBlock) BlockType() string {
+	return "locals"
+}
+
+func (l *localsBlock) CompletionCandidatesAtPos(pos hcl.Pos) (CompletionCandidates, error) {
+	return &candidateList{candidates: make([]CompletionCandidate, 0)}, nil
+}
+
+func (l *localsBlock) HoverAtPos(pos hcl.Pos) (*HoverData, error) {
+	return nil, nil
+}
+
+func (l *localsBlock) Validate() (hcl.Diagnostics, error) {
+	return hcl.Diagnostics{}, nil
+}
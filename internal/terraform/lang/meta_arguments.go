@@ -0,0 +1,456 @@
+package lang
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/go-version"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// metaArgument represents an argument which Terraform
+// handles itself, regardless of the provider
+type metaArgument struct {
+	schema *tfjson.SchemaAttribute
+
+	// since is the first Terraform version supporting the argument
+	// (empty if supported by all versions the parser supports)
+	since string
+}
+
+// supportedBy reports whether the meta-argument is supported
+// by the given Terraform version
+func (ma *metaArgument) supportedBy(v *version.Version) bool {
+	return versionSupports(v, ma.since)
+}
+
+// versionSupports reports whether the given Terraform version is
+// at least the given one, assuming that unknown version supports
+// everything and that empty "since" is supported by any version
+func versionSupports(v *version.Version, since string) bool {
+	if v == nil || since == "" {
+		return true
+	}
+	return !v.LessThan(version.Must(version.NewVersion(since)))
+}
+
+// versionPrecedes reports whether the given version precedes
+// the version in which a feature was removed, i.e. still supports it.
+// Unknown version is assumed to support all features.
+func versionPrecedes(v *version.Version, until string) bool {
+	if v == nil || until == "" {
+		return true
+	}
+	return v.LessThan(version.Must(version.NewVersion(until)))
+}
+
+var countableMetaArguments = map[string]*metaArgument{
+	"count": {
+		schema: &tfjson.SchemaAttribute{
+			AttributeType: cty.Number,
+			Optional:      true,
+			Description:   "The number of instances to create.",
+		},
+	},
+	"for_each": {
+		schema: &tfjson.SchemaAttribute{
+			AttributeType: cty.DynamicPseudoType,
+			Optional:      true,
+			Description:   "A map or a set of strings to create an instance for each item of.",
+		},
+		since: "0.12.6",
+	},
+	"depends_on": {
+		schema: &tfjson.SchemaAttribute{
+			AttributeType: cty.DynamicPseudoType,
+			Optional:      true,
+			Description:   "A list of hidden dependencies which Terraform cannot infer from references.",
+		},
+	},
+	"provider": {
+		schema: &tfjson.SchemaAttribute{
+			AttributeType: cty.DynamicPseudoType,
+			Optional:      true,
+			Description:   "Reference to a non-default provider configuration, e.g. aws.west.",
+		},
+	},
+}
+
+var lifecycleBlockType = &tfjson.SchemaBlockType{
+	NestingMode: tfjson.SchemaNestingModeSingle,
+	Block: &tfjson.SchemaBlock{
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"create_before_destroy": {
+				AttributeType: cty.Bool,
+				Optional:      true,
+				Description:   "Create the replacement object first and destroy the prior object afterwards.",
+			},
+			"prevent_destroy": {
+				AttributeType: cty.Bool,
+				Optional:      true,
+				Description:   "Reject any plan which would destroy the infrastructure object.",
+			},
+			"ignore_changes": {
+				AttributeType: cty.DynamicPseudoType,
+				Optional:      true,
+				Description:   "A list of attributes to ignore changes of when planning updates, or all.",
+			},
+		},
+	},
+}
+
+var connectionBlockType = &tfjson.SchemaBlockType{
+	NestingMode: tfjson.SchemaNestingModeSingle,
+	Block: &tfjson.SchemaBlock{
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"type":                {AttributeType: cty.String, Optional: true, Description: "The connection type, ssh (default) or winrm."},
+			"user":                {AttributeType: cty.String, Optional: true, Description: "The user to use for the connection."},
+			"password":            {AttributeType: cty.String, Optional: true, Description: "The password to use for the connection."},
+			"host":                {AttributeType: cty.String, Required: true, Description: "The address of the resource to connect to."},
+			"port":                {AttributeType: cty.Number, Optional: true, Description: "The port to connect to."},
+			"timeout":             {AttributeType: cty.String, Optional: true, Description: "The timeout to wait for the connection to become available."},
+			"script_path":         {AttributeType: cty.String, Optional: true, Description: "The path used to copy scripts meant for remote execution."},
+			"private_key":         {AttributeType: cty.String, Optional: true, Description: "The contents of an SSH key to use for the connection."},
+			"certificate":         {AttributeType: cty.String, Optional: true, Description: "The contents of a signed CA certificate."},
+			"agent":               {AttributeType: cty.Bool, Optional: true, Description: "Whether to use ssh-agent for authentication."},
+			"agent_identity":      {AttributeType: cty.String, Optional: true, Description: "The preferred identity from the ssh agent for authentication."},
+			"host_key":            {AttributeType: cty.String, Optional: true, Description: "The public key from the remote host or the signing CA, used to verify the connection."},
+			"bastion_host":        {AttributeType: cty.String, Optional: true, Description: "The address of a bastion host to connect through."},
+			"bastion_host_key":    {AttributeType: cty.String, Optional: true, Description: "The public key from the bastion host or the signing CA."},
+			"bastion_port":        {AttributeType: cty.Number, Optional: true, Description: "The port to use to connect to the bastion host."},
+			"bastion_user":        {AttributeType: cty.String, Optional: true, Description: "The user for the connection to the bastion host."},
+			"bastion_password":    {AttributeType: cty.String, Optional: true, Description: "The password to use for the bastion host."},
+			"bastion_private_key": {AttributeType: cty.String, Optional: true, Description: "The contents of an SSH key file to use for the bastion host."},
+			"bastion_certificate": {AttributeType: cty.String, Optional: true, Description: "The contents of a signed CA certificate for the bastion host."},
+			"https":               {AttributeType: cty.Bool, Optional: true, Description: "Whether to connect using HTTPS (winrm only)."},
+			"insecure":            {AttributeType: cty.Bool, Optional: true, Description: "Whether to skip validation of the HTTPS certificate chain (winrm only)."},
+			"use_ntlm":            {AttributeType: cty.Bool, Optional: true, Description: "Whether to use NTLM authentication (winrm only)."},
+			"cacert":              {AttributeType: cty.String, Optional: true, Description: "The CA certificate to validate against (winrm only)."},
+		},
+	},
+}
+
+// provisionerBlockType describes provisioner blocks in general,
+// schema of each block depends on its type (first label)
+var provisionerBlockType = &tfjson.SchemaBlockType{
+	NestingMode: tfjson.SchemaNestingModeList,
+}
+
+// provisionerSchemas maps built-in provisioner types to their schemas
+var provisionerSchemas = map[string]*tfjson.SchemaBlock{
+	"local-exec": provisionerSchema(map[string]*tfjson.SchemaAttribute{
+		"command":     {AttributeType: cty.String, Required: true, Description: "The command to execute."},
+		"working_dir": {AttributeType: cty.String, Optional: true, Description: "The working directory where command will be executed."},
+		"interpreter": {AttributeType: cty.List(cty.String), Optional: true, Description: "The interpreter and its arguments used to execute the command."},
+		"environment": {AttributeType: cty.Map(cty.String), Optional: true, Description: "Environment variables to set for the command."},
+	}),
+	"remote-exec": provisionerSchema(map[string]*tfjson.SchemaAttribute{
+		"inline":  {AttributeType: cty.List(cty.String), Optional: true, Description: "A list of command strings to execute in the order provided."},
+		"script":  {AttributeType: cty.String, Optional: true, Description: "A path to a local script to copy to the remote resource and execute."},
+		"scripts": {AttributeType: cty.List(cty.String), Optional: true, Description: "A list of paths to local scripts to copy to the remote resource and execute."},
+	}),
+	"file": provisionerSchema(map[string]*tfjson.SchemaAttribute{
+		"source":      {AttributeType: cty.String, Optional: true, Description: "The source file or folder to copy."},
+		"content":     {AttributeType: cty.String, Optional: true, Description: "The content to copy to the destination."},
+		"destination": {AttributeType: cty.String, Required: true, Description: "The destination path on the remote resource."},
+	}),
+	"chef": provisionerSchema(map[string]*tfjson.SchemaAttribute{
+		"server_url":              {AttributeType: cty.String, Required: true, Description: "The URL to the Chef server."},
+		"node_name":               {AttributeType: cty.String, Required: true, Description: "The name of the node to register with the Chef server."},
+		"user_name":               {AttributeType: cty.String, Required: true, Description: "The name of an existing Chef user to register the node."},
+		"user_key":                {AttributeType: cty.String, Required: true, Description: "The contents of the user key used to register the node."},
+		"run_list":                {AttributeType: cty.List(cty.String), Optional: true, Description: "A list of recipes or roles to run on the node."},
+		"attributes_json":         {AttributeType: cty.String, Optional: true, Description: "A raw JSON string with initial node attributes."},
+		"environment":             {AttributeType: cty.String, Optional: true, Description: "The Chef environment the node will be in."},
+		"channel":                 {AttributeType: cty.String, Optional: true, Description: "The release channel from which to install Chef Client, stable (default) or current."},
+		"client_options":          {AttributeType: cty.List(cty.String), Optional: true, Description: "A list of optional Chef Client configuration options."},
+		"disable_reporting":       {AttributeType: cty.Bool, Optional: true, Description: "Whether to disable the Chef Client reporting."},
+		"fetch_chef_certificates": {AttributeType: cty.Bool, Optional: true, Description: "Whether to fetch the Chef server certificates."},
+		"http_proxy":              {AttributeType: cty.String, Optional: true, Description: "The proxy server for Chef Client HTTP connections."},
+		"https_proxy":             {AttributeType: cty.String, Optional: true, Description: "The proxy server for Chef Client HTTPS connections."},
+		"no_proxy":                {AttributeType: cty.List(cty.String), Optional: true, Description: "A list of URLs that should bypass the proxy server."},
+		"log_to_file":             {AttributeType: cty.Bool, Optional: true, Description: "Whether to write the output of the initial run to a log file."},
+		"max_retries":             {AttributeType: cty.Number, Optional: true, Description: "The number of times to retry the Chef Client run."},
+		"retry_on_exit_code":      {AttributeType: cty.List(cty.Number), Optional: true, Description: "A list of exit codes on which to retry the Chef Client run."},
+		"wait_for_retry":          {AttributeType: cty.Number, Optional: true, Description: "The number of seconds to wait before retrying."},
+		"named_run_list":          {AttributeType: cty.String, Optional: true, Description: "The name of an alternate run list from the policy."},
+		"ohai_hints":              {AttributeType: cty.List(cty.String), Optional: true, Description: "A list of Ohai hint files to upload to the node."},
+		"os_type":                 {AttributeType: cty.String, Optional: true, Description: "The OS type of the node, linux or windows."},
+		"policy_group":            {AttributeType: cty.String, Optional: true, Description: "The name of a policy group that exists on the Chef server."},
+		"policy_name":             {AttributeType: cty.String, Optional: true, Description: "The name of a policy, as identified by the name setting in a Policyfile."},
+		"prevent_sudo":            {AttributeType: cty.Bool, Optional: true, Description: "Whether to prevent the use of sudo while installing and running Chef Client."},
+		"recreate_client":         {AttributeType: cty.Bool, Optional: true, Description: "Whether to recreate the client if a node or client with the same name exists."},
+		"secret_key":              {AttributeType: cty.String, Optional: true, Description: "The contents of the secret key used by the client to decrypt data bags."},
+		"skip_install":            {AttributeType: cty.Bool, Optional: true, Description: "Whether to skip the installation of Chef Client."},
+		"skip_register":           {AttributeType: cty.Bool, Optional: true, Description: "Whether to skip the registration of Chef Client."},
+		"ssl_verify_mode":         {AttributeType: cty.String, Optional: true, Description: "The SSL verification mode used by Chef Client."},
+		"use_policyfile":          {AttributeType: cty.Bool, Optional: true, Description: "Whether to use a Policyfile instead of a run list."},
+		"vault_json":              {AttributeType: cty.String, Optional: true, Description: "A raw JSON string with Chef Vaults and items to which the client has access."},
+		"version":                 {AttributeType: cty.String, Optional: true, Description: "The Chef Client version to install on the node."},
+	}),
+	"habitat": provisionerSchema(map[string]*tfjson.SchemaAttribute{
+		"version":            {AttributeType: cty.String, Optional: true, Description: "The Habitat version to install on the node."},
+		"accept_license":     {AttributeType: cty.Bool, Optional: true, Description: "Whether to accept the Habitat end user license agreement."},
+		"auto_update":        {AttributeType: cty.Bool, Optional: true, Description: "Whether to automatically update the Habitat supervisor."},
+		"http_disable":       {AttributeType: cty.Bool, Optional: true, Description: "Whether to disable the supervisor HTTP listener."},
+		"peer":               {AttributeType: cty.String, Optional: true, Description: "IP addresses or FQDNs of the supervisor peers."},
+		"permanent_peer":     {AttributeType: cty.Bool, Optional: true, Description: "Whether to mark this supervisor as a permanent peer."},
+		"listen_ctl":         {AttributeType: cty.String, Optional: true, Description: "The listen address for the control gateway system."},
+		"listen_gossip":      {AttributeType: cty.String, Optional: true, Description: "The listen address for the gossip system."},
+		"listen_http":        {AttributeType: cty.String, Optional: true, Description: "The listen address for the HTTP gateway."},
+		"ring_key":           {AttributeType: cty.String, Optional: true, Description: "The name of the ring key for encrypting gossip ring communication."},
+		"ring_key_content":   {AttributeType: cty.String, Optional: true, Description: "The contents of the ring key."},
+		"ctl_secret":         {AttributeType: cty.String, Optional: true, Description: "The secret key used to authenticate remote supervisor commands."},
+		"url":                {AttributeType: cty.String, Optional: true, Description: "The URL of the Habitat Builder depot."},
+		"channel":            {AttributeType: cty.String, Optional: true, Description: "The channel to install Habitat packages from."},
+		"events":             {AttributeType: cty.String, Optional: true, Description: "The name of the service group for events."},
+		"organization":       {AttributeType: cty.String, Optional: true, Description: "The organization the supervisor and its services are part of."},
+		"gateway_auth_token": {AttributeType: cty.String, Optional: true, Description: "The token for authenticating requests to the HTTP gateway."},
+		"builder_auth_token": {AttributeType: cty.String, Optional: true, Description: "The token for accessing private packages in Habitat Builder."},
+		"service_type":       {AttributeType: cty.String, Optional: true, Description: "The method used to run the supervisor, systemd (default) or unmanaged."},
+		"service_name":       {AttributeType: cty.String, Optional: true, Description: "The name of the supervisor service."},
+		"use_sudo":           {AttributeType: cty.Bool, Optional: true, Description: "Whether to use sudo when executing the provisioner."},
+	}, map[string]*tfjson.SchemaBlockType{
+		"service": habitatServiceBlockType,
+	}),
+	"puppet": provisionerSchema(map[string]*tfjson.SchemaAttribute{
+		"server":             {AttributeType: cty.String, Required: true, Description: "The FQDN of the Puppet master the agent connects to."},
+		"server_user":        {AttributeType: cty.String, Optional: true, Description: "The user used to connect to the Puppet master, root (default)."},
+		"os_type":            {AttributeType: cty.String, Optional: true, Description: "The OS type of the node, linux or windows."},
+		"autosign":           {AttributeType: cty.Bool, Optional: true, Description: "Whether to automatically sign the agent certificate on the Puppet master."},
+		"open_source":        {AttributeType: cty.Bool, Optional: true, Description: "Whether the Puppet master is open source (true) or Puppet Enterprise."},
+		"certname":           {AttributeType: cty.String, Optional: true, Description: "The name of the agent node certificate."},
+		"extension_requests": {AttributeType: cty.Map(cty.String), Optional: true, Description: "Extension requests embedded in the agent certificate signing request."},
+		"environment":        {AttributeType: cty.String, Optional: true, Description: "The Puppet environment the node is assigned to."},
+		"bolt_timeout":       {AttributeType: cty.String, Optional: true, Description: "The timeout to wait for Bolt tasks to complete."},
+		"use_sudo":           {AttributeType: cty.Bool, Optional: true, Description: "Whether to use sudo when executing the provisioner."},
+	}),
+	"salt-masterless": provisionerSchema(map[string]*tfjson.SchemaAttribute{
+		"local_state_tree":    {AttributeType: cty.String, Required: true, Description: "The path to the local Salt state tree to upload."},
+		"local_pillar_roots":  {AttributeType: cty.String, Optional: true, Description: "The path to the local pillar roots to upload."},
+		"remote_state_tree":   {AttributeType: cty.String, Optional: true, Description: "The path on the node to upload the Salt state tree to."},
+		"remote_pillar_roots": {AttributeType: cty.String, Optional: true, Description: "The path on the node to upload the pillar roots to."},
+		"temp_config_dir":     {AttributeType: cty.String, Optional: true, Description: "The directory on the node to upload files to."},
+		"minion_config_file":  {AttributeType: cty.String, Optional: true, Description: "The path to the local minion config file to upload."},
+		"bootstrap_args":      {AttributeType: cty.String, Optional: true, Description: "Arguments to pass to the Salt bootstrap script."},
+		"skip_bootstrap":      {AttributeType: cty.Bool, Optional: true, Description: "Whether to skip the installation of Salt."},
+		"no_exit_on_failure":  {AttributeType: cty.Bool, Optional: true, Description: "Whether to ignore failures of the Salt run."},
+		"log_level":           {AttributeType: cty.String, Optional: true, Description: "The log level of the Salt run."},
+		"disable_sudo":        {AttributeType: cty.Bool, Optional: true, Description: "Whether to run Salt without sudo."},
+		"custom_state":        {AttributeType: cty.String, Optional: true, Description: "A state to run instead of the highstate."},
+		"cmd_args":            {AttributeType: cty.String, Optional: true, Description: "Additional arguments to pass to salt-call."},
+	}),
+}
+
+// provisionersUntil maps provisioner types to the Terraform version
+// which removed them, provisioners not listed here are supported
+// by all versions the parser supports
+var provisionersUntil = map[string]string{
+	"chef":            "0.15.0",
+	"habitat":         "0.15.0",
+	"puppet":          "0.15.0",
+	"salt-masterless": "0.15.0",
+}
+
+var habitatServiceBlockType = &tfjson.SchemaBlockType{
+	NestingMode: tfjson.SchemaNestingModeList,
+	Block: &tfjson.SchemaBlock{
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"name":        {AttributeType: cty.String, Required: true, Description: "The Habitat package identifier of the service."},
+			"binds":       {AttributeType: cty.List(cty.String), Optional: true, Description: "A list of bind specifications for the service."},
+			"topology":    {AttributeType: cty.String, Optional: true, Description: "The topology of the service, standalone or leader."},
+			"strategy":    {AttributeType: cty.String, Optional: true, Description: "The update strategy of the service, none, rolling or at-once."},
+			"user_toml":   {AttributeType: cty.String, Optional: true, Description: "The contents of a user.toml file for the service."},
+			"channel":     {AttributeType: cty.String, Optional: true, Description: "The channel to install the service from."},
+			"group":       {AttributeType: cty.String, Optional: true, Description: "The service group to join."},
+			"url":         {AttributeType: cty.String, Optional: true, Description: "The URL of the Habitat Builder depot to install the service from."},
+			"application": {AttributeType: cty.String, Optional: true, Description: "The application name of the service."},
+			"environment": {AttributeType: cty.String, Optional: true, Description: "The environment name of the service."},
+			"service_key": {AttributeType: cty.String, Optional: true, Description: "The contents of the service group key."},
+		},
+		NestedBlocks: map[string]*tfjson.SchemaBlockType{
+			"bind": {
+				NestingMode: tfjson.SchemaNestingModeList,
+				Block: &tfjson.SchemaBlock{
+					Attributes: map[string]*tfjson.SchemaAttribute{
+						"alias":   {AttributeType: cty.String, Required: true, Description: "The name of the bind."},
+						"service": {AttributeType: cty.String, Required: true, Description: "The name of the service to bind to."},
+						"group":   {AttributeType: cty.String, Required: true, Description: "The service group of the service to bind to."},
+					},
+				},
+			},
+		},
+	},
+}
+
+// provisionerSupportedBy reports whether the given provisioner type
+// is supported by the given Terraform version
+func provisionerSupportedBy(name string, v *version.Version) bool {
+	return versionPrecedes(v, provisionersUntil[name])
+}
+
+// validateProvisionerTypes reports provisioner blocks within the given
+// resource block, whose type was removed in the given Terraform version
+func validateProvisionerTypes(block *hclsyntax.Block, v *version.Version) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	if block == nil || block.Body == nil {
+		return diags
+	}
+
+	for _, b := range block.Body.Blocks {
+		if b.Type != "provisioner" || len(b.Labels) == 0 {
+			continue
+		}
+		name := b.Labels[0]
+		if !provisionerSupportedBy(name, v) {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Unsupported provisioner type",
+				Detail: fmt.Sprintf("Provisioner type %q was removed in Terraform %s.",
+					name, provisionersUntil[name]),
+				Subject: b.LabelRanges[0].Ptr(),
+			})
+		}
+	}
+
+	return diags
+}
+
+// labeledBlockSchemas maps built-in nested block types
+// to schemas depending on the first label of the block
+var labeledBlockSchemas = map[*tfjson.SchemaBlockType]map[string]*tfjson.SchemaBlock{
+	provisionerBlockType: provisionerSchemas,
+	backendBlockType:     backendSchemas,
+
+	// provider_meta content is provider-defined, so it's never validated
+	providerMetaBlockType: {},
+}
+
+// labeledBlockSupportedBy maps built-in nested block types to functions
+// reporting whether a block type (first label) is supported
+// by the given Terraform version
+var labeledBlockSupportedBy = map[*tfjson.SchemaBlockType]func(string, *version.Version) bool{
+	provisionerBlockType: provisionerSupportedBy,
+	backendBlockType:     backendSupportedBy,
+}
+
+// labeledBlockTypes returns sorted types (first labels) of the given
+// built-in nested block type, which the given Terraform version supports
+func labeledBlockTypes(typeSchema *tfjson.SchemaBlockType, v *version.Version) []string {
+	schemas := labeledBlockSchemas[typeSchema]
+	supportedBy, filter := labeledBlockSupportedBy[typeSchema]
+
+	types := make([]string, 0, len(schemas))
+	for t := range schemas {
+		if filter && !supportedBy(t, v) {
+			continue
+		}
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	return types
+}
+
+func provisionerSchema(attrs map[string]*tfjson.SchemaAttribute, blocks ...map[string]*tfjson.SchemaBlockType) *tfjson.SchemaBlock {
+	attrs["when"] = &tfjson.SchemaAttribute{
+		AttributeType: cty.DynamicPseudoType,
+		Optional:      true,
+		Description:   "When to run the provisioner, create (default) or destroy.",
+	}
+	attrs["on_failure"] = &tfjson.SchemaAttribute{
+		AttributeType: cty.DynamicPseudoType,
+		Optional:      true,
+		Description:   "What to do when the provisioner fails, fail (default) or continue.",
+	}
+
+	nestedBlocks := map[string]*tfjson.SchemaBlockType{
+		"connection": connectionBlockType,
+	}
+	for _, bTypes := range blocks {
+		for name, bType := range bTypes {
+			nestedBlocks[name] = bType
+		}
+	}
+
+	return &tfjson.SchemaBlock{
+		Attributes:   attrs,
+		NestedBlocks: nestedBlocks,
+	}
+}
+
+// withResourceMetaArguments returns a copy of the given schema
+// extended with meta-arguments which Terraform handles
+// in every resource block, regardless of the provider
+func withResourceMetaArguments(s *tfjson.SchemaBlock, v *version.Version) *tfjson.SchemaBlock {
+	block := withCountableMetaArguments(s, v)
+
+	block.NestedBlocks["lifecycle"] = lifecycleBlockType
+	block.NestedBlocks["connection"] = connectionBlockType
+	block.NestedBlocks["provisioner"] = provisionerBlockType
+
+	return block
+}
+
+// withDataSourceMetaArguments returns a copy of the given schema
+// extended with meta-arguments which Terraform handles
+// in every data block, regardless of the provider
+func withDataSourceMetaArguments(s *tfjson.SchemaBlock, v *version.Version) *tfjson.SchemaBlock {
+	return withCountableMetaArguments(s, v)
+}
+
+// withProviderMetaArguments returns a copy of the given schema
+// extended with meta-arguments which Terraform handles
+// in every provider block, regardless of the provider
+func withProviderMetaArguments(s *tfjson.SchemaBlock) *tfjson.SchemaBlock {
+	block := copySchemaBlock(s)
+
+	block.Attributes["alias"] = &tfjson.SchemaAttribute{
+		AttributeType: cty.String,
+		Optional:      true,
+	}
+	block.Attributes["version"] = &tfjson.SchemaAttribute{
+		AttributeType: cty.String,
+		Optional:      true,
+	}
+
+	return block
+}
+
+func withCountableMetaArguments(s *tfjson.SchemaBlock, v *version.Version) *tfjson.SchemaBlock {
+	block := copySchemaBlock(s)
+
+	for name, ma := range countableMetaArguments {
+		if !ma.supportedBy(v) {
+			continue
+		}
+		block.Attributes[name] = ma.schema
+	}
+
+	return block
+}
+
+func copySchemaBlock(s *tfjson.SchemaBlock) *tfjson.SchemaBlock {
+	block := &tfjson.SchemaBlock{
+		Attributes:   make(map[string]*tfjson.SchemaAttribute, 0),
+		NestedBlocks: make(map[string]*tfjson.SchemaBlockType, 0),
+	}
+	if s == nil {
+		return block
+	}
+
+	block.Description = s.Description
+	block.DescriptionKind = s.DescriptionKind
+	block.Deprecated = s.Deprecated
+	for name, attr := range s.Attributes {
+		block.Attributes[name] = attr
+	}
+	for name, bType := range s.NestedBlocks {
+		block.NestedBlocks[name] = bType
+	}
+
+	return block
+}
@@ -0,0 +1,228 @@
+package lang
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-ls/internal/terraform/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestResourceBlock_completionCandidatesAtPos_metaArguments(t *testing.T) {
+	schemas := &tfjson.ProviderSchemas{
+		FormatVersion: "0.1",
+		Schemas: map[string]*tfjson.ProviderSchema{
+			"custom": {
+				ResourceSchemas: map[string]*tfjson.Schema{
+					"custom_rs": {
+						Block: &tfjson.SchemaBlock{
+							Attributes: map[string]*tfjson.SchemaAttribute{
+								"attr": {
+									AttributeType: cty.String,
+									Optional:      true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		tfVersion          string
+		src                string
+		expectedCandidates []string
+	}{
+		{
+			"0.12.6",
+			`resource "custom_rs" "name" {
+  |
+}`,
+			[]string{"attr", "connection", "count", "depends_on", "for_each",
+				"lifecycle", "provider", "provisioner"},
+		},
+		{
+			"0.12.5",
+			`resource "custom_rs" "name" {
+  |
+}`,
+			[]string{"attr", "connection", "count", "depends_on",
+				"lifecycle", "provider", "provisioner"},
+		},
+		{
+			"0.12.0",
+			`resource "custom_rs" "name" {
+  lifecycle {
+    |
+  }
+}`,
+			[]string{"create_before_destroy", "ignore_changes", "prevent_destroy"},
+		},
+		{
+			"0.12.0",
+			`resource "custom_rs" "name" {
+  provisioner "local-exec" {
+    |
+  }
+}`,
+			[]string{"command", "connection", "environment", "interpreter",
+				"on_failure", "when", "working_dir"},
+		},
+		{
+			"0.12.0",
+			`resource "custom_rs" "name" {
+  provisioner "file" {
+    connection {
+      bastion_h|
+    }
+  }
+}`,
+			[]string{"bastion_host", "bastion_host_key"},
+		},
+		{
+			"0.12.0",
+			`resource "custom_rs" "name" {
+  provisioner "unknown" {
+    |
+  }
+}`,
+			[]string{},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.tfVersion), func(t *testing.T) {
+			src, pos := splitCursor(t, tc.src)
+
+			v, err := version.NewVersion(tc.tfVersion)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f := &resourceBlockFactory{
+				logger:       testLogger(),
+				schemaReader: &schema.MockReader{ProviderSchemas: schemas},
+				tfVersion:    v,
+			}
+			b, err := f.New(newTestBlock(t, string(src)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			candidates, err := b.CompletionCandidatesAtPos(pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedCandidates, candidateLabels(candidates)); diff != "" {
+				t.Fatalf("Candidates don't match.\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResourceBlock_Validate_provisioners(t *testing.T) {
+	cfg := `resource "custom_rs" "name" {
+  provisioner "local-exec" {
+    command = "echo"
+    inline  = ["echo"]
+  }
+  provisioner "file" {
+    source = "a"
+  }
+  provisioner "custom" {
+    anything = "goes"
+  }
+}
+`
+	block := ParseBlock(newTestBlock(t, cfg), withResourceMetaArguments(&tfjson.SchemaBlock{}, nil))
+
+	expected := []string{
+		`4:5 error Unsupported argument: An argument named "inline" is not expected here.`,
+		`6:3 error Missing required argument: The argument "destination" is required, but no definition was found.`,
+	}
+	if diff := cmp.Diff(expected, renderDiagnostics(block.Validate())); diff != "" {
+		t.Fatalf("Diagnostics don't match.\n%s", diff)
+	}
+}
+
+func TestResourceBlock_provisionersByVersion(t *testing.T) {
+	schemas := &tfjson.ProviderSchemas{
+		FormatVersion: "0.1",
+		Schemas: map[string]*tfjson.ProviderSchema{
+			"custom": {
+				ResourceSchemas: map[string]*tfjson.Schema{
+					"custom_rs": {Block: &tfjson.SchemaBlock{}},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		tfVersion           string
+		expectedSnippet     string
+		expectedDiagnostics []string
+	}{
+		{
+			"0.14.11",
+			"provisioner \"${1|chef,file,habitat,local-exec,puppet,remote-exec,salt-masterless|}\" {\n  ${0}\n}",
+			[]string{},
+		},
+		{
+			"0.15.0",
+			"provisioner \"${1|file,local-exec,remote-exec|}\" {\n  ${0}\n}",
+			[]string{
+				`2:15 error Unsupported provisioner type: Provisioner type "puppet" was removed in Terraform 0.15.0.`,
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.tfVersion), func(t *testing.T) {
+			v, err := version.NewVersion(tc.tfVersion)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f := &resourceBlockFactory{
+				logger:       testLogger(),
+				schemaReader: &schema.MockReader{ProviderSchemas: schemas},
+				tfVersion:    v,
+			}
+
+			src, pos := splitCursor(t, `resource "custom_rs" "name" {
+  provisioner "puppet" {
+    server = "puppet.example.com"
+  }
+  provis|
+}`)
+			b, err := f.New(newTestBlock(t, string(src)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			candidates, err := b.CompletionCandidatesAtPos(pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			list := candidates.List()
+			if len(list) != 1 {
+				t.Fatalf("expected 1 candidate, given %d", len(list))
+			}
+			if diff := cmp.Diff(tc.expectedSnippet, list[0].Snippet().NewText()); diff != "" {
+				t.Fatalf("Snippet doesn't match.\n%s", diff)
+			}
+
+			diags, err := b.Validate()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedDiagnostics, renderDiagnostics(diags)); diff != "" {
+				t.Fatalf("Diagnostics don't match.\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package lang
+
+import (
+	"log"
+
+	"github.com/hashicorp/go-version"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfjson "github.com/hashicorp/terraform-json"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type moduleBlockFactory struct {
+	logger *log.Logger
+
+	tfVersion   *version.Version
+	moduleIndex *ModuleIndex
+}
+
+func (f *moduleBlockFactory) New(tBlock ihcl.TokenizedBlock) (ConfigBlock, error) {
+	if f.logger == nil {
+		f.logger = discardLog()
+	}
+
+	return &moduleBlock{
+		logger: f.logger,
+
+		labelSchema: f.LabelSchema(),
+		tBlock:      tBlock,
+		tfVersion:   f.tfVersion,
+		moduleIndex: f.moduleIndex,
+	}, nil
+}
+
+func (f *moduleBlockFactory) LabelSchema() LabelSchema {
+	return LabelSchema{
+		Label{Name: "name", IsCompletable: false},
+	}
+}
+
+func (f *moduleBlockFactory) BlockType() string {
+	return "module"
+}
+
+func (f *moduleBlockFactory) Documentation() MarkupContent {
+	return PlainText("A module block calls a child module, including its resources into the configuration. " +
+		"Arguments of the block set values of input variables declared in the module.")
+}
+
+type moduleBlock struct {
+	logger *log.Logger
+
+	labelSchema LabelSchema
+	labels      []*ParsedLabel
+	tBlock      ihcl.TokenizedBlock
+	tfVersion   *version.Version
+	moduleIndex *ModuleIndex
+}
+
+func (m *moduleBlock) Name() string {
+	name := m.Labels()[0].Value
+	if name == "" {
+		return "<unknown>"
+	}
+	return name
+}
+
+func (m *moduleBlock) Labels() []*ParsedLabel {
+	if m.labels != nil {
+		return m.labels
+	}
+	m.labels = ParseLabels(m.tBlock, m.labelSchema)
+
+	return m.labels
+}
+
+func (m *moduleBlock) BlockType() string {
+	return "module"
+}
+
+func (m *moduleBlock) CompletionCandidatesAtPos(pos hcl.Pos) (CompletionCandidates, error) {
+	hclBlock, _ := hclsyntax.ParseBlockFromTokens(m.tBlock.Tokens())
+	if PosInLabels(hclBlock, pos) {
+		// name of the module is up to the user
+		return &candidateList{candidates: make([]CompletionCandidate, 0)}, nil
+	}
+
+	cb := &completableBlock{
+		logger:       m.logger,
+		parsedLabels: m.Labels(),
+		schema:       m.schema(),
+		tBlock:       m.tBlock,
+	}
+	return cb.completionCandidatesAtPos(pos)
+}
+
+func (m *moduleBlock) HoverAtPos(pos hcl.Pos) (*HoverData, error) {
+	cb := &completableBlock{
+		logger:       m.logger,
+		parsedLabels: m.Labels(),
+		schema:       m.schema(),
+		tBlock:       m.tBlock,
+	}
+	return cb.hoverAtPos(pos)
+}
+
+func (m *moduleBlock) Validate() (hcl.Diagnostics, error) {
+	hclBlock, _ := hclsyntax.ParseBlockFromTokens(m.tBlock.Tokens())
+	if hclBlock == nil {
+		return hcl.Diagnostics{}, nil
+	}
+
+	s, ok := m.schemaWithInputs()
+	if !ok {
+		// inputs can only be validated if the module is installed
+		s = withDeclaredInputs(s, hclBlock.Body)
+	}
+
+	block := parseBlock(hclBlock, s)
+	return block.Validate(), nil
+}
+
+// schema returns schema of the module block including inputs
+// of the called module, if it's known (i.e. installed)
+func (m *moduleBlock) schema() *tfjson.SchemaBlock {
+	s, _ := m.schemaWithInputs()
+	return s
+}
+
+// schemaWithInputs returns schema of the module block and whether
+// it includes inputs of the called module, i.e. whether it's installed
+func (m *moduleBlock) schemaWithInputs() (*tfjson.SchemaBlock, bool) {
+	s := moduleSchema(m.tfVersion)
+	if m.moduleIndex == nil {
+		return s, false
+	}
+
+	child, ok := m.moduleIndex.CalledModule(m.Name())
+	if !ok {
+		m.logger.Printf("module %q is not installed, inputs are not known", m.Name())
+		return s, false
+	}
+
+	return withModuleInputs(s, child.Variables()), true
+}
+
+// withModuleInputs returns a copy of the given schema extended
+// with input variables of the called module
+func withModuleInputs(s *tfjson.SchemaBlock, variables []*Variable) *tfjson.SchemaBlock {
+	block := copySchemaBlock(s)
+	for _, v := range variables {
+		if _, ok := block.Attributes[v.Name]; ok {
+			// meta-arguments cannot be used as input names
+			continue
+		}
+
+		description := v.Description
+		if v.Default != "" {
+			if description != "" {
+				description += "\n\n"
+			}
+			description += "Default: " + v.Default
+		}
+
+		block.Attributes[v.Name] = &tfjson.SchemaAttribute{
+			AttributeType: v.Type,
+			Required:      v.Default == "",
+			Optional:      v.Default != "",
+			Description:   description,
+		}
+	}
+	return block
+}
+
+// withDeclaredInputs returns a copy of the given schema extended
+// with all other attributes declared in the body, as these are
+// inputs whose validity depends on the called module
+func withDeclaredInputs(s *tfjson.SchemaBlock, body *hclsyntax.Body) *tfjson.SchemaBlock {
+	block := copySchemaBlock(s)
+	for name := range body.Attributes {
+		if _, ok := block.Attributes[name]; ok {
+			continue
+		}
+		block.Attributes[name] = &tfjson.SchemaAttribute{
+			AttributeType: cty.DynamicPseudoType,
+			Optional:      true,
+		}
+	}
+	return block
+}
+
+func moduleSchema(v *version.Version) *tfjson.SchemaBlock {
+	block := &tfjson.SchemaBlock{
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"source": {
+				AttributeType: cty.String,
+				Required:      true,
+				Description:   "Location of the module source code, e.g. a local path or a registry address.",
+			},
+			"version": {
+				AttributeType: cty.String,
+				Optional:      true,
+				Description:   "Version constraint for modules installed from a registry.",
+			},
+			"providers": {
+				AttributeType: cty.DynamicPseudoType,
+				Optional:      true,
+				Description:   "A map of provider configurations to pass to the module.",
+			},
+		},
+	}
+
+	// Modules became countable in 0.13.0
+	if versionSupports(v, "0.13.0") {
+		for name, ma := range countableMetaArguments {
+			if name == "provider" {
+				// modules receive providers via the providers argument
+				continue
+			}
+			block.Attributes[name] = ma.schema
+		}
+	}
+
+	return block
+}
@@ -0,0 +1,178 @@
+package lang
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+)
+
+func TestModuleBlock_completionCandidatesAtPos(t *testing.T) {
+	testCases := []struct {
+		tfVersion          string
+		src                string
+		expectedCandidates []string
+	}{
+		{
+			"0.12.0",
+			`module "vpc" {
+  source = "./vpc"
+  |
+}`,
+			[]string{"providers", "version"},
+		},
+		{
+			"0.13.0",
+			`module "vpc" {
+  source = "./vpc"
+  |
+}`,
+			[]string{"count", "depends_on", "for_each", "providers", "version"},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.tfVersion), func(t *testing.T) {
+			src, pos := splitCursor(t, tc.src)
+
+			f := &moduleBlockFactory{
+				logger:    testLogger(),
+				tfVersion: version.Must(version.NewVersion(tc.tfVersion)),
+			}
+			b, err := f.New(newTestBlock(t, string(src)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			candidates, err := b.CompletionCandidatesAtPos(pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedCandidates, candidateLabels(candidates)); diff != "" {
+				t.Fatalf("Candidates don't match.\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestModuleBlock_completionCandidatesAtPos_calledModule(t *testing.T) {
+	child := NewModuleIndex()
+	child.IndexFile("/test/vpc/variables.tf", []byte(`variable "cidr_block" {
+  type        = string
+  description = "CIDR block of the VPC"
+}
+
+variable "tags" {
+  type    = map(string)
+  default = {}
+}
+`))
+	mi := NewModuleIndex()
+	mi.AddCalledModule("vpc", child)
+
+	src, pos := splitCursor(t, `module "vpc" {
+  source = "./vpc"
+  |
+}`)
+
+	f := &moduleBlockFactory{
+		logger:      testLogger(),
+		tfVersion:   version.Must(version.NewVersion("0.12.0")),
+		moduleIndex: mi,
+	}
+	b, err := f.New(newTestBlock(t, string(src)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	candidates, err := b.CompletionCandidatesAtPos(pos)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"cidr_block (Required, string) CIDR block of the VPC",
+		"providers (Optional, dynamic) A map of provider configurations to pass to the module.",
+		"tags (Optional, map of string) Default: {}",
+		"version (Optional, string) Version constraint for modules installed from a registry.",
+	}
+	rendered := make([]string, 0)
+	for _, c := range candidates.List() {
+		rendered = append(rendered, fmt.Sprintf("%s (%s) %s",
+			c.Label(), c.Detail(), c.Documentation().Value()))
+	}
+	if diff := cmp.Diff(expected, rendered); diff != "" {
+		t.Fatalf("Candidates don't match.\n%s", diff)
+	}
+}
+
+func TestModuleBlock_Validate(t *testing.T) {
+	f := &moduleBlockFactory{
+		logger:    testLogger(),
+		tfVersion: version.Must(version.NewVersion("0.12.0")),
+	}
+	b, err := f.New(newTestBlock(t, `module "vpc" {
+  cidr_block = "10.0.0.0/16"
+  count      = 2
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diags, err := b.Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		`1:1 error Missing required argument: The argument "source" is required, but no definition was found.`,
+	}
+	if diff := cmp.Diff(expected, renderDiagnostics(diags)); diff != "" {
+		t.Fatalf("Diagnostics don't match.\n%s", diff)
+	}
+}
+
+func TestModuleBlock_Validate_calledModule(t *testing.T) {
+	child := NewModuleIndex()
+	child.IndexFile("/test/vpc/variables.tf", []byte(`variable "cidr_block" {
+  type = string
+}
+
+variable "tags" {
+  type    = map(string)
+  default = {}
+}
+`))
+	mi := NewModuleIndex()
+	mi.AddCalledModule("vpc", child)
+
+	f := &moduleBlockFactory{
+		logger:      testLogger(),
+		tfVersion:   version.Must(version.NewVersion("0.12.0")),
+		moduleIndex: mi,
+	}
+	b, err := f.New(newTestBlock(t, `module "vpc" {
+  source    = "./vpc"
+  cidr_blok = "10.0.0.0/16"
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diags, err := b.Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		`1:1 error Missing required argument: The argument "cidr_block" is required, but no definition was found.`,
+		`3:3 error Unsupported argument: An argument named "cidr_blok" is not expected here.`,
+	}
+	if diff := cmp.Diff(expected, renderDiagnostics(diags)); diff != "" {
+		t.Fatalf("Diagnostics don't match.\n%s", diff)
+	}
+}
@@ -0,0 +1,449 @@
+package lang
+
+import (
+	"sort"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ModuleIndex keeps track of named objects declared across all files
+// of a single module (variables, locals, outputs, modules, resources
+// and data sources) and of expressions referring to them
+type ModuleIndex struct {
+	declarations map[string]*Declaration
+	references   []*Reference
+
+	// outputRefs keeps track of references to outputs
+	// of called modules, i.e. module.<name>.<output>
+	outputRefs []*Reference
+
+	// assignments keeps track of values assigned
+	// to input variables in variable definitions files
+	assignments []*Reference
+
+	// calledModules keeps track of indexes of modules
+	// called from this module, keyed by the module block name
+	calledModules map[string]*ModuleIndex
+
+	// variables keeps track of details of declared input variables
+	// which make up the schema of module blocks calling this module
+	variables map[string]*Variable
+}
+
+// Declaration represents a named object declared in a module
+type Declaration struct {
+	// Address is how the object is referred to from expressions
+	// e.g. var.region, local.tags, module.network or aws_vpc.main
+	Address string
+
+	// BlockType is the type of the block declaring the object
+	BlockType string
+
+	// Range covers the whole declaring block
+	// (or attribute in case of locals)
+	Range hcl.Range
+
+	// DefRange covers the block type and labels
+	// (or name of the local)
+	DefRange hcl.Range
+
+	// NameRange covers just the name of the object
+	// (last label without quotes or name of the local)
+	NameRange hcl.Range
+}
+
+// SymbolKind returns kind of the symbol representing the declaration
+func (d *Declaration) SymbolKind() SymbolKind {
+	if d.BlockType == "locals" {
+		return SymbolKindLocal
+	}
+	if kind, ok := symbolKinds[d.BlockType]; ok {
+		return kind
+	}
+	return SymbolKindBlock
+}
+
+// Variable represents an input variable declared in a module
+type Variable struct {
+	Name        string
+	Type        cty.Type
+	Description string
+
+	// Default is the source of the default value expression
+	// or empty if the variable has no default (i.e. is required)
+	Default string
+}
+
+// Reference represents a traversal in an expression
+// which refers to a declared object
+type Reference struct {
+	// Address of the referenced object
+	Address string
+
+	// Range covers the part of the traversal which makes up the address
+	// e.g. aws_vpc.main out of aws_vpc.main.id
+	Range hcl.Range
+
+	// NameRange covers just the name of the referenced object
+	// e.g. main out of aws_vpc.main.id
+	NameRange hcl.Range
+}
+
+// roots of traversals which never refer to declared objects
+var ignoredReferenceRoots = map[string]bool{
+	"count":     true,
+	"each":      true,
+	"path":      true,
+	"self":      true,
+	"terraform": true,
+}
+
+func NewModuleIndex() *ModuleIndex {
+	return &ModuleIndex{
+		declarations:  make(map[string]*Declaration, 0),
+		references:    make([]*Reference, 0),
+		outputRefs:    make([]*Reference, 0),
+		assignments:   make([]*Reference, 0),
+		calledModules: make(map[string]*ModuleIndex, 0),
+		variables:     make(map[string]*Variable, 0),
+	}
+}
+
+// IndexFile parses the given file and adds all declarations and references
+// found in it to the index. Files containing syntax errors are indexed
+// on a best-effort basis and returned diagnostics can be safely ignored.
+func (mi *ModuleIndex) IndexFile(filename string, src []byte) hcl.Diagnostics {
+	f, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return diags
+	}
+
+	for _, block := range body.Blocks {
+		mi.indexDeclarations(block)
+		mi.indexReferences(block.Body, map[string]bool{})
+		if block.Type == "variable" {
+			mi.indexVariable(block, src)
+		}
+	}
+
+	return diags
+}
+
+// AddCalledModule makes the index of a module called
+// via module block of the given name available
+// e.g. for looking up its outputs
+func (mi *ModuleIndex) AddCalledModule(name string, child *ModuleIndex) {
+	mi.calledModules[name] = child
+}
+
+// CalledModule returns index of a module called
+// via module block of the given name, if known
+func (mi *ModuleIndex) CalledModule(name string) (*ModuleIndex, bool) {
+	child, ok := mi.calledModules[name]
+	return child, ok
+}
+
+// Variables returns all input variables declared in the module
+// sorted by name
+func (mi *ModuleIndex) Variables() []*Variable {
+	variables := make([]*Variable, 0, len(mi.variables))
+	for _, v := range mi.variables {
+		variables = append(variables, v)
+	}
+	sort.Slice(variables, func(i, j int) bool {
+		return variables[i].Name < variables[j].Name
+	})
+	return variables
+}
+
+// IndexVarsFile parses the given variable definitions (.tfvars) file
+// and adds all variable assignments found in it to the index
+func (mi *ModuleIndex) IndexVarsFile(filename string, src []byte) hcl.Diagnostics {
+	f, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return diags
+	}
+
+	for name, attr := range body.Attributes {
+		mi.assignments = append(mi.assignments, &Reference{
+			Address:   "var." + name,
+			Range:     attr.NameRange,
+			NameRange: attr.NameRange,
+		})
+	}
+
+	return diags
+}
+
+func (mi *ModuleIndex) indexDeclarations(block *hclsyntax.Block) {
+	switch block.Type {
+	case "locals":
+		for name, attr := range block.Body.Attributes {
+			mi.addDeclaration(&Declaration{
+				Address:   "local." + name,
+				BlockType: block.Type,
+				Range:     attr.SrcRange,
+				DefRange:  attr.NameRange,
+				NameRange: attr.NameRange,
+			})
+		}
+	case "variable", "output", "module":
+		if len(block.Labels) != 1 {
+			return
+		}
+		prefix := map[string]string{
+			"variable": "var",
+			"output":   "output",
+			"module":   "module",
+		}[block.Type]
+		mi.addDeclaration(&Declaration{
+			Address:   prefix + "." + block.Labels[0],
+			BlockType: block.Type,
+			Range:     block.Range(),
+			DefRange:  block.DefRange(),
+			NameRange: unquotedLabelRange(block.LabelRanges[0]),
+		})
+	case "resource", "data":
+		if len(block.Labels) != 2 {
+			return
+		}
+		address := block.Labels[0] + "." + block.Labels[1]
+		if block.Type == "data" {
+			address = "data." + address
+		}
+		mi.addDeclaration(&Declaration{
+			Address:   address,
+			BlockType: block.Type,
+			Range:     block.Range(),
+			DefRange:  block.DefRange(),
+			NameRange: unquotedLabelRange(block.LabelRanges[1]),
+		})
+	}
+}
+
+func (mi *ModuleIndex) indexVariable(block *hclsyntax.Block, src []byte) {
+	if len(block.Labels) != 1 {
+		return
+	}
+	name := block.Labels[0]
+	if _, ok := mi.variables[name]; ok {
+		return
+	}
+
+	v := &Variable{
+		Name: name,
+		Type: cty.DynamicPseudoType,
+	}
+	if attr, ok := block.Body.Attributes["type"]; ok {
+		ty, diags := typeexpr.TypeConstraint(attr.Expr)
+		if !diags.HasErrors() {
+			v.Type = ty
+		}
+	}
+	if attr, ok := block.Body.Attributes["description"]; ok {
+		val, diags := attr.Expr.Value(nil)
+		if !diags.HasErrors() && val.Type() == cty.String && val.IsKnown() && !val.IsNull() {
+			v.Description = val.AsString()
+		}
+	}
+	if attr, ok := block.Body.Attributes["default"]; ok {
+		v.Default = string(attr.Expr.Range().SliceBytes(src))
+	}
+
+	mi.variables[name] = v
+}
+
+func (mi *ModuleIndex) addDeclaration(d *Declaration) {
+	// First declaration wins, duplicates are invalid anyway
+	if _, ok := mi.declarations[d.Address]; ok {
+		return
+	}
+	mi.declarations[d.Address] = d
+}
+
+func (mi *ModuleIndex) indexReferences(body *hclsyntax.Body, ignoredRoots map[string]bool) {
+	for name, attr := range body.Attributes {
+		if name == "provider" || name == "providers" {
+			// provider references are not declared objects
+			continue
+		}
+		for _, traversal := range attr.Expr.Variables() {
+			if ref, ok := referenceFromTraversal(traversal, ignoredRoots); ok {
+				mi.references = append(mi.references, ref)
+			}
+			if ref, ok := outputReferenceFromTraversal(traversal); ok {
+				mi.outputRefs = append(mi.outputRefs, ref)
+			}
+		}
+	}
+
+	for _, block := range body.Blocks {
+		switch block.Type {
+		case "lifecycle":
+			// ignore_changes refers to attributes, not objects
+			continue
+		case "dynamic":
+			if len(block.Labels) != 1 {
+				continue
+			}
+			iterator := block.Labels[0]
+			if attr, ok := block.Body.Attributes["iterator"]; ok {
+				if name := hcl.ExprAsKeyword(attr.Expr); name != "" {
+					iterator = name
+				}
+			}
+			nestedRoots := make(map[string]bool, len(ignoredRoots)+1)
+			for root := range ignoredRoots {
+				nestedRoots[root] = true
+			}
+			nestedRoots[iterator] = true
+			mi.indexReferences(block.Body, nestedRoots)
+		default:
+			mi.indexReferences(block.Body, ignoredRoots)
+		}
+	}
+}
+
+func referenceFromTraversal(traversal hcl.Traversal, ignoredRoots map[string]bool) (*Reference, bool) {
+	root := traversal.RootName()
+	if ignoredReferenceRoots[root] || ignoredRoots[root] {
+		return nil, false
+	}
+
+	steps := 2
+	if root == "data" {
+		steps = 3
+	}
+
+	return referenceFromSteps(traversal, steps)
+}
+
+func outputReferenceFromTraversal(traversal hcl.Traversal) (*Reference, bool) {
+	if traversal.RootName() != "module" {
+		return nil, false
+	}
+
+	return referenceFromSteps(traversal, 3)
+}
+
+// referenceFromSteps turns first n steps of the traversal into reference
+func referenceFromSteps(traversal hcl.Traversal, steps int) (*Reference, bool) {
+	if len(traversal) < steps {
+		return nil, false
+	}
+
+	names := []string{traversal.RootName()}
+	for _, step := range traversal[1:steps] {
+		attr, ok := step.(hcl.TraverseAttr)
+		if !ok {
+			return nil, false
+		}
+		names = append(names, attr.Name)
+	}
+
+	nameRng := traversal[steps-1].SourceRange()
+	// strip the leading dot
+	nameRng.Start.Byte++
+	nameRng.Start.Column++
+
+	return &Reference{
+		Address:   strings.Join(names, "."),
+		Range:     hcl.RangeBetween(traversal[0].SourceRange(), traversal[steps-1].SourceRange()),
+		NameRange: nameRng,
+	}, true
+}
+
+// Declaration returns declaration of an object with the given address
+func (mi *ModuleIndex) Declaration(address string) (*Declaration, bool) {
+	d, ok := mi.declarations[address]
+	return d, ok
+}
+
+// Declarations returns all declarations sorted by address
+func (mi *ModuleIndex) Declarations() []*Declaration {
+	decls := make([]*Declaration, 0, len(mi.declarations))
+	for _, d := range mi.declarations {
+		decls = append(decls, d)
+	}
+	sort.Slice(decls, func(i, j int) bool {
+		return decls[i].Address < decls[j].Address
+	})
+	return decls
+}
+
+// DeclarationAtPos returns a declaration whose block type and labels
+// (or name in case of locals) are found at the given position
+// of the given file, if any
+func (mi *ModuleIndex) DeclarationAtPos(filename string, pos hcl.Pos) (*Declaration, bool) {
+	for _, d := range mi.declarations {
+		if d.DefRange.Filename == filename && rangeContainsOffset(d.DefRange, pos.Byte) {
+			return d, true
+		}
+	}
+	return nil, false
+}
+
+// References returns all references to an object with the given address
+// sorted by their position. Outputs of called modules can be looked up
+// via module.<name>.<output> addresses.
+func (mi *ModuleIndex) References(address string) []*Reference {
+	candidates := mi.references
+	if strings.HasPrefix(address, "module.") && strings.Count(address, ".") == 2 {
+		candidates = mi.outputRefs
+	}
+
+	return filterReferences(candidates, address)
+}
+
+// Assignments returns all assignments of values to the variable
+// with the given address (var.<name>) in variable definitions files
+// sorted by their position
+func (mi *ModuleIndex) Assignments(address string) []*Reference {
+	return filterReferences(mi.assignments, address)
+}
+
+func filterReferences(candidates []*Reference, address string) []*Reference {
+	refs := make([]*Reference, 0)
+	for _, ref := range candidates {
+		if ref.Address == address {
+			refs = append(refs, ref)
+		}
+	}
+
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Range.Filename != refs[j].Range.Filename {
+			return refs[i].Range.Filename < refs[j].Range.Filename
+		}
+		return refs[i].Range.Start.Byte < refs[j].Range.Start.Byte
+	})
+
+	return refs
+}
+
+// ReferenceAtPos returns a reference found at the given position
+// of the given file, if any
+func (mi *ModuleIndex) ReferenceAtPos(filename string, pos hcl.Pos) (*Reference, bool) {
+	for _, ref := range mi.references {
+		if ref.Range.Filename == filename && rangeContainsOffset(ref.Range, pos.Byte) {
+			return ref, true
+		}
+	}
+	return nil, false
+}
+
+func unquotedLabelRange(rng hcl.Range) hcl.Range {
+	if rng.End.Byte-rng.Start.Byte < 2 {
+		return rng
+	}
+	rng.Start.Byte++
+	rng.Start.Column++
+	rng.End.Byte--
+	rng.End.Column--
+	return rng
+}
@@ -0,0 +1,337 @@
+package lang
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+)
+
+func TestModuleIndex_Declarations(t *testing.T) {
+	mi := NewModuleIndex()
+	mi.IndexFile("/test/main.tf", []byte(`variable "region" {}
+
+locals {
+  tags = {}
+}
+
+module "network" {
+  source = "./network"
+}
+
+resource "aws_vpc" "main" {
+}
+
+data "aws_ami" "ubuntu" {
+}
+
+output "vpc_id" {
+  value = aws_vpc.main.id
+}
+
+provider "aws" {
+}
+`))
+
+	expectedDecls := []string{
+		"aws_vpc.main (resource) /test/main.tf:11,21-25",
+		"data.aws_ami.ubuntu (data) /test/main.tf:14,17-23",
+		"local.tags (locals) /test/main.tf:4,3-7",
+		"module.network (module) /test/main.tf:7,9-16",
+		"output.vpc_id (output) /test/main.tf:17,9-15",
+		"var.region (variable) /test/main.tf:1,11-17",
+	}
+	decls := make([]string, 0)
+	for _, d := range mi.Declarations() {
+		decls = append(decls, fmt.Sprintf("%s (%s) %s", d.Address, d.BlockType, d.NameRange))
+	}
+	if diff := cmp.Diff(expectedDecls, decls); diff != "" {
+		t.Fatalf("Declarations don't match.\n%s", diff)
+	}
+}
+
+func TestModuleIndex_Variables(t *testing.T) {
+	mi := NewModuleIndex()
+	mi.IndexFile("/test/variables.tf", []byte(`variable "region" {
+  type        = string
+  description = "Region to deploy into"
+}
+
+variable "tags" {
+  type    = map(string)
+  default = {}
+}
+
+variable "count_hint" {
+  default = 2
+}
+`))
+
+	expectedVars := []string{
+		"count_hint (dynamic) default: 2",
+		"region (string) description: Region to deploy into",
+		"tags (map of string) default: {}",
+	}
+	vars := make([]string, 0)
+	for _, v := range mi.Variables() {
+		s := fmt.Sprintf("%s (%s)", v.Name, v.Type.FriendlyName())
+		if v.Description != "" {
+			s += " description: " + v.Description
+		}
+		if v.Default != "" {
+			s += " default: " + v.Default
+		}
+		vars = append(vars, s)
+	}
+	if diff := cmp.Diff(expectedVars, vars); diff != "" {
+		t.Fatalf("Variables don't match.\n%s", diff)
+	}
+}
+
+func TestModuleIndex_ReferenceAtPos(t *testing.T) {
+	mi := NewModuleIndex()
+	mi.IndexFile("/test/main.tf", []byte(`resource "aws_instance" "web" {
+  count         = var.instance_count
+  ami           = data.aws_ami.ubuntu.id
+  subnet_id     = module.network.subnet_ids[count.index]
+  tags          = merge(local.tags, { Name = "web-${var.env}" })
+  provider      = aws.west
+  dynamic "ebs_block_device" {
+    for_each = var.volumes
+    content {
+      device_name = ebs_block_device.value.name
+    }
+  }
+  lifecycle {
+    ignore_changes = [tags.Name]
+  }
+}
+`))
+
+	testCases := []struct {
+		pos hcl.Pos
+
+		expectedRef *Reference
+	}{
+		{
+			hcl.Pos{Line: 2, Column: 22, Byte: 53},
+			&Reference{
+				Address: "var.instance_count",
+				Range: hcl.Range{
+					Filename: "/test/main.tf",
+					Start:    hcl.Pos{Line: 2, Column: 19, Byte: 50},
+					End:      hcl.Pos{Line: 2, Column: 37, Byte: 68},
+				},
+				NameRange: hcl.Range{
+					Filename: "/test/main.tf",
+					Start:    hcl.Pos{Line: 2, Column: 23, Byte: 54},
+					End:      hcl.Pos{Line: 2, Column: 37, Byte: 68},
+				},
+			},
+		},
+		{
+			hcl.Pos{Line: 3, Column: 19, Byte: 87},
+			&Reference{
+				Address: "data.aws_ami.ubuntu",
+				Range: hcl.Range{
+					Filename: "/test/main.tf",
+					Start:    hcl.Pos{Line: 3, Column: 19, Byte: 87},
+					End:      hcl.Pos{Line: 3, Column: 38, Byte: 106},
+				},
+				NameRange: hcl.Range{
+					Filename: "/test/main.tf",
+					Start:    hcl.Pos{Line: 3, Column: 32, Byte: 100},
+					End:      hcl.Pos{Line: 3, Column: 38, Byte: 106},
+				},
+			},
+		},
+		{
+			hcl.Pos{Line: 4, Column: 30, Byte: 139},
+			&Reference{
+				Address: "module.network",
+				Range: hcl.Range{
+					Filename: "/test/main.tf",
+					Start:    hcl.Pos{Line: 4, Column: 19, Byte: 128},
+					End:      hcl.Pos{Line: 4, Column: 33, Byte: 142},
+				},
+				NameRange: hcl.Range{
+					Filename: "/test/main.tf",
+					Start:    hcl.Pos{Line: 4, Column: 26, Byte: 135},
+					End:      hcl.Pos{Line: 4, Column: 33, Byte: 142},
+				},
+			},
+		},
+		{
+			// count.index
+			hcl.Pos{Line: 4, Column: 47, Byte: 156},
+			nil,
+		},
+		{
+			// interpolation inside a string
+			hcl.Pos{Line: 5, Column: 55, Byte: 221},
+			&Reference{
+				Address: "var.env",
+				Range: hcl.Range{
+					Filename: "/test/main.tf",
+					Start:    hcl.Pos{Line: 5, Column: 53, Byte: 219},
+					End:      hcl.Pos{Line: 5, Column: 60, Byte: 226},
+				},
+				NameRange: hcl.Range{
+					Filename: "/test/main.tf",
+					Start:    hcl.Pos{Line: 5, Column: 57, Byte: 223},
+					End:      hcl.Pos{Line: 5, Column: 60, Byte: 226},
+				},
+			},
+		},
+		{
+			// provider reference
+			hcl.Pos{Line: 6, Column: 21, Byte: 252},
+			nil,
+		},
+		{
+			// dynamic block iterator
+			hcl.Pos{Line: 10, Column: 22, Byte: 352},
+			nil,
+		},
+		{
+			// lifecycle
+			hcl.Pos{Line: 14, Column: 29, Byte: 431},
+			nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			ref, ok := mi.ReferenceAtPos("/test/main.tf", tc.pos)
+			if !ok {
+				ref = nil
+			}
+			if diff := cmp.Diff(tc.expectedRef, ref); diff != "" {
+				t.Fatalf("Reference doesn't match.\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestModuleIndex_References(t *testing.T) {
+	mi := NewModuleIndex()
+	mi.IndexFile("/test/variables.tf", []byte(`variable "region" {}
+`))
+	mi.IndexFile("/test/main.tf", []byte(`provider "aws" {
+  region = var.region
+}
+
+module "network" {
+  source = "./network"
+  region = "${var.region}-1"
+}
+
+output "vpc_id" {
+  value = module.network.vpc_id
+}
+`))
+
+	testCases := []struct {
+		address      string
+		expectedRefs []string
+	}{
+		{
+			"var.region",
+			[]string{
+				"/test/main.tf:2,12-22 (/test/main.tf:2,16-22)",
+				"/test/main.tf:7,15-25 (/test/main.tf:7,19-25)",
+			},
+		},
+		{
+			"module.network",
+			[]string{
+				"/test/main.tf:11,11-25 (/test/main.tf:11,18-25)",
+			},
+		},
+		{
+			"module.network.vpc_id",
+			[]string{
+				"/test/main.tf:11,11-32 (/test/main.tf:11,26-32)",
+			},
+		},
+		{
+			"var.unknown",
+			[]string{},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.address), func(t *testing.T) {
+			refs := make([]string, 0)
+			for _, ref := range mi.References(tc.address) {
+				refs = append(refs, fmt.Sprintf("%s (%s)", ref.Range, ref.NameRange))
+			}
+			if diff := cmp.Diff(tc.expectedRefs, refs); diff != "" {
+				t.Fatalf("References don't match.\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestModuleIndex_DeclarationAtPos(t *testing.T) {
+	mi := NewModuleIndex()
+	mi.IndexFile("/test/main.tf", []byte(`variable "region" {
+  default = "eu-west-1"
+}
+
+locals {
+  tags = {}
+}
+`))
+
+	testCases := []struct {
+		pos             hcl.Pos
+		expectedAddress string
+	}{
+		{hcl.Pos{Line: 1, Column: 3, Byte: 2}, "var.region"},
+		{hcl.Pos{Line: 1, Column: 13, Byte: 12}, "var.region"},
+		{hcl.Pos{Line: 2, Column: 5, Byte: 24}, ""},
+		{hcl.Pos{Line: 6, Column: 4, Byte: 59}, "local.tags"},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			var address string
+			if decl, ok := mi.DeclarationAtPos("/test/main.tf", tc.pos); ok {
+				address = decl.Address
+			}
+			if address != tc.expectedAddress {
+				t.Fatalf("Address doesn't match.\nexpected: %q\ngiven: %q",
+					tc.expectedAddress, address)
+			}
+		})
+	}
+}
+
+func TestModuleIndex_Assignments(t *testing.T) {
+	mi := NewModuleIndex()
+	mi.IndexFile("/test/main.tf", []byte(`variable "region" {}
+`))
+	mi.IndexVarsFile("/test/terraform.tfvars", []byte(`region = "eu-west-1"
+zone   = "a"
+`))
+	mi.IndexVarsFile("/test/prod.auto.tfvars", []byte(`region = "us-east-1"
+`))
+
+	expectedAssignments := []string{
+		"/test/prod.auto.tfvars:1,1-7",
+		"/test/terraform.tfvars:1,1-7",
+	}
+	assignments := make([]string, 0)
+	for _, a := range mi.Assignments("var.region") {
+		assignments = append(assignments, a.NameRange.String())
+	}
+	if diff := cmp.Diff(expectedAssignments, assignments); diff != "" {
+		t.Fatalf("Assignments don't match.\n%s", diff)
+	}
+
+	if refs := mi.References("var.region"); len(refs) != 0 {
+		t.Fatalf("expected no references, %d given", len(refs))
+	}
+}
@@ -0,0 +1,128 @@
+package lang
+
+import (
+	"log"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfjson "github.com/hashicorp/terraform-json"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type outputBlockFactory struct {
+	logger *log.Logger
+}
+
+func (f *outputBlockFactory) New(tBlock ihcl.TokenizedBlock) (ConfigBlock, error) {
+	if f.logger == nil {
+		f.logger = discardLog()
+	}
+
+	return &outputBlock{
+		logger: f.logger,
+
+		labelSchema: f.LabelSchema(),
+		tBlock:      tBlock,
+	}, nil
+}
+
+func (f *outputBlockFactory) LabelSchema() LabelSchema {
+	return LabelSchema{
+		Label{Name: "name", IsCompletable: false},
+	}
+}
+
+func (f *outputBlockFactory) BlockType() string {
+	return "output"
+}
+
+func (f *outputBlockFactory) Documentation() MarkupContent {
+	return PlainText("An output block declares an output value which is exported by the module. Outputs of a root " +
+		"module are printed after apply, outputs of a child module can be referenced by its parent module.")
+}
+
+type outputBlock struct {
+	logger *log.Logger
+
+	labelSchema LabelSchema
+	labels      []*ParsedLabel
+	tBlock      ihcl.TokenizedBlock
+}
+
+func (o *outputBlock) Name() string {
+	name := o.Labels()[0].Value
+	if name == "" {
+		return "<unknown>"
+	}
+	return name
+}
+
+func (o *outputBlock) Labels() []*ParsedLabel {
+	if o.labels != nil {
+		return o.labels
+	}
+	o.labels = ParseLabels(o.tBlock, o.labelSchema)
+
+	return o.labels
+}
+
+func (o *outputBlock) BlockType() string {
+	return "output"
+}
+
+func (o *outputBlock) CompletionCandidatesAtPos(pos hcl.Pos) (CompletionCandidates, error) {
+	hclBlock, _ := hclsyntax.ParseBlockFromTokens(o.tBlock.Tokens())
+	if PosInLabels(hclBlock, pos) {
+		// name of the output is up to the user
+		return &candidateList{candidates: make([]CompletionCandidate, 0)}, nil
+	}
+
+	cb := &completableBlock{
+		logger:       o.logger,
+		parsedLabels: o.Labels(),
+		schema:       outputSchema,
+		tBlock:       o.tBlock,
+	}
+	return cb.completionCandidatesAtPos(pos)
+}
+
+func (o *outputBlock) HoverAtPos(pos hcl.Pos) (*HoverData, error) {
+	cb := &completableBlock{
+		logger:       o.logger,
+		parsedLabels: o.Labels(),
+		schema:       outputSchema,
+		tBlock:       o.tBlock,
+	}
+	return cb.hoverAtPos(pos)
+}
+
+func (o *outputBlock) Validate() (hcl.Diagnostics, error) {
+	block := ParseBlock(o.tBlock, outputSchema)
+	return block.Validate(), nil
+}
+
+var outputSchema = &tfjson.SchemaBlock{
+	Attributes: map[string]*tfjson.SchemaAttribute{
+		"value": {
+			AttributeType: cty.DynamicPseudoType,
+			Required:      true,
+			Description:   "The value to export.",
+		},
+		"description": {
+			AttributeType: cty.String,
+			Optional:      true,
+			Description:   "Description of the output's purpose.",
+		},
+		"sensitive": {
+			AttributeType: cty.Bool,
+			Optional:      true,
+			Description:   "Whether to hide the value in the CLI output.",
+		},
+		"depends_on": {
+			AttributeType: cty.DynamicPseudoType,
+			Optional:      true,
+			Description:   "A list of hidden dependencies which Terraform cannot infer from references.",
+		},
+	},
+}
@@ -0,0 +1,53 @@
+package lang
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestOutputBlock_completionCandidatesAtPos(t *testing.T) {
+	src, pos := splitCursor(t, `output "id" {
+  value = aws_instance.web.id
+  |
+}`)
+
+	f := &outputBlockFactory{logger: testLogger()}
+	b, err := f.New(newTestBlock(t, string(src)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	candidates, err := b.CompletionCandidatesAtPos(pos)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"depends_on", "description", "sensitive"}
+	if diff := cmp.Diff(expected, candidateLabels(candidates)); diff != "" {
+		t.Fatalf("Candidates don't match.\n%s", diff)
+	}
+}
+
+func TestOutputBlock_Validate(t *testing.T) {
+	f := &outputBlockFactory{logger: testLogger()}
+	b, err := f.New(newTestBlock(t, `output "id" {
+  description = "ID"
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diags, err := b.Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		`1:1 error Missing required argument: The argument "value" is required, but no definition was found.`,
+	}
+	if diff := cmp.Diff(expected, renderDiagnostics(diags)); diff != "" {
+		t.Fatalf("Diagnostics don't match.\n%s", diff)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"log"
 	"strings"
+	"sync"
 
 	"github.com/hashicorp/go-version"
 	hcl "github.com/hashicorp/hcl/v2"
@@ -34,20 +35,42 @@ type parser struct {
 
 	maxCandidates int
 	schemaReader  schema.Reader
+
+	// tfVersion is the Terraform version the parser was found
+	// compatible with, or nil if not known (assuming the latest)
+	tfVersion *version.Version
+
+	// functions available in the Terraform version
+	functions map[string]*FunctionSignature
+
+	// providerLocks are provider selections
+	// as recorded in the dependency lock file
+	providerLocks   []*ProviderLock
+	providerLocksMu *sync.RWMutex
 }
 
-func parserSupportsTerraform(v string) error {
+// coreVersion parses the given version, stripping any prerelease,
+// assuming that alpha/beta/rc prereleases have the same compatibility
+func coreVersion(v string) (*version.Version, error) {
 	rawVer, err := version.NewVersion(v)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Assume that alpha/beta/rc prereleases have the same compatibility
 	segments := rawVer.Segments64()
 	segmentsOnly := fmt.Sprintf("%d.%d.%d", segments[0], segments[1], segments[2])
 	tfVersion, err := version.NewVersion(segmentsOnly)
 	if err != nil {
-		return fmt.Errorf("failed to parse stripped version: %w", err)
+		return nil, fmt.Errorf("failed to parse stripped version: %w", err)
+	}
+
+	return tfVersion, nil
+}
+
+func parserSupportsTerraform(v string) error {
+	tfVersion, err := coreVersion(v)
+	if err != nil {
+		return err
 	}
 
 	c, err := version.NewConstraint(parserVersionConstraint)
@@ -74,13 +97,30 @@ func FindCompatibleParser(v string) (Parser, error) {
 		return nil, err
 	}
 
-	return newParser(), nil
+	tfVersion, err := coreVersion(v)
+	if err != nil {
+		return nil, err
+	}
+
+	p := newParser()
+	p.tfVersion = tfVersion
+	p.functions = functionsForVersion(tfVersion)
+
+	return p, nil
+}
+
+// NewParser returns a parser for unknown Terraform version,
+// which assumes all language features to be supported
+func NewParser() Parser {
+	return newParser()
 }
 
 func newParser() *parser {
 	return &parser{
-		logger:        log.New(ioutil.Discard, "", 0),
-		maxCandidates: defaultMaxCompletionCandidates,
+		logger:          log.New(ioutil.Discard, "", 0),
+		maxCandidates:   defaultMaxCompletionCandidates,
+		functions:       functionsForVersion(nil),
+		providerLocksMu: &sync.RWMutex{},
 	}
 }
 
@@ -92,7 +132,23 @@ func (p *parser) SetSchemaReader(sr schema.Reader) {
 	p.schemaReader = sr
 }
 
-func (p *parser) blockTypes() map[string]configBlockFactory {
+// SetProviderLocks updates provider selections, which
+// is safe to do while the parser is in use (e.g. by the watcher)
+func (p *parser) SetProviderLocks(locks []*ProviderLock) {
+	p.providerLocksMu.Lock()
+	defer p.providerLocksMu.Unlock()
+	p.providerLocks = locks
+}
+
+func (p *parser) getProviderLocks() []*ProviderLock {
+	p.providerLocksMu.RLock()
+	defer p.providerLocksMu.RUnlock()
+	return p.providerLocks
+}
+
+// blockTypes returns factories for all known block types, with
+// module index (if available) providing schema for module inputs
+func (p *parser) blockTypes(mi *ModuleIndex) map[string]configBlockFactory {
 	return map[string]configBlockFactory{
 		"provider": &providerBlockFactory{
 			logger:       p.logger,
@@ -101,15 +157,41 @@ func (p *parser) blockTypes() map[string]configBlockFactory {
 		"resource": &resourceBlockFactory{
 			logger:       p.logger,
 			schemaReader: p.schemaReader,
+			tfVersion:    p.tfVersion,
 		},
 		"data": &datasourceBlockFactory{
 			logger:       p.logger,
 			schemaReader: p.schemaReader,
+			tfVersion:    p.tfVersion,
+		},
+		"variable": &variableBlockFactory{
+			logger:    p.logger,
+			tfVersion: p.tfVersion,
+		},
+		"output": &outputBlockFactory{
+			logger: p.logger,
+		},
+		"locals": &localsBlockFactory{
+			logger: p.logger,
+		},
+		"module": &moduleBlockFactory{
+			logger:      p.logger,
+			tfVersion:   p.tfVersion,
+			moduleIndex: mi,
+		},
+		"terraform": &terraformBlockFactory{
+			logger:        p.logger,
+			schemaReader:  p.schemaReader,
+			tfVersion:     p.tfVersion,
+			providerLocks: p.getProviderLocks(),
 		},
 	}
 }
 
-func (p *parser) CompletionCandidatesAtPos(file ihcl.TokenizedFile, pos hcl.Pos) (CompletionCandidates, error) {
+// CompletionCandidatesAtPos returns candidates for completing block types,
+// labels, attributes and nested blocks, or references to objects declared
+// in the module (as known from the given module index) within expressions
+func (p *parser) CompletionCandidatesAtPos(file ihcl.TokenizedFile, mi *ModuleIndex, pos hcl.Pos) (CompletionCandidates, error) {
 	if !file.PosInBlock(pos) {
 		return p.BlockTypeCandidates(file, pos), nil
 	}
@@ -119,7 +201,11 @@ func (p *parser) CompletionCandidatesAtPos(file ihcl.TokenizedFile, pos hcl.Pos)
 		return nil, fmt.Errorf("finding HCL block failed: %#v", err)
 	}
 
-	cfgBlock, err := p.ParseBlockFromTokens(block)
+	if steps, ok := traversalBeforePos(block.Tokens(), pos); ok {
+		return p.referenceCandidatesAtPos(block, mi, steps, pos), nil
+	}
+
+	cfgBlock, err := p.parseBlockFromTokens(block, mi)
 	if err != nil {
 		return nil, fmt.Errorf("finding config block failed: %w", err)
 	}
@@ -127,8 +213,59 @@ func (p *parser) CompletionCandidatesAtPos(file ihcl.TokenizedFile, pos hcl.Pos)
 	return cfgBlock.CompletionCandidatesAtPos(pos)
 }
 
+// HoverAtPos returns hover data for attributes, with the module index
+// (if available) providing details of module inputs
+func (p *parser) HoverAtPos(file ihcl.TokenizedFile, mi *ModuleIndex, pos hcl.Pos) (*HoverData, error) {
+	if !file.PosInBlock(pos) {
+		return nil, nil
+	}
+
+	block, err := file.BlockAtPosition(pos)
+	if err != nil {
+		return nil, fmt.Errorf("finding HCL block failed: %#v", err)
+	}
+
+	cfgBlock, err := p.parseBlockFromTokens(block, mi)
+	if err != nil {
+		return nil, fmt.Errorf("finding config block failed: %w", err)
+	}
+
+	return cfgBlock.HoverAtPos(pos)
+}
+
+// ValidateFile validates all blocks in the file against their schema,
+// with the module index (if available) providing module inputs.
+// Blocks which cannot be validated (e.g. because they're of unknown
+// type or schema is not available) are skipped.
+func (p *parser) ValidateFile(file ihcl.TokenizedFile, mi *ModuleIndex) (hcl.Diagnostics, error) {
+	var diags hcl.Diagnostics
+
+	blocks, err := file.Blocks()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, block := range blocks {
+		cfgBlock, err := p.parseBlockFromTokens(block, mi)
+		if err != nil {
+			p.logger.Printf("skipping validation of block: %s", err)
+			continue
+		}
+
+		blockDiags, err := cfgBlock.Validate()
+		if err != nil {
+			p.logger.Printf("skipping validation of %s %q: %s",
+				cfgBlock.BlockType(), cfgBlock.Name(), err)
+			continue
+		}
+		diags = append(diags, blockDiags...)
+	}
+
+	return diags, nil
+}
+
 func (p *parser) BlockTypeCandidates(file ihcl.TokenizedFile, pos hcl.Pos) CompletionCandidates {
-	bTypes := p.blockTypes()
+	bTypes := p.blockTypes(nil)
 
 	list := &candidateList{
 		candidates: make([]CompletionCandidate, 0),
@@ -190,6 +327,10 @@ func (bt *completableBlockType) Documentation() MarkupContent {
 }
 
 func (p *parser) ParseBlockFromTokens(tBlock ihcl.TokenizedBlock) (ConfigBlock, error) {
+	return p.parseBlockFromTokens(tBlock, nil)
+}
+
+func (p *parser) parseBlockFromTokens(tBlock ihcl.TokenizedBlock, mi *ModuleIndex) (ConfigBlock, error) {
 	// It is probably excessive to be parsing the whole block just for type
 	// but there is no avoiding it without refactoring the upstream HCL parser
 	// and it should not hurt the performance too much
@@ -199,7 +340,7 @@ func (p *parser) ParseBlockFromTokens(tBlock ihcl.TokenizedBlock) (ConfigBlock,
 
 	p.logger.Printf("Parsed block type: %q", block.Type)
 
-	f, ok := p.blockTypes()[block.Type]
+	f, ok := p.blockTypes(mi)[block.Type]
 	if !ok {
 		return nil, &unknownBlockTypeErr{block.Type}
 	}
@@ -11,7 +11,10 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfjson "github.com/hashicorp/terraform-json"
 	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+	"github.com/hashicorp/terraform-ls/internal/terraform/schema"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func TestParser_BlockTypeCandidates_len(t *testing.T) {
@@ -160,3 +163,60 @@ func testLogger() *log.Logger {
 
 	return log.New(ioutil.Discard, "", 0)
 }
+
+func TestParser_ValidateFile(t *testing.T) {
+	content := `variable "name" {
+  default = "test"
+}
+
+provider "test" {
+  alias = "second"
+}
+
+resource "test_resource" "one" {
+  count = 2
+  instanse_type = "t2.micro"
+}
+
+resource "unknown_resource" "two" {
+  anything = "goes"
+}
+`
+	p := newParser()
+	p.SetSchemaReader(&schema.MockReader{
+		ProviderSchemas: &tfjson.ProviderSchemas{
+			Schemas: map[string]*tfjson.ProviderSchema{
+				"test": {
+					ConfigSchema: &tfjson.Schema{
+						Block: &tfjson.SchemaBlock{},
+					},
+					ResourceSchemas: map[string]*tfjson.Schema{
+						"test_resource": {
+							Block: &tfjson.SchemaBlock{
+								Attributes: map[string]*tfjson.SchemaAttribute{
+									"instance_type": {
+										AttributeType: cty.String,
+										Required:      true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	diags, err := p.ValidateFile(ihcl.NewTestFile([]byte(content)), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedDiags := []string{
+		`9:1 error Missing required argument: The argument "instance_type" is required, but no definition was found.`,
+		`11:3 error Unsupported argument: An argument named "instanse_type" is not expected here.`,
+	}
+	if diff := cmp.Diff(expectedDiags, renderDiagnostics(diags)); diff != "" {
+		t.Fatalf("Diagnostics don't match.\n%s", diff)
+	}
+}
@@ -117,6 +117,37 @@ func (p *providerBlock) CompletionCandidatesAtPos(pos hcl.Pos) (CompletionCandid
 	return cb.completionCandidatesAtPos(pos)
 }
 
+func (p *providerBlock) HoverAtPos(pos hcl.Pos) (*HoverData, error) {
+	if p.sr == nil {
+		return nil, &noSchemaReaderErr{p.BlockType()}
+	}
+
+	pSchema, err := p.sr.ProviderConfigSchema(p.RawName())
+	if err != nil {
+		return nil, err
+	}
+	cb := &completableBlock{
+		logger: p.logger,
+		schema: pSchema.Block,
+		tBlock: p.tBlock,
+	}
+	return cb.hoverAtPos(pos)
+}
+
+func (p *providerBlock) Validate() (hcl.Diagnostics, error) {
+	if p.sr == nil {
+		return nil, &noSchemaReaderErr{p.BlockType()}
+	}
+
+	pSchema, err := p.sr.ProviderConfigSchema(p.RawName())
+	if err != nil {
+		return nil, err
+	}
+
+	block := ParseBlock(p.tBlock, withProviderMetaArguments(pSchema.Block))
+	return block.Validate(), nil
+}
+
 func providerCandidates(names []string) []*labelCandidate {
 	candidates := []*labelCandidate{}
 	for _, name := range names {
@@ -0,0 +1,126 @@
+package lang
+
+import (
+	"fmt"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-ls/internal/terraform/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ProviderLock represents a provider selection recorded
+// in the dependency lock file (.terraform.lock.hcl)
+type ProviderLock struct {
+	Address     schema.ProviderAddress
+	Version     string
+	Constraints string
+	Hashes      []string
+}
+
+var lockFileSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{
+			Type:       "provider",
+			LabelNames: []string{"source_addr"},
+		},
+	},
+}
+
+var providerLockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "version", Required: true},
+		{Name: "constraints"},
+		{Name: "hashes"},
+	},
+}
+
+// ParseProviderLocks parses provider selections
+// out of the content of a dependency lock file
+func ParseProviderLocks(filename string, src []byte) ([]*ProviderLock, hcl.Diagnostics) {
+	locks := make([]*ProviderLock, 0)
+
+	f, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return locks, diags
+	}
+
+	// other block types may be added to the lock file in the future
+	content, _, contentDiags := f.Body.PartialContent(lockFileSchema)
+	diags = append(diags, contentDiags...)
+
+	for _, block := range content.Blocks {
+		lock, lockDiags := parseProviderLock(block)
+		diags = append(diags, lockDiags...)
+		if lock != nil {
+			locks = append(locks, lock)
+		}
+	}
+
+	return locks, diags
+}
+
+func parseProviderLock(block *hcl.Block) (*ProviderLock, hcl.Diagnostics) {
+	addr, err := schema.ParseProviderAddress(block.Labels[0])
+	if err != nil {
+		return nil, hcl.Diagnostics{
+			{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid provider source address",
+				Detail:   err.Error(),
+				Subject:  block.LabelRanges[0].Ptr(),
+			},
+		}
+	}
+
+	content, _, diags := block.Body.PartialContent(providerLockSchema)
+
+	lock := &ProviderLock{
+		Address: addr,
+		Hashes:  make([]string, 0),
+	}
+	if attr, ok := content.Attributes["version"]; ok {
+		lock.Version, diags = stringAttributeValue(attr, diags)
+	}
+	if attr, ok := content.Attributes["constraints"]; ok {
+		lock.Constraints, diags = stringAttributeValue(attr, diags)
+	}
+	if attr, ok := content.Attributes["hashes"]; ok {
+		val, valDiags := attr.Expr.Value(nil)
+		diags = append(diags, valDiags...)
+		isList := val.Type().IsTupleType() || val.Type().IsListType()
+		if !valDiags.HasErrors() && isList && val.IsWhollyKnown() && !val.IsNull() {
+			for it := val.ElementIterator(); it.Next(); {
+				_, v := it.Element()
+				if v.IsKnown() && !v.IsNull() && v.Type() == cty.String {
+					lock.Hashes = append(lock.Hashes, v.AsString())
+				}
+			}
+		}
+	}
+
+	return lock, diags
+}
+
+func stringAttributeValue(attr *hcl.Attribute, diags hcl.Diagnostics) (string, hcl.Diagnostics) {
+	val, valDiags := attr.Expr.Value(nil)
+	diags = append(diags, valDiags...)
+	if valDiags.HasErrors() || val.IsNull() || !val.IsKnown() || val.Type() != cty.String {
+		return "", diags
+	}
+	return val.AsString(), diags
+}
+
+// providerLockHoverContent returns details of the selected
+// version of a provider as recorded in the lock file
+func providerLockHoverContent(name string, lock *ProviderLock) MarkupContent {
+	content := fmt.Sprintf("%s (%s)\n\nLocked version: %s", name, lock.Address.Source(), lock.Version)
+	if lock.Constraints != "" {
+		content += fmt.Sprintf("\nConstraints: %s", lock.Constraints)
+	}
+	if len(lock.Hashes) > 0 {
+		content += "\n\nHashes:\n- " + strings.Join(lock.Hashes, "\n- ")
+	}
+	return PlainText(content)
+}
@@ -0,0 +1,61 @@
+package lang
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-ls/internal/terraform/schema"
+)
+
+func TestParseProviderLocks(t *testing.T) {
+	locks, diags := ParseProviderLocks(".terraform.lock.hcl", []byte(`# This file is maintained automatically by "terraform init".
+# Manual edits may be lost in future updates.
+
+provider "registry.terraform.io/hashicorp/aws" {
+  version     = "3.20.0"
+  constraints = "~> 3.0"
+  hashes = [
+    "h1:fakeh1hash=",
+    "zh:fakezhhash",
+  ]
+}
+
+provider "registry.terraform.io/hashicorp/null" {
+  version = "3.0.0"
+}
+`))
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	expected := []*ProviderLock{
+		{
+			Address:     schema.ProviderAddress{Hostname: "registry.terraform.io", Namespace: "hashicorp", Type: "aws"},
+			Version:     "3.20.0",
+			Constraints: "~> 3.0",
+			Hashes:      []string{"h1:fakeh1hash=", "zh:fakezhhash"},
+		},
+		{
+			Address: schema.ProviderAddress{Hostname: "registry.terraform.io", Namespace: "hashicorp", Type: "null"},
+			Version: "3.0.0",
+			Hashes:  []string{},
+		},
+	}
+	if diff := cmp.Diff(expected, locks); diff != "" {
+		t.Fatalf("Locks don't match.\n%s", diff)
+	}
+}
+
+func TestParseProviderLocks_invalid(t *testing.T) {
+	_, diags := ParseProviderLocks(".terraform.lock.hcl", []byte(`provider "registry.terraform.io//aws" {
+  version = "3.20.0"
+}
+`))
+
+	expected := []string{
+		`1:10 error Invalid provider source address: invalid provider address: "registry.terraform.io//aws"`,
+	}
+	if diff := cmp.Diff(expected, renderDiagnostics(diags)); diff != "" {
+		t.Fatalf("Diagnostics don't match.\n%s", diff)
+	}
+}
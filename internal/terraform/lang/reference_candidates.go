@@ -0,0 +1,302 @@
+package lang
+
+import (
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfjson "github.com/hashicorp/terraform-json"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+)
+
+// expressionStartTokens are tokens after which
+// an expression (and hence a reference) can begin
+var expressionStartTokens = map[hclsyntax.TokenType]bool{
+	hclsyntax.TokenEqual:           true,
+	hclsyntax.TokenOParen:          true,
+	hclsyntax.TokenOBrack:          true,
+	hclsyntax.TokenComma:           true,
+	hclsyntax.TokenTemplateInterp:  true,
+	hclsyntax.TokenTemplateControl: true,
+	hclsyntax.TokenQuestion:        true,
+	hclsyntax.TokenColon:           true,
+	hclsyntax.TokenFatArrow:        true,
+	hclsyntax.TokenPlus:            true,
+	hclsyntax.TokenMinus:           true,
+	hclsyntax.TokenStar:            true,
+	hclsyntax.TokenSlash:           true,
+	hclsyntax.TokenPercent:         true,
+	hclsyntax.TokenAnd:             true,
+	hclsyntax.TokenOr:              true,
+	hclsyntax.TokenBang:            true,
+	hclsyntax.TokenEqualOp:         true,
+	hclsyntax.TokenNotEqual:        true,
+	hclsyntax.TokenLessThan:        true,
+	hclsyntax.TokenLessThanEq:      true,
+	hclsyntax.TokenGreaterThan:     true,
+	hclsyntax.TokenGreaterThanEq:   true,
+}
+
+// traversalBeforePos returns names of traversal steps preceding
+// the given position, e.g. [aws_instance web] for aws_instance.web.i
+// and reports whether the position is within an expression at all.
+//
+// Tokens are used instead of parsed expressions as incomplete
+// traversals (e.g. var.) are not valid expressions.
+func traversalBeforePos(tokens hclsyntax.Tokens, pos hcl.Pos) ([]string, bool) {
+	i := -1
+	for idx, t := range tokens {
+		if t.Range.Start.Byte >= pos.Byte {
+			break
+		}
+		i = idx
+	}
+	if i < 0 {
+		return nil, false
+	}
+
+	if tokens[i].Type == hclsyntax.TokenIdent && tokens[i].Range.End.Byte >= pos.Byte {
+		// identifier being typed is a prefix, not a step
+		i--
+	} else if tokens[i].Range.End.Byte > pos.Byte {
+		// e.g. inside a string literal
+		return nil, false
+	}
+
+	steps := make([]string, 0)
+	for i >= 0 && tokens[i].Type == hclsyntax.TokenDot {
+		i--
+
+		// skip index steps, e.g. [0] in aws_instance.web[0].id
+		if i >= 0 && tokens[i].Type == hclsyntax.TokenCBrack {
+			depth := 0
+			for ; i >= 0; i-- {
+				switch tokens[i].Type {
+				case hclsyntax.TokenCBrack:
+					depth++
+				case hclsyntax.TokenOBrack:
+					depth--
+				}
+				if depth == 0 {
+					break
+				}
+			}
+			i--
+		}
+
+		if i < 0 || tokens[i].Type != hclsyntax.TokenIdent {
+			return nil, false
+		}
+		steps = append([]string{string(tokens[i].Bytes)}, steps...)
+		i--
+	}
+
+	if i < 0 {
+		return nil, false
+	}
+
+	inExpression := expressionStartTokens[tokens[i].Type]
+	if tokens[i].Type == hclsyntax.TokenNewline {
+		// newlines are only insignificant inside brackets and parentheses
+		inExpression = isInsideBrackets(tokens[:i])
+	}
+	if !inExpression {
+		return nil, false
+	}
+
+	return steps, true
+}
+
+// isInsideBrackets reports whether the given tokens
+// leave any bracket or parenthesis unclosed
+func isInsideBrackets(tokens hclsyntax.Tokens) bool {
+	depth := 0
+	for i := len(tokens) - 1; i >= 0; i-- {
+		switch tokens[i].Type {
+		case hclsyntax.TokenCBrack, hclsyntax.TokenCParen, hclsyntax.TokenCBrace:
+			depth++
+		case hclsyntax.TokenOBrack, hclsyntax.TokenOParen:
+			if depth == 0 {
+				return true
+			}
+			depth--
+		case hclsyntax.TokenOBrace:
+			if depth == 0 {
+				return false
+			}
+			depth--
+		}
+	}
+	return false
+}
+
+// referenceCandidatesAtPos returns candidates for completing
+// a reference to a declared object, or its attribute
+// following the given traversal steps, or a function call
+func (p *parser) referenceCandidatesAtPos(tBlock ihcl.TokenizedBlock, mi *ModuleIndex,
+	steps []string, pos hcl.Pos) CompletionCandidates {
+	list := &candidateList{
+		candidates: make([]CompletionCandidate, 0),
+	}
+
+	prefix, prefixRng := prefixAtPos(tBlock, pos)
+
+	candidates := make([]CompletionCandidate, 0)
+	if mi != nil {
+		for _, c := range p.referenceCandidates(mi, steps) {
+			c.prefixRng = prefixRng
+			candidates = append(candidates, c)
+		}
+	} else {
+		p.logger.Println("module index not available for reference completion")
+	}
+	if len(steps) == 0 {
+		// functions can only be called where a traversal could begin
+		candidates = append(candidates, p.functionCandidates(prefixRng)...)
+	}
+
+	for _, c := range candidates {
+		if len(list.candidates) >= p.maxCandidates {
+			list.isIncomplete = true
+			break
+		}
+		if !strings.HasPrefix(c.Label(), prefix) {
+			continue
+		}
+		list.candidates = append(list.candidates, c)
+	}
+	list.Sort()
+
+	return list
+}
+
+func (p *parser) referenceCandidates(mi *ModuleIndex, steps []string) []*labelCandidate {
+	candidates := make([]*labelCandidate, 0)
+
+	// Next step of a declared address, e.g. region out of var.region
+	seen := make(map[string]bool, 0)
+	for _, d := range mi.Declarations() {
+		if d.BlockType == "output" {
+			// outputs are not referenceable within the module
+			continue
+		}
+		segments := strings.Split(d.Address, ".")
+		if len(segments) <= len(steps) || !hasPrefixSteps(segments, steps) {
+			continue
+		}
+		next := segments[len(steps)]
+		if seen[next] {
+			continue
+		}
+		seen[next] = true
+
+		c := &labelCandidate{
+			label:         next,
+			documentation: PlainText(""),
+		}
+		if len(segments) == len(steps)+1 {
+			c.detail = declarationDetail(d)
+		}
+		candidates = append(candidates, c)
+	}
+	if len(candidates) > 0 {
+		return candidates
+	}
+
+	// Attributes of a declared object, e.g. id out of aws_instance.web.id
+	decl, ok := mi.Declaration(strings.Join(steps, "."))
+	if !ok {
+		return candidates
+	}
+
+	switch decl.BlockType {
+	case "module":
+		child, ok := mi.CalledModule(steps[1])
+		if !ok {
+			p.logger.Printf("module %q is not installed", steps[1])
+			return candidates
+		}
+		for _, d := range child.Declarations() {
+			if d.BlockType != "output" {
+				continue
+			}
+			candidates = append(candidates, &labelCandidate{
+				label:         strings.TrimPrefix(d.Address, "output."),
+				detail:        declarationDetail(d),
+				documentation: PlainText(""),
+			})
+		}
+	case "resource", "data":
+		if p.schemaReader == nil {
+			p.logger.Println("schema reader not available for reference completion")
+			return candidates
+		}
+
+		var s *tfjson.Schema
+		var err error
+		if decl.BlockType == "resource" {
+			s, err = p.schemaReader.ResourceSchema(steps[0])
+		} else {
+			s, err = p.schemaReader.DataSourceSchema(steps[1])
+		}
+		if err != nil {
+			p.logger.Printf("schema not available for %s: %s", decl.Address, err)
+			return candidates
+		}
+
+		candidates = append(candidates, schemaAttributeCandidates(s.Block)...)
+	}
+
+	return candidates
+}
+
+func schemaAttributeCandidates(block *tfjson.SchemaBlock) []*labelCandidate {
+	candidates := make([]*labelCandidate, 0)
+	if block == nil {
+		return candidates
+	}
+
+	for name, attr := range block.Attributes {
+		candidates = append(candidates, &labelCandidate{
+			label:         name,
+			detail:        schemaAttributeDetail(attr),
+			documentation: schemaAttributeDescription(attr),
+		})
+	}
+	for name, bType := range block.NestedBlocks {
+		candidates = append(candidates, &labelCandidate{
+			label:         name,
+			detail:        schemaBlockDetail(&BlockType{schema: bType}),
+			documentation: PlainText(""),
+		})
+	}
+
+	return candidates
+}
+
+func declarationDetail(d *Declaration) string {
+	switch d.BlockType {
+	case "variable":
+		return "Variable"
+	case "locals":
+		return "Local value"
+	case "output":
+		return "Output"
+	case "module":
+		return "Module"
+	case "resource":
+		return "Resource"
+	case "data":
+		return "Data source"
+	}
+	return ""
+}
+
+func hasPrefixSteps(segments, steps []string) bool {
+	for i, step := range steps {
+		if segments[i] != step {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,183 @@
+package lang
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfjson "github.com/hashicorp/terraform-json"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+	"github.com/hashicorp/terraform-ls/internal/terraform/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestTraversalBeforePos(t *testing.T) {
+	testCases := []struct {
+		cfg string
+
+		expectedSteps []string
+		expectedOk    bool
+	}{
+		{`value = var.|`, []string{"var"}, true},
+		{`value = var.reg|`, []string{"var"}, true},
+		{`value = |`, []string{}, true},
+		{`value = lo|`, []string{}, true},
+		{`value = "${local.|}"`, []string{"local"}, true},
+		{`value = aws_instance.web[0].|`, []string{"aws_instance", "web"}, true},
+		{`value = data.aws_ami.ubuntu.|`, []string{"data", "aws_ami", "ubuntu"}, true},
+		{`value = merge(local.tags, |)`, []string{}, true},
+		{"value = [\n  var.|\n]", []string{"var"}, true},
+		{`value = "foo.|"`, nil, false},
+		{`valu|`, nil, false},
+		{"value = 1\n  aws_instance.|", nil, false},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			src, pos := splitCursor(t, tc.cfg)
+			tokens, diags := hclsyntax.LexConfig(src, "/test.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+
+			steps, ok := traversalBeforePos(tokens, pos)
+			if ok != tc.expectedOk {
+				t.Fatalf("expected ok: %t, given: %t", tc.expectedOk, ok)
+			}
+			if diff := cmp.Diff(tc.expectedSteps, steps); diff != "" {
+				t.Fatalf("Steps don't match.\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParser_CompletionCandidatesAtPos_references(t *testing.T) {
+	cfg := `variable "region" {}
+
+locals {
+  tags = {}
+}
+
+module "vpc" {
+  source = "./vpc"
+}
+
+data "aws_ami" "ubuntu" {}
+
+resource "aws_instance" "web" {
+  ami = %s
+}
+`
+	vpc := NewModuleIndex()
+	vpc.IndexFile("/test/vpc/outputs.tf", []byte(`output "vpc_id" {}
+output "subnet_ids" {}
+`))
+
+	p := newParser()
+	// functions are tested separately
+	p.functions = map[string]*FunctionSignature{}
+	p.SetSchemaReader(&schema.MockReader{
+		ProviderSchemas: &tfjson.ProviderSchemas{
+			Schemas: map[string]*tfjson.ProviderSchema{
+				"aws": {
+					ConfigSchema: &tfjson.Schema{
+						Block: &tfjson.SchemaBlock{},
+					},
+					ResourceSchemas: map[string]*tfjson.Schema{
+						"aws_instance": {
+							Block: &tfjson.SchemaBlock{
+								Attributes: map[string]*tfjson.SchemaAttribute{
+									"ami": {
+										AttributeType: cty.String,
+										Required:      true,
+									},
+									"id": {
+										AttributeType: cty.String,
+										Computed:      true,
+									},
+								},
+							},
+						},
+					},
+					DataSourceSchemas: map[string]*tfjson.Schema{
+						"aws_ami": {
+							Block: &tfjson.SchemaBlock{
+								Attributes: map[string]*tfjson.SchemaAttribute{
+									"image_id": {
+										AttributeType: cty.String,
+										Computed:      true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	testCases := []struct {
+		expr               string
+		expectedCandidates []string
+	}{
+		{"|", []string{"aws_instance", "data", "local", "module", "var"}},
+		{"var.|", []string{"region (Variable)"}},
+		{"local.t|", []string{"tags (Local value)"}},
+		{"module.|", []string{"vpc (Module)"}},
+		{"module.vpc.|", []string{"subnet_ids (Output)", "vpc_id (Output)"}},
+		{"data.|", []string{"aws_ami"}},
+		{"data.aws_ami.ubuntu.|", []string{"image_id (Computed, string)"}},
+		{"aws_instance.web.|", []string{"ami (Required, string)", "id (Computed, string)"}},
+		{"var.region.|", []string{}},
+		{"module.unknown.|", []string{}},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.expr), func(t *testing.T) {
+			src, pos := splitCursor(t, fmt.Sprintf(cfg, tc.expr))
+
+			mi := NewModuleIndex()
+			mi.IndexFile("/test/main.tf", src)
+			mi.AddCalledModule("vpc", vpc)
+
+			candidates, err := p.CompletionCandidatesAtPos(ihcl.NewTestFile(src), mi, pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rendered := make([]string, 0)
+			for _, c := range candidates.List() {
+				label := c.Label()
+				if c.Detail() != "" {
+					label += fmt.Sprintf(" (%s)", c.Detail())
+				}
+				rendered = append(rendered, label)
+			}
+			if diff := cmp.Diff(tc.expectedCandidates, rendered); diff != "" {
+				t.Fatalf("Candidates don't match.\n%s", diff)
+			}
+		})
+	}
+}
+
+// splitCursor removes the cursor marker (|) from the given config
+// and returns position of the cursor
+func splitCursor(t *testing.T, cfg string) ([]byte, hcl.Pos) {
+	offset := strings.Index(cfg, "|")
+	if offset < 0 {
+		t.Fatalf("no cursor found in %q", cfg)
+	}
+
+	before := cfg[:offset]
+	line := strings.Count(before, "\n") + 1
+	column := offset - strings.LastIndex(before, "\n")
+
+	return []byte(before + cfg[offset+1:]), hcl.Pos{
+		Line:   line,
+		Column: column,
+		Byte:   offset,
+	}
+}
@@ -0,0 +1,294 @@
+package lang
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+	"github.com/hashicorp/terraform-ls/internal/terraform/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// versionConstraintSnippet offers common operators
+// of version constraints, e.g. ~> 2.0
+const versionConstraintSnippet = `"${%d|~>,>=,=|} ${%d}"`
+
+// requiredProvidersBlockAtPos returns a required_providers block
+// within the given terraform block, whose body contains the position
+func requiredProvidersBlockAtPos(block *hclsyntax.Block, pos hcl.Pos) (*hclsyntax.Block, bool) {
+	if block == nil || block.Body == nil {
+		return nil, false
+	}
+	for _, b := range block.Body.Blocks {
+		if b.Type == "required_providers" && posInBodyOfBlock(b, pos) {
+			return b, true
+		}
+	}
+	return nil, false
+}
+
+// completableRequiredProviders provides completion of provider
+// local names, source addresses and version constraints
+// within the required_providers block
+type completableRequiredProviders struct {
+	logger        *log.Logger
+	maxCandidates int
+	tBlock        ihcl.TokenizedBlock
+	block         *hclsyntax.Block
+	sr            schema.Reader
+	tfVersion     *version.Version
+}
+
+func (rp *completableRequiredProviders) maxCompletionCandidates() int {
+	if rp.maxCandidates > 0 {
+		return rp.maxCandidates
+	}
+	return defaultMaxCompletionCandidates
+}
+
+func (rp *completableRequiredProviders) completionCandidatesAtPos(pos hcl.Pos) (CompletionCandidates, error) {
+	list := &candidateList{
+		candidates: make([]CompletionCandidate, 0),
+	}
+
+	prefix, prefixRng := prefixAtPos(rp.tBlock, pos)
+
+	var candidates []CompletionCandidate
+	attr, ok := rp.attributeAtPos(pos)
+	switch {
+	case !ok || rangeContainsOffset(attr.NameRange, pos.Byte):
+		addrs, err := rp.sr.ProviderAddresses()
+		if err != nil {
+			return nil, err
+		}
+		candidates = rp.localNameCandidates(addrs, prefixRng)
+	default:
+		obj, ok := attr.Expr.(*hclsyntax.ObjectConsExpr)
+		if !ok {
+			// e.g. version constraint in 0.12 style
+			return list, nil
+		}
+		item, ok := objectItemAtPos(obj, pos)
+		if ok && rangeContainsOffset(item.ValueExpr.Range(), pos.Byte) {
+			if hcl.ExprAsKeyword(item.KeyExpr) != "source" {
+				return list, nil
+			}
+			addrs, err := rp.sr.ProviderAddresses()
+			if err != nil {
+				return nil, err
+			}
+			candidates = sourceCandidates(addrs, prefixRng)
+			break
+		}
+		candidates = rp.keyCandidates(obj, prefixRng)
+	}
+
+	for _, c := range candidates {
+		if len(list.candidates) >= rp.maxCompletionCandidates() {
+			list.isIncomplete = true
+			break
+		}
+		if !strings.HasPrefix(c.Label(), prefix) {
+			continue
+		}
+		list.candidates = append(list.candidates, c)
+	}
+	list.Sort()
+
+	return list, nil
+}
+
+func (rp *completableRequiredProviders) attributeAtPos(pos hcl.Pos) (*hclsyntax.Attribute, bool) {
+	for _, attr := range rp.block.Body.Attributes {
+		if rangeContainsOffset(attr.Range(), pos.Byte) {
+			return attr, true
+		}
+	}
+	return nil, false
+}
+
+func objectItemAtPos(obj *hclsyntax.ObjectConsExpr, pos hcl.Pos) (hclsyntax.ObjectConsItem, bool) {
+	for _, item := range obj.Items {
+		rng := hcl.RangeBetween(item.KeyExpr.Range(), item.ValueExpr.Range())
+		if rangeContainsOffset(rng, pos.Byte) {
+			return item, true
+		}
+	}
+	return hclsyntax.ObjectConsItem{}, false
+}
+
+// localNameCandidates returns candidates for providers
+// which are not required yet, with snippets declaring
+// their source (0.13+) and version constraint
+func (rp *completableRequiredProviders) localNameCandidates(addrs []schema.ProviderAddress,
+	prefixRng *hcl.Range) []CompletionCandidate {
+	sort.Slice(addrs, func(i, j int) bool {
+		return addrs[i].String() < addrs[j].String()
+	})
+
+	candidates := make([]CompletionCandidate, 0)
+	seen := make(map[string]bool, 0)
+	for _, pa := range addrs {
+		if _, ok := rp.block.Body.Attributes[pa.Type]; ok || seen[pa.Type] {
+			continue
+		}
+		seen[pa.Type] = true
+
+		c := &snippetCandidate{
+			label:         pa.Type,
+			detail:        pa.Source(),
+			documentation: PlainText(""),
+			prefixRng:     prefixRng,
+		}
+		if versionSupports(rp.tfVersion, "0.13.0") {
+			c.snippet = fmt.Sprintf("%s = {\n  source  = %q\n  version = %s\n}",
+				pa.Type, pa.Source(), fmt.Sprintf(versionConstraintSnippet, 1, 2))
+		} else {
+			c.snippet = fmt.Sprintf("%s = %s",
+				pa.Type, fmt.Sprintf(versionConstraintSnippet, 1, 2))
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates
+}
+
+// keyCandidates returns candidates for arguments of a provider
+// requirement which are not declared yet
+func (rp *completableRequiredProviders) keyCandidates(obj *hclsyntax.ObjectConsExpr,
+	prefixRng *hcl.Range) []CompletionCandidate {
+	declared := make(map[string]bool, 0)
+	for _, item := range obj.Items {
+		declared[hcl.ExprAsKeyword(item.KeyExpr)] = true
+	}
+
+	candidates := make([]CompletionCandidate, 0)
+	if !declared["source"] && versionSupports(rp.tfVersion, "0.13.0") {
+		candidates = append(candidates, &snippetCandidate{
+			label:         "source",
+			detail:        "Optional, string",
+			documentation: PlainText("Source address of the provider, e.g. hashicorp/aws."),
+			snippet:       `source = "${1}"`,
+			prefixRng:     prefixRng,
+		})
+	}
+	if !declared["version"] {
+		candidates = append(candidates, &snippetCandidate{
+			label:         "version",
+			detail:        "Optional, string",
+			documentation: PlainText("Version constraint for the provider, e.g. ~> 2.0."),
+			snippet:       "version = " + fmt.Sprintf(versionConstraintSnippet, 1, 2),
+			prefixRng:     prefixRng,
+		})
+	}
+	return candidates
+}
+
+// sourceCandidates returns candidates for source addresses
+// of all providers with schemas available
+func sourceCandidates(addrs []schema.ProviderAddress, prefixRng *hcl.Range) []CompletionCandidate {
+	candidates := make([]CompletionCandidate, 0)
+	seen := make(map[string]bool, 0)
+	for _, pa := range addrs {
+		source := pa.Source()
+		if seen[source] {
+			continue
+		}
+		seen[source] = true
+
+		candidates = append(candidates, &labelCandidate{
+			label:         source,
+			detail:        "Provider source",
+			documentation: PlainText(""),
+			prefixRng:     prefixRng,
+		})
+	}
+	return candidates
+}
+
+// snippetCandidate is a candidate whose snippet
+// differs from the label inserted as plain text
+type snippetCandidate struct {
+	label         string
+	detail        string
+	documentation MarkupContent
+	snippet       string
+	prefixRng     *hcl.Range
+}
+
+func (c *snippetCandidate) Label() string {
+	return c.label
+}
+
+func (c *snippetCandidate) Detail() string {
+	return c.detail
+}
+
+func (c *snippetCandidate) Documentation() MarkupContent {
+	return c.documentation
+}
+
+func (c *snippetCandidate) Snippet() TextEdit {
+	return &textEdit{
+		newText: c.snippet,
+		rng:     c.prefixRng,
+	}
+}
+
+func (c *snippetCandidate) PlainText() TextEdit {
+	return &textEdit{
+		newText: c.label,
+		rng:     c.prefixRng,
+	}
+}
+
+// requiredProviderAddress returns address of the provider required
+// by the given entry of the required_providers block, assuming
+// an official provider if the source is not specified
+func requiredProviderAddress(attr *hclsyntax.Attribute) (schema.ProviderAddress, error) {
+	source := "hashicorp/" + attr.Name
+	if obj, ok := attr.Expr.(*hclsyntax.ObjectConsExpr); ok {
+		for _, item := range obj.Items {
+			if hcl.ExprAsKeyword(item.KeyExpr) != "source" {
+				continue
+			}
+			val, diags := item.ValueExpr.Value(nil)
+			if diags.HasErrors() || !val.IsKnown() || val.IsNull() || val.Type() != cty.String {
+				return schema.ProviderAddress{}, fmt.Errorf("invalid source of provider %q", attr.Name)
+			}
+			source = val.AsString()
+		}
+	}
+
+	return schema.ParseProviderAddress(source)
+}
+
+// requiredProviderHoverAtPos returns the locked version
+// of the provider required at the given position (if any)
+func requiredProviderHoverAtPos(block *hclsyntax.Block, locks []*ProviderLock, pos hcl.Pos) (*HoverData, bool) {
+	for _, attr := range block.Body.Attributes {
+		if !rangeContainsOffset(attr.Range(), pos.Byte) {
+			continue
+		}
+
+		addr, err := requiredProviderAddress(attr)
+		if err != nil {
+			return nil, false
+		}
+		for _, lock := range locks {
+			if lock.Address != addr {
+				continue
+			}
+			rng := attr.NameRange
+			return &HoverData{
+				Content: providerLockHoverContent(attr.Name, lock),
+				Range:   &rng,
+			}, true
+		}
+	}
+	return nil, false
+}
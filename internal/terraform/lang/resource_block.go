@@ -4,9 +4,9 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/hashicorp/go-version"
 	hcl "github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
-	tfjson "github.com/hashicorp/terraform-json"
 	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
 	"github.com/hashicorp/terraform-ls/internal/terraform/schema"
 )
@@ -15,6 +15,7 @@ type resourceBlockFactory struct {
 	logger *log.Logger
 
 	schemaReader schema.Reader
+	tfVersion    *version.Version
 }
 
 func (f *resourceBlockFactory) New(tBlock ihcl.TokenizedBlock) (ConfigBlock, error) {
@@ -28,6 +29,7 @@ func (f *resourceBlockFactory) New(tBlock ihcl.TokenizedBlock) (ConfigBlock, err
 		labelSchema: f.LabelSchema(),
 		tBlock:      tBlock,
 		sr:          f.schemaReader,
+		tfVersion:   f.tfVersion,
 	}, nil
 }
 
@@ -55,6 +57,7 @@ type resourceBlock struct {
 	labels      []*ParsedLabel
 	tBlock      ihcl.TokenizedBlock
 	sr          schema.Reader
+	tfVersion   *version.Version
 }
 
 func (r *resourceBlock) Type() string {
@@ -108,7 +111,7 @@ func (r *resourceBlock) CompletionCandidatesAtPos(pos hcl.Pos) (CompletionCandid
 			parsedLabels: r.Labels(),
 			tBlock:       r.tBlock,
 			labels: labelCandidates{
-				"type": resourceCandidates(resources),
+				"type": resourceCandidates(r.sr, resources),
 			},
 		}
 
@@ -122,24 +125,63 @@ func (r *resourceBlock) CompletionCandidatesAtPos(pos hcl.Pos) (CompletionCandid
 	cb := &completableBlock{
 		logger:       r.logger,
 		parsedLabels: r.Labels(),
-		schema:       rSchema.Block,
+		schema:       withResourceMetaArguments(rSchema.Block, r.tfVersion),
 		tBlock:       r.tBlock,
+		tfVersion:    r.tfVersion,
 	}
 	return cb.completionCandidatesAtPos(pos)
 }
 
-func resourceCandidates(resources []schema.Resource) []*labelCandidate {
+func (r *resourceBlock) HoverAtPos(pos hcl.Pos) (*HoverData, error) {
+	if r.sr == nil {
+		return nil, &noSchemaReaderErr{r.BlockType()}
+	}
+
+	rSchema, err := r.sr.ResourceSchema(r.Type())
+	if err != nil {
+		return nil, err
+	}
+	cb := &completableBlock{
+		logger:       r.logger,
+		parsedLabels: r.Labels(),
+		schema:       withResourceMetaArguments(rSchema.Block, r.tfVersion),
+		tBlock:       r.tBlock,
+	}
+	return cb.hoverAtPos(pos)
+}
+
+func (r *resourceBlock) Validate() (hcl.Diagnostics, error) {
+	if r.sr == nil {
+		return nil, &noSchemaReaderErr{r.BlockType()}
+	}
+
+	rSchema, err := r.sr.ResourceSchema(r.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	hclBlock, _ := hclsyntax.ParseBlockFromTokens(r.tBlock.Tokens())
+	diags := validateProvisionerTypes(hclBlock, r.tfVersion)
+
+	block := parseBlock(hclBlock, withResourceMetaArguments(rSchema.Block, r.tfVersion))
+	return append(diags, block.Validate()...), nil
+}
+
+func resourceCandidates(sr schema.Reader, resources []schema.Resource) []*labelCandidate {
 	candidates := []*labelCandidate{}
 	for _, r := range resources {
-		var desc MarkupContent = PlainText(r.Description)
-		if r.DescriptionKind == tfjson.SchemaDescriptionKindMarkdown {
-			desc = Markdown(r.Description)
-		}
-
+		name := r.Name
 		candidates = append(candidates, &labelCandidate{
-			label:         r.Name,
-			detail:        fmt.Sprintf("Resource (%s)", r.Provider),
-			documentation: desc,
+			label:  name,
+			detail: fmt.Sprintf("Resource (%s)", r.Provider),
+			// schema is only decoded for candidates which are returned
+			resolveDocumentation: func() MarkupContent {
+				s, err := sr.ResourceSchema(name)
+				if err != nil {
+					return PlainText("")
+				}
+				return schemaBlockDescription(s.Block)
+			},
 		})
 	}
 	return candidates
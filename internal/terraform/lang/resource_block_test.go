@@ -142,6 +142,66 @@ func TestResourceBlock_completionCandidatesAtPos(t *testing.T) {
 						Text: `attr_required = "${0:value}"`,
 					},
 				},
+				{
+					Label:  "connection",
+					Detail: "Block, single",
+					Snippet: renderedSnippet{
+						Pos:  hcl.Pos{Line: 2, Column: 1, Byte: 30},
+						Text: "connection {\n  ${0}\n}",
+					},
+				},
+				{
+					Label:         "count",
+					Detail:        "Optional, number",
+					Documentation: "The number of instances to create.",
+					Snippet: renderedSnippet{
+						Pos:  hcl.Pos{Line: 2, Column: 1, Byte: 30},
+						Text: "count = ${0:42}",
+					},
+				},
+				{
+					Label:         "depends_on",
+					Detail:        "Optional, dynamic",
+					Documentation: "A list of hidden dependencies which Terraform cannot infer from references.",
+					Snippet: renderedSnippet{
+						Pos:  hcl.Pos{Line: 2, Column: 1, Byte: 30},
+						Text: "depends_on = ${0}",
+					},
+				},
+				{
+					Label:         "for_each",
+					Detail:        "Optional, dynamic",
+					Documentation: "A map or a set of strings to create an instance for each item of.",
+					Snippet: renderedSnippet{
+						Pos:  hcl.Pos{Line: 2, Column: 1, Byte: 30},
+						Text: "for_each = ${0}",
+					},
+				},
+				{
+					Label:  "lifecycle",
+					Detail: "Block, single",
+					Snippet: renderedSnippet{
+						Pos:  hcl.Pos{Line: 2, Column: 1, Byte: 30},
+						Text: "lifecycle {\n  ${0}\n}",
+					},
+				},
+				{
+					Label:         "provider",
+					Detail:        "Optional, dynamic",
+					Documentation: "Reference to a non-default provider configuration, e.g. aws.west.",
+					Snippet: renderedSnippet{
+						Pos:  hcl.Pos{Line: 2, Column: 1, Byte: 30},
+						Text: "provider = ${0}",
+					},
+				},
+				{
+					Label:  "provisioner",
+					Detail: "Block, list",
+					Snippet: renderedSnippet{
+						Pos:  hcl.Pos{Line: 2, Column: 1, Byte: 30},
+						Text: "provisioner \"${1|chef,file,habitat,local-exec,puppet,remote-exec,salt-masterless|}\" {\n  ${0}\n}",
+					},
+				},
 			},
 			nil,
 		},
@@ -35,6 +35,9 @@ func snippetForAttrType(placeholder int, attrType cty.Type) string {
 		return fmt.Sprintf(`[${%d:42}]`, placeholder)
 	case cty.Map(cty.Number):
 		return mapSnippet(cty.Number)
+
+	case cty.DynamicPseudoType:
+		return fmt.Sprintf(`${%d}`, placeholder)
 	}
 
 	return ""
@@ -44,6 +47,15 @@ func snippetForNestedBlock(name string) string {
 	return fmt.Sprintf("%s {\n  ${0}\n}", name)
 }
 
+// snippetForLabeledNestedBlock returns snippet for a nested block
+// offering a choice of types, e.g. provisioner "local-exec"
+func snippetForLabeledNestedBlock(name string, types []string) string {
+	if len(types) == 0 {
+		return fmt.Sprintf("%s \"${1}\" {\n  ${0}\n}", name)
+	}
+	return fmt.Sprintf("%s \"${1|%s|}\" {\n  ${0}\n}", name, strings.Join(types, ","))
+}
+
 func snippetForBlock(name string, labelSchema LabelSchema) string {
 	bodyPlaceholder := 0
 	labels := make([]string, len(labelSchema))
@@ -56,6 +68,10 @@ func snippetForBlock(name string, labelSchema LabelSchema) string {
 		bodyPlaceholder = i + 2
 	}
 
+	if len(labels) == 0 {
+		return fmt.Sprintf("%s {\n  ${%d}\n}", name, bodyPlaceholder)
+	}
+
 	return fmt.Sprintf("%s %s {\n  ${%d}\n}",
 		name, strings.Join(labels, " "), bodyPlaceholder)
 }
@@ -68,6 +84,9 @@ func schemaAttributeDetail(attr *tfjson.SchemaAttribute) string {
 	if attr.Required {
 		requiredText = "Required"
 	}
+	if attr.Computed && !attr.Optional && !attr.Required {
+		requiredText = "Computed"
+	}
 
 	return strings.TrimSpace(fmt.Sprintf("%s, %s",
 		requiredText, attr.AttributeType.FriendlyName()))
@@ -87,3 +106,20 @@ func schemaBlockDetail(blockType *BlockType) string {
 
 	return strings.TrimSpace(detail)
 }
+
+func schemaAttributeDescription(attr *tfjson.SchemaAttribute) MarkupContent {
+	if attr.DescriptionKind == tfjson.SchemaDescriptionKindMarkdown {
+		return Markdown(attr.Description)
+	}
+	return PlainText(attr.Description)
+}
+
+func schemaBlockDescription(block *tfjson.SchemaBlock) MarkupContent {
+	if block == nil {
+		return PlainText("")
+	}
+	if block.DescriptionKind == tfjson.SchemaDescriptionKindMarkdown {
+		return Markdown(block.Description)
+	}
+	return PlainText(block.Description)
+}
@@ -0,0 +1,89 @@
+package lang
+
+import (
+	"fmt"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+)
+
+// SignatureData represents signature of a function called
+// at a given position, loosely reflecting lsp.SignatureHelp
+type SignatureData struct {
+	Function        *FunctionSignature
+	ActiveParameter int
+}
+
+// SignatureAtPos returns signature of the built-in function
+// in whose arguments the given position is, if any
+func (p *parser) SignatureAtPos(file ihcl.TokenizedFile, pos hcl.Pos) (*SignatureData, error) {
+	if !file.PosInBlock(pos) {
+		return nil, nil
+	}
+
+	block, err := file.BlockAtPosition(pos)
+	if err != nil {
+		return nil, fmt.Errorf("finding HCL block failed: %#v", err)
+	}
+
+	name, argIdx, ok := functionCallAtPos(block.Tokens(), pos)
+	if !ok {
+		return nil, nil
+	}
+
+	fs, ok := p.functions[name]
+	if !ok {
+		p.logger.Printf("function %q is not available", name)
+		return nil, nil
+	}
+
+	return &SignatureData{
+		Function:        fs,
+		ActiveParameter: fs.ActiveParameter(argIdx),
+	}, nil
+}
+
+// functionCallAtPos returns name of the function in whose arguments
+// the given position is and index of the argument at that position
+//
+// Tokens are used instead of parsed expressions as calls
+// being typed are usually not valid expressions yet.
+func functionCallAtPos(tokens hclsyntax.Tokens, pos hcl.Pos) (string, int, bool) {
+	last := -1
+	for i, t := range tokens {
+		if t.Range.Start.Byte >= pos.Byte {
+			break
+		}
+		last = i
+	}
+
+	depth := 0
+	argIdx := 0
+	for i := last; i >= 0; i-- {
+		switch tokens[i].Type {
+		case hclsyntax.TokenCParen, hclsyntax.TokenCBrack,
+			hclsyntax.TokenCBrace, hclsyntax.TokenTemplateSeqEnd:
+			depth++
+		case hclsyntax.TokenOParen, hclsyntax.TokenOBrack, hclsyntax.TokenOBrace,
+			hclsyntax.TokenTemplateInterp, hclsyntax.TokenTemplateControl:
+			if depth > 0 {
+				depth--
+				continue
+			}
+			if tokens[i].Type == hclsyntax.TokenOParen &&
+				i > 0 && tokens[i-1].Type == hclsyntax.TokenIdent {
+				return string(tokens[i-1].Bytes), argIdx, true
+			}
+			// position is within a nested expression
+			// which is a single argument of any outer call
+			argIdx = 0
+		case hclsyntax.TokenComma:
+			if depth == 0 {
+				argIdx++
+			}
+		}
+	}
+
+	return "", 0, false
+}
@@ -0,0 +1,141 @@
+package lang
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+)
+
+func TestFunctionCallAtPos(t *testing.T) {
+	testCases := []struct {
+		cfg            string
+		expectedName   string
+		expectedArgIdx int
+		expectedOk     bool
+	}{
+		{`value = lookup(|`, "lookup", 0, true},
+		{`value = lookup(var.map, |`, "lookup", 1, true},
+		{`value = lookup(var.map, "key", |)`, "lookup", 2, true},
+		{`value = lookup({a = 1, b = 2}, |`, "lookup", 1, true},
+		{`value = lookup(var.map, [1, 2|])`, "lookup", 1, true},
+		{`value = join(",", split(",", |))`, "split", 1, true},
+		{`value = join(",", split(",", var.list), |)`, "join", 2, true},
+		{`value = max((1 + |`, "max", 0, true},
+		{"value = format(\n  \"%s\",\n  |\n)", "format", 1, true},
+		{`value = "${upper(|)}"`, "upper", 0, true},
+		{`value = upper(var.name)|`, "", 0, false},
+		{`value = [1, |]`, "", 0, false},
+		{`value = |`, "", 0, false},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			src, pos := splitCursor(t, tc.cfg)
+			tokens, diags := hclsyntax.LexConfig(src, "/test.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+
+			name, argIdx, ok := functionCallAtPos(tokens, pos)
+			if ok != tc.expectedOk {
+				t.Fatalf("expected ok: %t, given: %t", tc.expectedOk, ok)
+			}
+			if name != tc.expectedName || argIdx != tc.expectedArgIdx {
+				t.Fatalf("call doesn't match.\nexpected: %s (%d)\ngiven: %s (%d)",
+					tc.expectedName, tc.expectedArgIdx, name, argIdx)
+			}
+		})
+	}
+}
+
+func TestParser_SignatureAtPos(t *testing.T) {
+	testCases := []struct {
+		tfVersion         string
+		cfg               string
+		expectedLabel     string
+		expectedParameter int
+	}{
+		{
+			"0.12.0",
+			`resource "aws_instance" "web" {
+  ami = cidrsubnet("10.0.0.0/16", 8, |)
+}
+`,
+			"cidrsubnet(prefix string, newbits number, netnum number) string",
+			2,
+		},
+		{
+			"0.12.0",
+			`resource "aws_instance" "web" {
+  ami = format("%s-%s", "a", |)
+}
+`,
+			"format(format string, args ...any) string",
+			1,
+		},
+		{
+			"0.12.0",
+			`resource "aws_instance" "web" {
+  ami = try(|)
+}
+`,
+			"",
+			0,
+		},
+		{
+			"0.12.20",
+			`resource "aws_instance" "web" {
+  ami = try(|)
+}
+`,
+			"try(expressions ...any) any",
+			0,
+		},
+		{
+			"0.12.0",
+			`resource "aws_instance" "web" {
+  ami = unknown(|)
+}
+`,
+			"",
+			0,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.tfVersion), func(t *testing.T) {
+			src, pos := splitCursor(t, tc.cfg)
+
+			p, err := FindCompatibleParser(tc.tfVersion)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			data, err := p.SignatureAtPos(ihcl.NewTestFile(src), pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tc.expectedLabel == "" {
+				if data != nil {
+					t.Fatalf("expected no signature, given: %q", data.Function.Label())
+				}
+				return
+			}
+			if data == nil {
+				t.Fatalf("expected signature %q, none given", tc.expectedLabel)
+			}
+			if data.Function.Label() != tc.expectedLabel {
+				t.Fatalf("signature doesn't match.\nexpected: %q\ngiven: %q",
+					tc.expectedLabel, data.Function.Label())
+			}
+			if data.ActiveParameter != tc.expectedParameter {
+				t.Fatalf("active parameter doesn't match.\nexpected: %d\ngiven: %d",
+					tc.expectedParameter, data.ActiveParameter)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package lang
+
+import (
+	"sort"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Symbol represents a block (or a local value) declared in a file
+// loosely reflecting lsp.DocumentSymbol
+type Symbol struct {
+	Name   string
+	Detail string
+	Kind   SymbolKind
+
+	// Range covers the whole block (or attribute in case of locals)
+	Range hcl.Range
+
+	// SelectionRange covers the block type and labels
+	// (or name of the local)
+	SelectionRange hcl.Range
+
+	Children []*Symbol
+}
+
+type SymbolKind uint
+
+const (
+	SymbolKindBlock SymbolKind = iota
+	SymbolKindResource
+	SymbolKindDataSource
+	SymbolKindProvider
+	SymbolKindVariable
+	SymbolKindOutput
+	SymbolKindLocals
+	SymbolKindLocal
+	SymbolKindModule
+	SymbolKindTerraform
+)
+
+// symbolNamePrefixes maps block types to the prefix used
+// when referring to their blocks from expressions,
+// blocks of other types are prefixed with the type
+var symbolNamePrefixes = map[string]string{
+	"resource": "",
+	"provider": "",
+	"data":     "data",
+	"variable": "var",
+	"output":   "output",
+	"module":   "module",
+}
+
+var symbolKinds = map[string]SymbolKind{
+	"resource":  SymbolKindResource,
+	"data":      SymbolKindDataSource,
+	"provider":  SymbolKindProvider,
+	"variable":  SymbolKindVariable,
+	"output":    SymbolKindOutput,
+	"locals":    SymbolKindLocals,
+	"module":    SymbolKindModule,
+	"terraform": SymbolKindTerraform,
+}
+
+// SymbolsInFile returns symbols for all top-level blocks in the file
+// in the order of declaration, with nested blocks as children.
+// Blocks are named the way they are referred to from expressions
+// where possible, e.g. aws_instance.web or var.region.
+//
+// Symbols depend on neither schemas nor Terraform version,
+// so they can be obtained without a (compatible) parser.
+func SymbolsInFile(file ihcl.TokenizedFile) ([]*Symbol, error) {
+	return newParser().symbolsInFile(file)
+}
+
+func (p *parser) symbolsInFile(file ihcl.TokenizedFile) ([]*Symbol, error) {
+	blocks, err := file.Blocks()
+	if err != nil {
+		return nil, err
+	}
+
+	symbols := make([]*Symbol, 0)
+	for _, tBlock := range blocks {
+		// We ignore diags as we assume incomplete (invalid) configuration
+		block, _ := hclsyntax.ParseBlockFromTokens(tBlock.Tokens())
+		if block == nil {
+			continue
+		}
+
+		// Labels of unknown block types are taken as-is
+		labels := block.Labels
+		cfgBlock, err := p.ParseBlockFromTokens(tBlock)
+		if err == nil {
+			labels = labelValues(cfgBlock.Labels())
+		}
+
+		symbols = append(symbols, topLevelBlockSymbol(block, labels))
+	}
+
+	return symbols, nil
+}
+
+// labelValues returns values of the given labels, as parsed
+// according to the label schema of the block type
+func labelValues(parsedLabels []*ParsedLabel) []string {
+	labels := make([]string, len(parsedLabels))
+	for i, label := range parsedLabels {
+		labels[i] = label.Value
+	}
+	return labels
+}
+
+func topLevelBlockSymbol(block *hclsyntax.Block, labels []string) *Symbol {
+	kind, ok := symbolKinds[block.Type]
+	if !ok {
+		kind = SymbolKindBlock
+	}
+
+	s := &Symbol{
+		Name:           topLevelBlockName(block, labels),
+		Detail:         block.Type,
+		Kind:           kind,
+		Range:          block.Range(),
+		SelectionRange: block.DefRange(),
+	}
+
+	if kind == SymbolKindLocals {
+		s.Children = localSymbols(block.Body)
+		return s
+	}
+
+	s.Children = nestedBlockSymbols(block.Body)
+	return s
+}
+
+// topLevelBlockName returns name of the block, joining
+// the labels with the prefix for the block type
+func topLevelBlockName(block *hclsyntax.Block, labels []string) string {
+	if len(labels) == 0 {
+		return block.Type
+	}
+
+	prefix, ok := symbolNamePrefixes[block.Type]
+	if !ok {
+		prefix = block.Type
+	}
+
+	parts := make([]string, 0, len(labels)+1)
+	if prefix != "" {
+		parts = append(parts, prefix)
+	}
+	for _, label := range labels {
+		if label == "" {
+			label = "<unknown>"
+		}
+		parts = append(parts, label)
+	}
+	name := strings.Join(parts, ".")
+
+	if block.Type == "provider" {
+		if alias, ok := block.Body.Attributes["alias"]; ok {
+			v, diags := alias.Expr.Value(nil)
+			if !diags.HasErrors() && v.Type() == cty.String && v.IsKnown() && !v.IsNull() {
+				name += "." + v.AsString()
+			}
+		}
+	}
+
+	return name
+}
+
+func localSymbols(body *hclsyntax.Body) []*Symbol {
+	symbols := make([]*Symbol, 0)
+	for _, attr := range sortedAttributes(body.Attributes) {
+		symbols = append(symbols, &Symbol{
+			Name:           "local." + attr.Name,
+			Kind:           SymbolKindLocal,
+			Range:          attr.SrcRange,
+			SelectionRange: attr.NameRange,
+			Children:       make([]*Symbol, 0),
+		})
+	}
+	return symbols
+}
+
+func nestedBlockSymbols(body *hclsyntax.Body) []*Symbol {
+	symbols := make([]*Symbol, 0)
+	for _, block := range body.Blocks {
+		symbols = append(symbols, &Symbol{
+			Name:           block.Type,
+			Detail:         strings.Join(block.Labels, "."),
+			Kind:           SymbolKindBlock,
+			Range:          block.Range(),
+			SelectionRange: block.DefRange(),
+			Children:       nestedBlockSymbols(block.Body),
+		})
+	}
+	return symbols
+}
+
+// sortedAttributes returns attributes in the order of declaration
+func sortedAttributes(attrs hclsyntax.Attributes) []*hclsyntax.Attribute {
+	sorted := make([]*hclsyntax.Attribute, 0, len(attrs))
+	for _, attr := range attrs {
+		sorted = append(sorted, attr)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].SrcRange.Start.Byte < sorted[j].SrcRange.Start.Byte
+	})
+	return sorted
+}
@@ -0,0 +1,123 @@
+package lang
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+)
+
+func TestSymbolsInFile(t *testing.T) {
+	cfg := `terraform {
+  required_version = ">= 0.12"
+}
+
+provider "aws" {
+  alias  = "west"
+  region = "us-west-2"
+}
+
+variable "region" {}
+
+locals {
+  env  = "prod"
+  tags = {}
+}
+
+resource "aws_instance" "web" {
+  ebs_block_device {
+    device_name = "sda1"
+  }
+  provisioner "local-exec" {
+    command = "echo hello"
+  }
+}
+
+data "aws_ami" "ubuntu" {
+}
+
+module "vpc" {
+  source = "./vpc"
+}
+
+output "id" {
+  value = aws_instance.web.id
+}
+
+unknown "foo" "bar" {
+}
+`
+	symbols, err := SymbolsInFile(ihcl.NewTestFile([]byte(cfg)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedSymbols := []string{
+		"terraform (9) terraform 1,1-12",
+		"aws.west (3) provider 5,1-17",
+		"var.region (4) variable 10,1-20",
+		"locals (6) locals 12,1-9",
+		"  local.env (7)  13,3-6",
+		"  local.tags (7)  14,3-7",
+		"aws_instance.web (1) resource 17,1-32",
+		"  ebs_block_device (0)  18,3-21",
+		"  provisioner (0) local-exec 21,3-29",
+		"data.aws_ami.ubuntu (2) data 26,1-26",
+		"module.vpc (8) module 29,1-15",
+		"output.id (5) output 33,1-14",
+		"unknown.foo.bar (0) unknown 37,1-22",
+	}
+	if diff := cmp.Diff(expectedSymbols, renderSymbols(symbols, 0)); diff != "" {
+		t.Fatalf("Symbols don't match.\n%s", diff)
+	}
+}
+
+func TestSymbolsInFile_missingLabels(t *testing.T) {
+	symbols, err := SymbolsInFile(ihcl.NewTestFile([]byte(`resource "aws_instance" {
+}
+`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(symbols) != 1 {
+		t.Fatalf("expected 1 symbol, %d given", len(symbols))
+	}
+	expectedName := "aws_instance.<unknown>"
+	if symbols[0].Name != expectedName {
+		t.Fatalf("Name doesn't match.\nexpected: %q\ngiven: %q",
+			expectedName, symbols[0].Name)
+	}
+}
+
+func TestSymbolsInFile_extraLabels(t *testing.T) {
+	symbols, err := SymbolsInFile(ihcl.NewTestFile([]byte(`resource "aws_instance" "web" "extra" {
+}
+`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(symbols) != 1 {
+		t.Fatalf("expected 1 symbol, %d given", len(symbols))
+	}
+	expectedName := "aws_instance.web"
+	if symbols[0].Name != expectedName {
+		t.Fatalf("Name doesn't match.\nexpected: %q\ngiven: %q",
+			expectedName, symbols[0].Name)
+	}
+}
+
+func renderSymbols(symbols []*Symbol, depth int) []string {
+	rendered := make([]string, 0)
+	for _, s := range symbols {
+		rendered = append(rendered, fmt.Sprintf("%s%s (%d) %s %d,%d-%d",
+			strings.Repeat("  ", depth), s.Name, s.Kind, s.Detail,
+			s.SelectionRange.Start.Line, s.SelectionRange.Start.Column,
+			s.SelectionRange.End.Column))
+		rendered = append(rendered, renderSymbols(s.Children, depth+1)...)
+	}
+	return rendered
+}
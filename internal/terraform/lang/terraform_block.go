@@ -0,0 +1,175 @@
+package lang
+
+import (
+	"log"
+
+	"github.com/hashicorp/go-version"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfjson "github.com/hashicorp/terraform-json"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+	"github.com/hashicorp/terraform-ls/internal/terraform/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type terraformBlockFactory struct {
+	logger *log.Logger
+
+	schemaReader  schema.Reader
+	tfVersion     *version.Version
+	providerLocks []*ProviderLock
+}
+
+func (f *terraformBlockFactory) New(tBlock ihcl.TokenizedBlock) (ConfigBlock, error) {
+	if f.logger == nil {
+		f.logger = discardLog()
+	}
+
+	return &terraformBlock{
+		logger:        f.logger,
+		tBlock:        tBlock,
+		sr:            f.schemaReader,
+		tfVersion:     f.tfVersion,
+		providerLocks: f.providerLocks,
+	}, nil
+}
+
+func (f *terraformBlockFactory) LabelSchema() LabelSchema {
+	return LabelSchema{}
+}
+
+func (f *terraformBlockFactory) BlockType() string {
+	return "terraform"
+}
+
+func (f *terraformBlockFactory) Documentation() MarkupContent {
+	return PlainText("A terraform block configures behaviors of Terraform itself, such as the required " +
+		"Terraform version, required providers and the backend to store state in.")
+}
+
+type terraformBlock struct {
+	logger *log.Logger
+
+	tBlock        ihcl.TokenizedBlock
+	sr            schema.Reader
+	tfVersion     *version.Version
+	providerLocks []*ProviderLock
+}
+
+func (t *terraformBlock) Name() string {
+	return "terraform"
+}
+
+func (t *terraformBlock) Labels() []*ParsedLabel {
+	return []*ParsedLabel{}
+}
+
+func (t *terraformBlock) BlockType() string {
+	return "terraform"
+}
+
+func (t *terraformBlock) CompletionCandidatesAtPos(pos hcl.Pos) (CompletionCandidates, error) {
+	hclBlock, _ := hclsyntax.ParseBlockFromTokens(t.tBlock.Tokens())
+	if backend, ok := backendBlockAtLabelPos(hclBlock, pos); ok {
+		cl := &completableLabels{
+			logger:       t.logger,
+			parsedLabels: parseLabels(backend, backendLabelSchema),
+			tBlock:       t.tBlock,
+			labels: labelCandidates{
+				"type": backendCandidates(t.tfVersion),
+			},
+		}
+
+		return cl.completionCandidatesAtPos(pos)
+	}
+	if rpBlock, ok := requiredProvidersBlockAtPos(hclBlock, pos); ok {
+		if t.sr == nil {
+			return nil, &noSchemaReaderErr{"required_providers"}
+		}
+		rp := &completableRequiredProviders{
+			logger:    t.logger,
+			tBlock:    t.tBlock,
+			block:     rpBlock,
+			sr:        t.sr,
+			tfVersion: t.tfVersion,
+		}
+
+		return rp.completionCandidatesAtPos(pos)
+	}
+
+	cb := &completableBlock{
+		logger:    t.logger,
+		schema:    terraformSchema(t.tfVersion),
+		tBlock:    t.tBlock,
+		tfVersion: t.tfVersion,
+	}
+	return cb.completionCandidatesAtPos(pos)
+}
+
+func (t *terraformBlock) HoverAtPos(pos hcl.Pos) (*HoverData, error) {
+	hclBlock, _ := hclsyntax.ParseBlockFromTokens(t.tBlock.Tokens())
+	if rpBlock, ok := requiredProvidersBlockAtPos(hclBlock, pos); ok {
+		hoverData, ok := requiredProviderHoverAtPos(rpBlock, t.providerLocks, pos)
+		if !ok {
+			t.logger.Printf("no provider lock found at %#v", pos)
+		}
+		return hoverData, nil
+	}
+
+	cb := &completableBlock{
+		logger: t.logger,
+		schema: terraformSchema(t.tfVersion),
+		tBlock: t.tBlock,
+	}
+	return cb.hoverAtPos(pos)
+}
+
+func (t *terraformBlock) Validate() (hcl.Diagnostics, error) {
+	hclBlock, _ := hclsyntax.ParseBlockFromTokens(t.tBlock.Tokens())
+	diags := validateBackendTypes(hclBlock, t.tfVersion)
+
+	block := parseBlock(hclBlock, terraformSchema(t.tfVersion))
+	return append(diags, block.Validate()...), nil
+}
+
+// requiredProvidersBlockType describes the required_providers block
+// whose attributes are names of providers, i.e. declared by the user
+var requiredProvidersBlockType = &tfjson.SchemaBlockType{
+	NestingMode: tfjson.SchemaNestingModeSingle,
+}
+
+// providerMetaBlockType describes provider_meta blocks which are labeled
+// by the provider name and whose content is defined by the provider
+var providerMetaBlockType = &tfjson.SchemaBlockType{
+	NestingMode: tfjson.SchemaNestingModeList,
+}
+
+func terraformSchema(v *version.Version) *tfjson.SchemaBlock {
+	block := &tfjson.SchemaBlock{
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"required_version": {
+				AttributeType: cty.String,
+				Optional:      true,
+				Description:   "Version constraint for Terraform, e.g. >= 0.12.",
+			},
+		},
+		NestedBlocks: map[string]*tfjson.SchemaBlockType{
+			"required_providers": requiredProvidersBlockType,
+			"backend":            backendBlockType,
+		},
+	}
+
+	if versionSupports(v, "0.12.20") {
+		block.Attributes["experiments"] = &tfjson.SchemaAttribute{
+			AttributeType: cty.DynamicPseudoType,
+			Optional:      true,
+			Description:   "A list of experimental language features to opt in to.",
+		}
+	}
+
+	if versionSupports(v, "0.13.0") {
+		block.NestedBlocks["provider_meta"] = providerMetaBlockType
+	}
+
+	return block
+}
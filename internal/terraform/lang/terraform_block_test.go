@@ -0,0 +1,509 @@
+package lang
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-ls/internal/terraform/schema"
+)
+
+func TestTerraformBlock_completionCandidatesAtPos(t *testing.T) {
+	testCases := []struct {
+		src                string
+		expectedCandidates []string
+		expectedSnippets   []string
+	}{
+		{
+			`terraform {
+  |
+}`,
+			[]string{"backend", "experiments", "provider_meta", "required_providers", "required_version"},
+			[]string{
+				"backend \"${1|artifactory,azurerm,consul,cos,etcd,etcdv3,gcs,http,kubernetes,local,manta,oss,pg,remote,s3,swift|}\" {\n  ${0}\n}",
+				"experiments = ${0}",
+				"provider_meta \"${1}\" {\n  ${0}\n}",
+				"required_providers {\n  ${0}\n}",
+				`required_version = "${0:value}"`,
+			},
+		},
+		{
+			`terraform {
+  required_version = ">= 0.12"
+  backend "s3" {}
+  req|
+}`,
+			[]string{"required_providers"},
+			[]string{"required_providers {\n  ${0}\n}"},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			src, pos := splitCursor(t, tc.src)
+
+			f := &terraformBlockFactory{logger: testLogger()}
+			b, err := f.New(newTestBlock(t, string(src)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			candidates, err := b.CompletionCandidatesAtPos(pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedCandidates, candidateLabels(candidates)); diff != "" {
+				t.Fatalf("Candidates don't match.\n%s", diff)
+			}
+
+			snippets := make([]string, 0)
+			for _, c := range candidates.List() {
+				snippets = append(snippets, c.Snippet().NewText())
+			}
+			if diff := cmp.Diff(tc.expectedSnippets, snippets); diff != "" {
+				t.Fatalf("Snippets don't match.\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTerraformBlock_Validate(t *testing.T) {
+	f := &terraformBlockFactory{logger: testLogger()}
+	b, err := f.New(newTestBlock(t, `terraform {
+  required_version = ">= 0.12"
+  required_providers {
+    aws = "~> 2.0"
+  }
+  backend "s3" {
+    bucket = "state"
+    key    = "network/terraform.tfstate"
+    region = "us-east-1"
+  }
+  unknown = true
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diags, err := b.Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		`11:3 error Unsupported argument: An argument named "unknown" is not expected here.`,
+	}
+	if diff := cmp.Diff(expected, renderDiagnostics(diags)); diff != "" {
+		t.Fatalf("Diagnostics don't match.\n%s", diff)
+	}
+}
+
+func TestTerraformBlock_Validate_providerMeta(t *testing.T) {
+	cfg := `terraform {
+  provider_meta "aws" {
+    module_name = "network"
+  }
+}
+`
+	testCases := []struct {
+		tfVersion     string
+		expectedDiags []string
+	}{
+		{
+			"0.12.0",
+			[]string{
+				`2:3 error Unsupported block type: Blocks of type "provider_meta" are not expected here.`,
+			},
+		},
+		{"0.13.0", []string{}},
+		{"1.0.0", []string{}},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.tfVersion), func(t *testing.T) {
+			f := &terraformBlockFactory{
+				logger:    testLogger(),
+				tfVersion: version.Must(version.NewVersion(tc.tfVersion)),
+			}
+			b, err := f.New(newTestBlock(t, cfg))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			diags, err := b.Validate()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedDiags, renderDiagnostics(diags)); diff != "" {
+				t.Fatalf("Diagnostics don't match.\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTerraformBlock_completionCandidatesAtPos_backend(t *testing.T) {
+	testCases := []struct {
+		tfVersion          string
+		src                string
+		expectedCandidates []string
+	}{
+		{
+			"0.12.0",
+			`terraform {
+  backend "|" {}
+}`,
+			[]string{"artifactory", "azurerm", "consul", "etcd", "etcdv3", "gcs", "http",
+				"local", "manta", "pg", "remote", "s3", "swift"},
+		},
+		{
+			"0.13.0",
+			`terraform {
+  backend "|" {}
+}`,
+			[]string{"artifactory", "azurerm", "consul", "cos", "etcd", "etcdv3", "gcs", "http",
+				"kubernetes", "local", "manta", "oss", "pg", "remote", "s3", "swift"},
+		},
+		{
+			"1.3.0",
+			`terraform {
+  backend "|" {}
+}`,
+			[]string{"azurerm", "consul", "cos", "etcdv3", "gcs", "http",
+				"kubernetes", "local", "oss", "pg", "remote", "s3"},
+		},
+		{
+			"0.13.0",
+			`terraform {
+  backend "s3" {
+    dynamo|
+  }
+}`,
+			[]string{"dynamodb_endpoint", "dynamodb_table"},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.tfVersion), func(t *testing.T) {
+			src, pos := splitCursor(t, tc.src)
+
+			f := &terraformBlockFactory{
+				logger:    testLogger(),
+				tfVersion: version.Must(version.NewVersion(tc.tfVersion)),
+			}
+			b, err := f.New(newTestBlock(t, string(src)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			candidates, err := b.CompletionCandidatesAtPos(pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedCandidates, candidateLabels(candidates)); diff != "" {
+				t.Fatalf("Candidates don't match.\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTerraformBlock_Validate_backend(t *testing.T) {
+	testCases := []struct {
+		tfVersion     string
+		src           string
+		expectedDiags []string
+	}{
+		{
+			"0.12.0",
+			`terraform {
+  backend "s3" {
+    bucket          = "state"
+    key             = "network/terraform.tfstate"
+    region          = "us-east-1"
+    dynamo_db_table = "locks"
+  }
+}
+`,
+			[]string{
+				`6:5 warning Unsupported argument: An argument named "dynamo_db_table" is not expected here.`,
+			},
+		},
+		{
+			"0.12.0",
+			`terraform {
+  backend "cos" {
+    bucket = "state"
+  }
+}
+`,
+			[]string{
+				`2:11 error Unsupported backend type: Backend type "cos" requires Terraform 0.13.0 or later.`,
+			},
+		},
+		{
+			"0.12.0",
+			`terraform {
+  backend "s3" {}
+}
+`,
+			[]string{},
+		},
+		{
+			"0.12.0",
+			`terraform {
+  backend "remote" {}
+}
+`,
+			[]string{},
+		},
+		{
+			"0.12.0",
+			`terraform {
+  backend "s4" {
+    bucket = "state"
+  }
+}
+`,
+			[]string{
+				`2:11 warning Unknown backend type: Backend type "s4" is not known to the language server, so its configuration cannot be validated.`,
+			},
+		},
+		{
+			"1.2.0",
+			`terraform {
+  backend "etcd" {
+    path      = "path/to/terraform.tfstate"
+    endpoints = "http://one:4001 http://two:4001"
+  }
+}
+`,
+			[]string{},
+		},
+		{
+			"1.3.0",
+			`terraform {
+  backend "etcd" {}
+}
+`,
+			[]string{
+				`2:11 error Unsupported backend type: Backend type "etcd" was removed in Terraform 1.3.0.`,
+			},
+		},
+		{
+			"0.13.0",
+			`terraform {
+  backend "s3" {
+    role_arn                        = "arn:aws:iam::123456789012:role/state"
+    assume_role_duration_seconds    = 3600
+    assume_role_policy_arns         = ["arn:aws:iam::aws:policy/ReadOnlyAccess"]
+    assume_role_tags                = { team = "network" }
+    assume_role_transitive_tag_keys = ["team"]
+  }
+}
+`,
+			[]string{},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.tfVersion), func(t *testing.T) {
+			f := &terraformBlockFactory{
+				logger:    testLogger(),
+				tfVersion: version.Must(version.NewVersion(tc.tfVersion)),
+			}
+			b, err := f.New(newTestBlock(t, tc.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			diags, err := b.Validate()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedDiags, renderDiagnostics(diags)); diff != "" {
+				t.Fatalf("Diagnostics don't match.\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTerraformBlock_completionCandidatesAtPos_requiredProviders(t *testing.T) {
+	ps := &tfjson.ProviderSchemas{
+		Schemas: map[string]*tfjson.ProviderSchema{
+			"registry.terraform.io/hashicorp/aws":    {},
+			"registry.terraform.io/hashicorp/google": {},
+			"example.com/acme/widget":                {},
+		},
+	}
+
+	testCases := []struct {
+		tfVersion          string
+		src                string
+		expectedCandidates []string
+		expectedSnippets   []string
+	}{
+		{
+			"0.13.0",
+			`terraform {
+  required_providers {
+    google = {
+      source = "hashicorp/google"
+    }
+    |
+  }
+}`,
+			[]string{"aws", "widget"},
+			[]string{
+				"aws = {\n  source  = \"hashicorp/aws\"\n  version = \"${1|~>,>=,=|} ${2}\"\n}",
+				"widget = {\n  source  = \"example.com/acme/widget\"\n  version = \"${1|~>,>=,=|} ${2}\"\n}",
+			},
+		},
+		{
+			"0.12.0",
+			`terraform {
+  required_providers {
+    a|
+  }
+}`,
+			[]string{"aws"},
+			[]string{"aws = \"${1|~>,>=,=|} ${2}\""},
+		},
+		{
+			"0.13.0",
+			`terraform {
+  required_providers {
+    aws = {
+      source = "hashicorp/aws"
+      |
+    }
+  }
+}`,
+			[]string{"version"},
+			[]string{"version = \"${1|~>,>=,=|} ${2}\""},
+		},
+		{
+			"0.13.0",
+			`terraform {
+  required_providers {
+    aws = {
+      source = "hashicorp/|"
+    }
+  }
+}`,
+			[]string{"hashicorp/aws", "hashicorp/google"},
+			[]string{"hashicorp/aws", "hashicorp/google"},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.tfVersion), func(t *testing.T) {
+			src, pos := splitCursor(t, tc.src)
+
+			f := &terraformBlockFactory{
+				logger:       testLogger(),
+				schemaReader: &schema.MockReader{ProviderSchemas: ps},
+				tfVersion:    version.Must(version.NewVersion(tc.tfVersion)),
+			}
+			b, err := f.New(newTestBlock(t, string(src)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			candidates, err := b.CompletionCandidatesAtPos(pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedCandidates, candidateLabels(candidates)); diff != "" {
+				t.Fatalf("Candidates don't match.\n%s", diff)
+			}
+
+			snippets := make([]string, 0)
+			for _, c := range candidates.List() {
+				snippets = append(snippets, c.Snippet().NewText())
+			}
+			if diff := cmp.Diff(tc.expectedSnippets, snippets); diff != "" {
+				t.Fatalf("Snippets don't match.\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTerraformBlock_HoverAtPos_requiredProviders(t *testing.T) {
+	locks := []*ProviderLock{
+		{
+			Address:     schema.ProviderAddress{Hostname: "registry.terraform.io", Namespace: "hashicorp", Type: "aws"},
+			Version:     "3.20.0",
+			Constraints: "~> 3.0",
+			Hashes:      []string{"h1:fakeh1hash=", "zh:fakezhhash"},
+		},
+	}
+
+	testCases := []struct {
+		src             string
+		expectedContent string
+	}{
+		{
+			`terraform {
+  required_providers {
+    a|ws = {
+      source  = "hashicorp/aws"
+      version = "~> 3.0"
+    }
+  }
+}`,
+			"aws (hashicorp/aws)\n\nLocked version: 3.20.0\nConstraints: ~> 3.0\n\nHashes:\n- h1:fakeh1hash=\n- zh:fakezhhash",
+		},
+		{
+			`terraform {
+  required_providers {
+    aws = "~> 3|.0"
+  }
+}`,
+			"aws (hashicorp/aws)\n\nLocked version: 3.20.0\nConstraints: ~> 3.0\n\nHashes:\n- h1:fakeh1hash=\n- zh:fakezhhash",
+		},
+		{
+			`terraform {
+  required_providers {
+    a|ws = {
+      source = "acme/aws"
+    }
+  }
+}`,
+			"",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			src, pos := splitCursor(t, tc.src)
+
+			f := &terraformBlockFactory{
+				logger:        testLogger(),
+				providerLocks: locks,
+			}
+			b, err := f.New(newTestBlock(t, string(src)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			hoverData, err := b.HoverAtPos(pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			content := ""
+			if hoverData != nil {
+				content = hoverData.Content.Value()
+			}
+			if diff := cmp.Diff(tc.expectedContent, content); diff != "" {
+				t.Fatalf("Hover content doesn't match.\n%s", diff)
+			}
+		})
+	}
+}
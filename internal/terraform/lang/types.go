@@ -6,7 +6,6 @@ import (
 	hcl "github.com/hashicorp/hcl/v2"
 	tfjson "github.com/hashicorp/terraform-json"
 	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
-	"github.com/hashicorp/terraform-ls/internal/mdplain"
 	"github.com/hashicorp/terraform-ls/internal/terraform/schema"
 )
 
@@ -15,14 +14,20 @@ import (
 type Parser interface {
 	SetLogger(*log.Logger)
 	SetSchemaReader(schema.Reader)
+	SetProviderLocks([]*ProviderLock)
 	BlockTypeCandidates(ihcl.TokenizedFile, hcl.Pos) CompletionCandidates
-	CompletionCandidatesAtPos(ihcl.TokenizedFile, hcl.Pos) (CompletionCandidates, error)
+	CompletionCandidatesAtPos(ihcl.TokenizedFile, *ModuleIndex, hcl.Pos) (CompletionCandidates, error)
+	HoverAtPos(ihcl.TokenizedFile, *ModuleIndex, hcl.Pos) (*HoverData, error)
+	SignatureAtPos(ihcl.TokenizedFile, hcl.Pos) (*SignatureData, error)
+	ValidateFile(ihcl.TokenizedFile, *ModuleIndex) (hcl.Diagnostics, error)
 }
 
 // ConfigBlock implements an abstraction above HCL block
 // which provides any LSP capabilities (e.g. completion)
 type ConfigBlock interface {
 	CompletionCandidatesAtPos(pos hcl.Pos) (CompletionCandidates, error)
+	HoverAtPos(pos hcl.Pos) (*HoverData, error)
+	Validate() (hcl.Diagnostics, error)
 	Name() string
 	BlockType() string
 	Labels() []*ParsedLabel
@@ -33,10 +38,13 @@ type ConfigBlock interface {
 type Block interface {
 	BlockAtPos(pos hcl.Pos) (Block, bool)
 	Range() hcl.Range
+	DefRange() hcl.Range
 	PosInBody(pos hcl.Pos) bool
 	PosInAttribute(pos hcl.Pos) bool
+	AttributeAtPos(pos hcl.Pos) (string, *Attribute, bool)
 	Attributes() map[string]*Attribute
 	BlockTypes() map[string]*BlockType
+	Validate() hcl.Diagnostics
 }
 
 type LabelSchema []Label
@@ -80,6 +88,13 @@ type CompletionCandidate interface {
 	PlainText() TextEdit
 }
 
+// HoverData represents information to display
+// for a given position, loosely reflecting lsp.Hover
+type HoverData struct {
+	Content MarkupContent
+	Range   *hcl.Range
+}
+
 type TextEdit interface {
 	Range() *hcl.Range
 	NewText() string
@@ -100,14 +115,26 @@ func (te *textEdit) NewText() string {
 
 // MarkupContent reflects lsp.MarkupContent
 type MarkupContent interface {
-	// TODO: eventually will need to propapate Kind here once the LSP
-	// protocol types we use support it
+	Kind() MarkupKind
 	Value() string
 }
 
+// MarkupKind reflects lsp.MarkupKind
+type MarkupKind string
+
+const (
+	PlainTextKind MarkupKind = "plaintext"
+	MarkdownKind  MarkupKind = "markdown"
+)
+
 // PlainText represents plain text markup content for the LSP.
 type PlainText string
 
+// Kind returns the kind of the content for the LSP protocol.
+func (m PlainText) Kind() MarkupKind {
+	return PlainTextKind
+}
+
 // Value returns the content itself for the LSP protocol.
 func (m PlainText) Value() string {
 	return string(m)
@@ -116,9 +143,12 @@ func (m PlainText) Value() string {
 // Markdown represents markdown formatted markup content for the LSP.
 type Markdown string
 
+// Kind returns the kind of the content for the LSP protocol.
+func (m Markdown) Kind() MarkupKind {
+	return MarkdownKind
+}
+
 // Value returns the content itself for the LSP protocol.
 func (m Markdown) Value() string {
-	// This currently returns plaintext for Markdown, but should be changed once
-	// the protocol types support markdown.
-	return mdplain.Clean(string(m))
+	return string(m)
 }
@@ -0,0 +1,164 @@
+package lang
+
+import (
+	"fmt"
+	"sort"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// Validate checks the block against its schema and returns
+// diagnostics for unknown and missing attributes, nested blocks
+// violating the min/max items constraints and deprecated attributes
+//
+// Blocks without schema are not validated at all
+// as there is nothing to validate them against
+func (b *parsedBlock) Validate() hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	if b.hclBlock == nil || b.schema == nil {
+		return diags
+	}
+
+	// Backend catalogue may lag behind Terraform, so we cannot
+	// be sure that an unknown argument is actually invalid
+	unknownSeverity := hcl.DiagError
+	if isBackendSchema(b.schema) {
+		unknownSeverity = hcl.DiagWarning
+	}
+
+	for name, attr := range b.unknownAttributes {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: unknownSeverity,
+			Summary:  "Unsupported argument",
+			Detail:   fmt.Sprintf("An argument named %q is not expected here.", name),
+			Subject:  attr.NameRange.Ptr(),
+		})
+	}
+
+	for _, block := range b.unknownBlocks {
+		if block.Type == "dynamic" {
+			// dynamic blocks generate nested blocks which we
+			// cannot reliably validate without evaluation
+			continue
+		}
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: unknownSeverity,
+			Summary:  "Unsupported block type",
+			Detail:   fmt.Sprintf("Blocks of type %q are not expected here.", block.Type),
+			Subject:  block.TypeRange.Ptr(),
+		})
+	}
+
+	for name, attr := range b.AttributesMap {
+		if !attr.IsDeclared() {
+			if attr.schema.Required {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Missing required argument",
+					Detail:   fmt.Sprintf("The argument %q is required, but no definition was found.", name),
+					Subject:  b.DefRange().Ptr(),
+				})
+			}
+			continue
+		}
+
+		if attr.IsComputedOnly() {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Unconfigurable attribute",
+				Detail: fmt.Sprintf("Can't configure a value for %q: its value will be decided "+
+					"automatically based on the result of applying this configuration.", name),
+				Subject: attr.NameRange().Ptr(),
+			})
+		}
+
+		if attr.schema.Deprecated {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagWarning,
+				Summary:  "Deprecated attribute",
+				Detail: fmt.Sprintf("The attribute %q is deprecated. "+
+					"Refer to the provider documentation for details.", name),
+				Subject: attr.NameRange().Ptr(),
+			})
+		}
+	}
+
+	for name, bType := range b.BlockTypesMap {
+		diags = append(diags, b.validateBlockType(name, bType)...)
+	}
+
+	sortDiagnostics(diags)
+
+	return diags
+}
+
+func (b *parsedBlock) validateBlockType(name string, bType *BlockType) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	for _, block := range bType.BlockList {
+		if bType.schema.Block != nil && bType.schema.Block.Deprecated {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagWarning,
+				Summary:  "Deprecated block",
+				Detail: fmt.Sprintf("The block type %q is deprecated. "+
+					"Refer to the provider documentation for details.", name),
+				Subject: block.DefRange().Ptr(),
+			})
+		}
+		diags = append(diags, block.Validate()...)
+	}
+
+	if b.hasDynamicBlockOfType(name) {
+		// The number of blocks is unknown until evaluation
+		return diags
+	}
+
+	minItems, maxItems := int(bType.schema.MinItems), int(bType.schema.MaxItems)
+	if bType.schema.NestingMode == tfjson.SchemaNestingModeSingle {
+		maxItems = 1
+	}
+
+	declared := len(bType.BlockList)
+
+	if minItems > 0 && declared < minItems {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("Insufficient %s blocks", name),
+			Detail:   fmt.Sprintf("At least %d %q blocks are required.", minItems, name),
+			Subject:  b.DefRange().Ptr(),
+		})
+	}
+
+	if maxItems > 0 && declared > maxItems {
+		for _, block := range bType.BlockList[maxItems:] {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  fmt.Sprintf("Too many %s blocks", name),
+				Detail:   fmt.Sprintf("No more than %d %q blocks are allowed.", maxItems, name),
+				Subject:  block.DefRange().Ptr(),
+			})
+		}
+	}
+
+	return diags
+}
+
+func (b *parsedBlock) hasDynamicBlockOfType(bType string) bool {
+	for _, block := range b.unknownBlocks {
+		if block.Type == "dynamic" && len(block.Labels) > 0 && block.Labels[0] == bType {
+			return true
+		}
+	}
+	return false
+}
+
+func sortDiagnostics(diags hcl.Diagnostics) {
+	sort.SliceStable(diags, func(i, j int) bool {
+		if diags[i].Subject == nil || diags[j].Subject == nil {
+			return diags[j].Subject == nil && diags[i].Subject != nil
+		}
+		return diags[i].Subject.Start.Byte < diags[j].Subject.Start.Byte
+	})
+}
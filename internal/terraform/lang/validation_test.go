@@ -0,0 +1,263 @@
+package lang
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestParsedBlock_Validate(t *testing.T) {
+	testSchema := &tfjson.SchemaBlock{
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"instance_type": {
+				AttributeType: cty.String,
+				Required:      true,
+			},
+			"name": {
+				AttributeType: cty.String,
+				Optional:      true,
+			},
+			"legacy_name": {
+				AttributeType: cty.String,
+				Optional:      true,
+				Deprecated:    true,
+			},
+			"id": {
+				AttributeType: cty.String,
+				Computed:      true,
+			},
+		},
+		NestedBlocks: map[string]*tfjson.SchemaBlockType{
+			"network": {
+				NestingMode: tfjson.SchemaNestingModeList,
+				MinItems:    1,
+				MaxItems:    2,
+				Block: &tfjson.SchemaBlock{
+					Attributes: map[string]*tfjson.SchemaAttribute{
+						"subnet": {
+							AttributeType: cty.String,
+							Required:      true,
+						},
+					},
+				},
+			},
+			"timeouts": {
+				NestingMode: tfjson.SchemaNestingModeSingle,
+				Block: &tfjson.SchemaBlock{
+					Attributes: map[string]*tfjson.SchemaAttribute{
+						"create": {
+							AttributeType: cty.String,
+							Optional:      true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name   string
+		cfg    string
+		schema *tfjson.SchemaBlock
+
+		expectedDiags []string
+	}{
+		{
+			"nil schema",
+			`myblock "one" {
+  anything = "goes"
+}
+`,
+			nil,
+			[]string{},
+		},
+		{
+			"valid block",
+			`myblock "one" {
+  instance_type = "t2.micro"
+  network {
+    subnet = "abc"
+  }
+}
+`,
+			testSchema,
+			[]string{},
+		},
+		{
+			"unknown attribute and block",
+			`myblock "one" {
+  instanse_type = "t2.micro"
+  instance_type = "t2.micro"
+  network {
+    subnet = "abc"
+  }
+  unknown {}
+}
+`,
+			testSchema,
+			[]string{
+				`2:3 error Unsupported argument: An argument named "instanse_type" is not expected here.`,
+				`7:3 error Unsupported block type: Blocks of type "unknown" are not expected here.`,
+			},
+		},
+		{
+			"missing required attributes",
+			`myblock "one" {
+  network {
+  }
+}
+`,
+			testSchema,
+			[]string{
+				`1:1 error Missing required argument: The argument "instance_type" is required, but no definition was found.`,
+				`2:3 error Missing required argument: The argument "subnet" is required, but no definition was found.`,
+			},
+		},
+		{
+			"deprecated and computed attributes",
+			`myblock "one" {
+  instance_type = "t2.micro"
+  legacy_name = "foo"
+  id = "bar"
+  network {
+    subnet = "abc"
+  }
+}
+`,
+			testSchema,
+			[]string{
+				`3:3 warning Deprecated attribute: The attribute "legacy_name" is deprecated. Refer to the provider documentation for details.`,
+				`4:3 error Unconfigurable attribute: Can't configure a value for "id": its value will be decided automatically based on the result of applying this configuration.`,
+			},
+		},
+		{
+			"insufficient blocks",
+			`myblock "one" {
+  instance_type = "t2.micro"
+}
+`,
+			testSchema,
+			[]string{
+				`1:1 error Insufficient network blocks: At least 1 "network" blocks are required.`,
+			},
+		},
+		{
+			"too many blocks",
+			`myblock "one" {
+  instance_type = "t2.micro"
+  network {
+    subnet = "one"
+  }
+  network {
+    subnet = "two"
+  }
+  network {
+    subnet = "three"
+  }
+  timeouts {}
+  timeouts {}
+}
+`,
+			testSchema,
+			[]string{
+				`9:3 error Too many network blocks: No more than 2 "network" blocks are allowed.`,
+				`13:3 error Too many timeouts blocks: No more than 1 "timeouts" blocks are allowed.`,
+			},
+		},
+		{
+			"dynamic blocks",
+			`myblock "one" {
+  instance_type = "t2.micro"
+  dynamic "network" {
+    for_each = var.subnets
+    content {
+      subnet = network.value
+    }
+  }
+}
+`,
+			testSchema,
+			[]string{},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			block := ParseBlock(newTestBlock(t, tc.cfg), tc.schema)
+
+			diags := renderDiagnostics(block.Validate())
+			if diff := cmp.Diff(tc.expectedDiags, diags); diff != "" {
+				t.Fatalf("Diagnostics don't match.\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWithResourceMetaArguments(t *testing.T) {
+	cfg := `resource "aws_instance" "one" {
+  count = 2
+  depends_on = [aws_vpc.main]
+  instance_type = "t2.micro"
+  network {
+    subnet = "abc"
+  }
+  lifecycle {
+    create_before_destroy = true
+  }
+  provisioner "local-exec" {
+    command = "echo hello"
+  }
+  connection {
+    host = "localhost"
+  }
+}
+`
+	schema := &tfjson.SchemaBlock{
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"instance_type": {
+				AttributeType: cty.String,
+				Required:      true,
+			},
+		},
+		NestedBlocks: map[string]*tfjson.SchemaBlockType{
+			"network": {
+				NestingMode: tfjson.SchemaNestingModeList,
+				Block: &tfjson.SchemaBlock{
+					Attributes: map[string]*tfjson.SchemaAttribute{
+						"subnet": {
+							AttributeType: cty.String,
+							Required:      true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	block := ParseBlock(newTestBlock(t, cfg), withResourceMetaArguments(schema, nil))
+	diags := renderDiagnostics(block.Validate())
+	if diff := cmp.Diff([]string{}, diags); diff != "" {
+		t.Fatalf("Diagnostics don't match.\n%s", diff)
+	}
+
+	if _, ok := schema.Attributes["count"]; ok {
+		t.Fatal("original schema was modified")
+	}
+}
+
+func renderDiagnostics(diags hcl.Diagnostics) []string {
+	rendered := make([]string, 0)
+	for _, d := range diags {
+		severity := "error"
+		if d.Severity == hcl.DiagWarning {
+			severity = "warning"
+		}
+		rendered = append(rendered, fmt.Sprintf("%d:%d %s %s: %s",
+			d.Subject.Start.Line, d.Subject.Start.Column, severity, d.Summary, d.Detail))
+	}
+	return rendered
+}
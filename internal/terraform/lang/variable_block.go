@@ -0,0 +1,170 @@
+package lang
+
+import (
+	"log"
+
+	"github.com/hashicorp/go-version"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfjson "github.com/hashicorp/terraform-json"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type variableBlockFactory struct {
+	logger *log.Logger
+
+	tfVersion *version.Version
+}
+
+func (f *variableBlockFactory) New(tBlock ihcl.TokenizedBlock) (ConfigBlock, error) {
+	if f.logger == nil {
+		f.logger = discardLog()
+	}
+
+	return &variableBlock{
+		logger: f.logger,
+
+		labelSchema: f.LabelSchema(),
+		tBlock:      tBlock,
+		tfVersion:   f.tfVersion,
+	}, nil
+}
+
+func (f *variableBlockFactory) LabelSchema() LabelSchema {
+	return LabelSchema{
+		Label{Name: "name", IsCompletable: false},
+	}
+}
+
+func (f *variableBlockFactory) BlockType() string {
+	return "variable"
+}
+
+func (f *variableBlockFactory) Documentation() MarkupContent {
+	return PlainText("A variable block declares an input variable which serves as a parameter of the module, " +
+		"allowing aspects of the module to be customized without altering the module's own source code.")
+}
+
+type variableBlock struct {
+	logger *log.Logger
+
+	labelSchema LabelSchema
+	labels      []*ParsedLabel
+	tBlock      ihcl.TokenizedBlock
+	tfVersion   *version.Version
+}
+
+func (v *variableBlock) Name() string {
+	name := v.Labels()[0].Value
+	if name == "" {
+		return "<unknown>"
+	}
+	return name
+}
+
+func (v *variableBlock) Labels() []*ParsedLabel {
+	if v.labels != nil {
+		return v.labels
+	}
+	v.labels = ParseLabels(v.tBlock, v.labelSchema)
+
+	return v.labels
+}
+
+func (v *variableBlock) BlockType() string {
+	return "variable"
+}
+
+func (v *variableBlock) CompletionCandidatesAtPos(pos hcl.Pos) (CompletionCandidates, error) {
+	hclBlock, _ := hclsyntax.ParseBlockFromTokens(v.tBlock.Tokens())
+	if PosInLabels(hclBlock, pos) {
+		// name of the variable is up to the user
+		return &candidateList{candidates: make([]CompletionCandidate, 0)}, nil
+	}
+
+	cb := &completableBlock{
+		logger:       v.logger,
+		parsedLabels: v.Labels(),
+		schema:       variableSchema(v.tfVersion),
+		tBlock:       v.tBlock,
+	}
+	return cb.completionCandidatesAtPos(pos)
+}
+
+func (v *variableBlock) HoverAtPos(pos hcl.Pos) (*HoverData, error) {
+	cb := &completableBlock{
+		logger:       v.logger,
+		parsedLabels: v.Labels(),
+		schema:       variableSchema(v.tfVersion),
+		tBlock:       v.tBlock,
+	}
+	return cb.hoverAtPos(pos)
+}
+
+func (v *variableBlock) Validate() (hcl.Diagnostics, error) {
+	block := ParseBlock(v.tBlock, variableSchema(v.tfVersion))
+	return block.Validate(), nil
+}
+
+func variableSchema(v *version.Version) *tfjson.SchemaBlock {
+	block := &tfjson.SchemaBlock{
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"type": {
+				AttributeType: cty.DynamicPseudoType,
+				Optional:      true,
+				Description:   "Type constraint restricting the type of value to accept, e.g. list(string).",
+			},
+			"default": {
+				AttributeType: cty.DynamicPseudoType,
+				Optional:      true,
+				Description:   "Default value which makes the variable optional.",
+			},
+			"description": {
+				AttributeType: cty.String,
+				Optional:      true,
+				Description:   "Description of the variable's purpose and the value it expects.",
+			},
+		},
+		NestedBlocks: map[string]*tfjson.SchemaBlockType{},
+	}
+
+	if versionSupports(v, "0.14.0") {
+		block.Attributes["sensitive"] = &tfjson.SchemaAttribute{
+			AttributeType: cty.Bool,
+			Optional:      true,
+			Description:   "Whether to hide the value of the variable in the plan and apply output.",
+		}
+	}
+	if versionSupports(v, "1.1.0") {
+		block.Attributes["nullable"] = &tfjson.SchemaAttribute{
+			AttributeType: cty.Bool,
+			Optional:      true,
+			Description:   "Whether null is an acceptable value for the variable.",
+		}
+	}
+
+	// Custom validation rules were experimental in 0.12.20+
+	// and became generally available in 0.13.0
+	if versionSupports(v, "0.12.20") {
+		block.NestedBlocks["validation"] = &tfjson.SchemaBlockType{
+			NestingMode: tfjson.SchemaNestingModeList,
+			Block: &tfjson.SchemaBlock{
+				Attributes: map[string]*tfjson.SchemaAttribute{
+					"condition": {
+						AttributeType: cty.Bool,
+						Required:      true,
+						Description:   "Expression which must be true for the value to be valid.",
+					},
+					"error_message": {
+						AttributeType: cty.String,
+						Required:      true,
+						Description:   "Message to display when the condition is false.",
+					},
+				},
+			},
+		}
+	}
+
+	return block
+}
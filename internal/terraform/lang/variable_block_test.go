@@ -0,0 +1,179 @@
+package lang
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+)
+
+func TestVariableBlock_Name(t *testing.T) {
+	testCases := []struct {
+		src          string
+		expectedName string
+	}{
+		{`variable "region" {}`, "region"},
+		{`variable "" {}`, "<unknown>"},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			f := &variableBlockFactory{logger: testLogger()}
+			b, err := f.New(newTestBlock(t, tc.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if b.Name() != tc.expectedName {
+				t.Fatalf("Name mismatch.\nexpected: %q\ngiven: %q", tc.expectedName, b.Name())
+			}
+		})
+	}
+}
+
+func TestVariableBlock_completionCandidatesAtPos(t *testing.T) {
+	testCases := []struct {
+		tfVersion          string
+		src                string
+		expectedCandidates []string
+	}{
+		{
+			"0.13.0",
+			`variable "region" {
+  |
+}`,
+			[]string{"default", "description", "type", "validation"},
+		},
+		{
+			"0.12.0",
+			`variable "region" {
+  type = string
+  |
+}`,
+			[]string{"default", "description"},
+		},
+		{
+			"0.13.0",
+			`variable "region" {
+  validation {
+    |
+  }
+}`,
+			[]string{"condition", "error_message"},
+		},
+		{
+			"0.13.0",
+			`variable "reg|" {
+}`,
+			[]string{},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.tfVersion), func(t *testing.T) {
+			src, pos := splitCursor(t, tc.src)
+
+			f := &variableBlockFactory{
+				logger:    testLogger(),
+				tfVersion: version.Must(version.NewVersion(tc.tfVersion)),
+			}
+			b, err := f.New(newTestBlock(t, string(src)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			candidates, err := b.CompletionCandidatesAtPos(pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedCandidates, candidateLabels(candidates)); diff != "" {
+				t.Fatalf("Candidates don't match.\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestVariableBlock_Validate(t *testing.T) {
+	cfg := `variable "region" {
+  type    = string
+  default = "eu-west-1"
+  value   = "eu-west-2"
+
+  validation {
+    condition = length(var.region) > 0
+  }
+}
+`
+	f := &variableBlockFactory{logger: testLogger()}
+	b, err := f.New(newTestBlock(t, cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diags, err := b.Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		`4:3 error Unsupported argument: An argument named "value" is not expected here.`,
+		`6:3 error Missing required argument: The argument "error_message" is required, but no definition was found.`,
+	}
+	if diff := cmp.Diff(expected, renderDiagnostics(diags)); diff != "" {
+		t.Fatalf("Diagnostics don't match.\n%s", diff)
+	}
+}
+
+func TestVariableBlock_Validate_sensitive(t *testing.T) {
+	cfg := `variable "password" {
+  type      = string
+  sensitive = true
+}
+`
+	testCases := []struct {
+		tfVersion     string
+		expectedDiags []string
+	}{
+		{
+			"0.13.0",
+			[]string{
+				`3:3 error Unsupported argument: An argument named "sensitive" is not expected here.`,
+			},
+		},
+		{"0.14.0", []string{}},
+		{"1.0.0", []string{}},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.tfVersion), func(t *testing.T) {
+			f := &variableBlockFactory{
+				logger:    testLogger(),
+				tfVersion: version.Must(version.NewVersion(tc.tfVersion)),
+			}
+			b, err := f.New(newTestBlock(t, cfg))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			diags, err := b.Validate()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedDiags, renderDiagnostics(diags)); diff != "" {
+				t.Fatalf("Diagnostics don't match.\n%s", diff)
+			}
+		})
+	}
+}
+
+func candidateLabels(list CompletionCandidates) []string {
+	labels := make([]string, 0)
+	if list == nil {
+		return labels
+	}
+	for _, c := range list.List() {
+		labels = append(labels, c.Label())
+	}
+	return labels
+}
@@ -22,3 +22,13 @@ func IsRootModuleNotFound(err error) bool {
 	_, ok := err.(*RootModuleNotFoundErr)
 	return ok
 }
+
+// NotInitializedErr indicates that provider schemas are unavailable
+// as the root module has no plugin lock file, i.e. it wasn't initialized
+type NotInitializedErr struct {
+	Dir string
+}
+
+func (e *NotInitializedErr) Error() string {
+	return "no plugin lock file found, provider schemas are unavailable"
+}
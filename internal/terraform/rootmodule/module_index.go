@@ -0,0 +1,57 @@
+package rootmodule
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
+	"github.com/hashicorp/terraform-ls/internal/terraform/lang"
+)
+
+func buildModuleIndex(fs filesystem.Filesystem, dir string) (*lang.ModuleIndex, error) {
+	names, err := fs.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	mi := lang.NewModuleIndex()
+	for _, name := range names {
+		isConfig, isVars := isModuleConfigFile(name), isVarsFile(name)
+		if !isConfig && !isVars {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+		src, err := fs.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		// We ignore diags as we assume incomplete (invalid) configuration
+		if isConfig {
+			mi.IndexFile(path, src)
+		} else {
+			mi.IndexVarsFile(path, src)
+		}
+	}
+
+	return mi, nil
+}
+
+// isModuleConfigFile reflects how Terraform itself
+// picks files to load from a module directory
+func isModuleConfigFile(name string) bool {
+	return !isIgnoredFile(name) && filepath.Ext(name) == ".tf"
+}
+
+// isVarsFile reports whether the file contains variable definitions
+// in the native syntax (e.g. terraform.tfvars or prod.auto.tfvars)
+func isVarsFile(name string) bool {
+	return !isIgnoredFile(name) && filepath.Ext(name) == ".tfvars"
+}
+
+func isIgnoredFile(name string) bool {
+	return strings.HasPrefix(name, ".") || // Unix-like hidden files
+		strings.HasSuffix(name, "~") || // vim
+		strings.HasPrefix(name, "#") && strings.HasSuffix(name, "#") // emacs
+}
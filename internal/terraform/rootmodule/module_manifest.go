@@ -98,6 +98,20 @@ type moduleManifest struct {
 	Records []ModuleRecord `json:"Modules"`
 }
 
+// moduleDir returns absolute path to the directory
+// of a module with the given key
+func (mm *moduleManifest) moduleDir(key string) (string, bool) {
+	if key == "" {
+		return mm.rootDir, true
+	}
+	for _, m := range mm.Records {
+		if m.Key == key {
+			return filepath.Join(mm.rootDir, m.Dir), true
+		}
+	}
+	return "", false
+}
+
 func ParseModuleManifestFromFile(path string) (*moduleManifest, error) {
 	b, err := ioutil.ReadFile(path)
 	if err != nil {
@@ -10,3 +10,11 @@ func pathEquals(path1, path2 string) bool {
 	volume2 := filepath.VolumeName(path2)
 	return strings.EqualFold(volume1, volume2) && path1[len(volume1):] == path2[len(volume2):]
 }
+
+// pathKey returns a key under which the path can be looked up,
+// such that paths which are equal per pathEquals share the same key
+func pathKey(path string) string {
+	path = filepath.Clean(path)
+	volume := filepath.VolumeName(path)
+	return strings.ToLower(volume) + path[len(volume):]
+}
@@ -7,6 +7,8 @@ import (
 
 func pluginLockFilePaths(dir string) []string {
 	return []string{
+		// Terraform >= v0.14
+		dependencyLockFilePath(dir),
 		// Terraform >= v0.13
 		filepath.Join(dir,
 			".terraform",
@@ -20,3 +22,24 @@ func pluginLockFilePaths(dir string) []string {
 			"lock.json"),
 	}
 }
+
+// dependencyLockFilePath returns path to the lock file
+// recording provider selections, including their hashes
+func dependencyLockFilePath(dir string) string {
+	return filepath.Join(dir, ".terraform.lock.hcl")
+}
+
+func isDependencyLockFile(path string) bool {
+	return pathEquals(filepath.Base(path), ".terraform.lock.hcl")
+}
+
+// pluginDirPaths returns paths to directories into which
+// Terraform installs plugins, which may change during init
+// even if the lock file itself stays the same
+func pluginDirPaths(dir string) []string {
+	return []string{
+		filepath.Join(dir, ".terraform"),
+		// Terraform >= v0.14
+		filepath.Join(dir, ".terraform", "providers"),
+	}
+}
@@ -0,0 +1,104 @@
+package rootmodule
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-ls/internal/terraform/lang"
+	"github.com/hashicorp/terraform-ls/internal/terraform/schema"
+)
+
+// checksummedFile is implemented by files tracked by the watcher
+// which compute the checksum whenever the file changes
+type checksummedFile interface {
+	Sha256Sum() string
+}
+
+// schemaCacheKey returns key under which schemas obtained
+// for the given plugin lock file are cached
+func (rm *rootModule) schemaCacheKey(lockFile File) (schema.CacheKey, error) {
+	key := schema.CacheKey{
+		TerraformVersion: rm.tfVersion,
+	}
+
+	if cf, ok := lockFile.(checksummedFile); ok && cf.Sha256Sum() != "" {
+		key.LockFileSum = hex.EncodeToString([]byte(cf.Sha256Sum()))
+	} else {
+		sum, err := fileSha256Sum(lockFile.Path())
+		if err != nil {
+			return key, err
+		}
+		key.LockFileSum = hex.EncodeToString(sum)
+	}
+
+	versions, err := lockedProviderVersions(lockFile.Path())
+	if err != nil {
+		// versions only allow reuse of schemas across lock files
+		rm.logger.Printf("failed to read provider versions for %s: %s", rm.Path(), err)
+	}
+	key.ProviderVersions = versions
+
+	return key, nil
+}
+
+// lockedProviderVersions returns versions of providers selected
+// in the given lock file, keyed by provider address, or nil
+// if the lock file doesn't record versions (Terraform <= 0.12)
+func lockedProviderVersions(path string) (map[string]string, error) {
+	switch {
+	case isDependencyLockFile(path):
+		src, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		locks, diags := lang.ParseProviderLocks(path, src)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		versions := make(map[string]string, len(locks))
+		for _, lock := range locks {
+			versions[lock.Address.String()] = lock.Version
+		}
+		return versions, nil
+	case pathEquals(filepath.Base(path), "selections.json"):
+		src, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var selections map[string]struct {
+			Version string `json:"version"`
+		}
+		err = json.Unmarshal(src, &selections)
+		if err != nil {
+			return nil, err
+		}
+		versions := make(map[string]string, len(selections))
+		for addr, s := range selections {
+			versions[addr] = s.Version
+		}
+		return versions, nil
+	}
+
+	return nil, nil
+}
+
+func fileSha256Sum(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.Sum(nil), nil
+}
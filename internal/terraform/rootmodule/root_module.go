@@ -8,10 +8,14 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
 	"github.com/hashicorp/terraform-ls/internal/terraform/discovery"
 	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
 	"github.com/hashicorp/terraform-ls/internal/terraform/lang"
@@ -21,12 +25,16 @@ import (
 type rootModule struct {
 	path   string
 	logger *log.Logger
+	fs     filesystem.Filesystem
 
 	// loading
+	loadMu        *sync.Mutex
 	isLoading     bool
 	isLoadingMu   *sync.RWMutex
+	loadingDoneCh chan struct{}
 	cancelLoading context.CancelFunc
 	loadErr       error
+	schemaErr     error
 	loadErrMu     *sync.RWMutex
 
 	// module cache
@@ -34,11 +42,18 @@ type rootModule struct {
 	moduleManifestFile File
 	moduleManifest     *moduleManifest
 
+	// module indexes, cached per module directory
+	moduleIndexes   map[string]*lang.ModuleIndex
+	moduleIndexesMu *sync.Mutex
+
 	// plugin cache
 	pluginMu         *sync.RWMutex
 	pluginLockFile   File
 	newSchemaStorage schema.StorageFactory
 	schemaStorage    *schema.Storage
+	schemaCache      *schema.Cache
+	schemaRegistry   *schema.Registry
+	fallbackSchemas  *schema.Index
 	schemaLoaded     bool
 	schemaLoadedMu   *sync.RWMutex
 
@@ -65,15 +80,19 @@ type rootModule struct {
 
 func newRootModule(dir string) *rootModule {
 	return &rootModule{
-		path:           dir,
-		logger:         defaultLogger,
-		isLoadingMu:    &sync.RWMutex{},
-		loadErrMu:      &sync.RWMutex{},
-		moduleMu:       &sync.RWMutex{},
-		pluginMu:       &sync.RWMutex{},
-		schemaLoadedMu: &sync.RWMutex{},
-		tfLoadedMu:     &sync.RWMutex{},
-		parserLoadedMu: &sync.RWMutex{},
+		path:            dir,
+		logger:          defaultLogger,
+		fs:              filesystem.NewFilesystem(),
+		loadMu:          &sync.Mutex{},
+		isLoadingMu:     &sync.RWMutex{},
+		loadErrMu:       &sync.RWMutex{},
+		moduleMu:        &sync.RWMutex{},
+		moduleIndexes:   make(map[string]*lang.ModuleIndex, 0),
+		moduleIndexesMu: &sync.Mutex{},
+		pluginMu:        &sync.RWMutex{},
+		schemaLoadedMu:  &sync.RWMutex{},
+		tfLoadedMu:      &sync.RWMutex{},
+		parserLoadedMu:  &sync.RWMutex{},
 	}
 }
 
@@ -154,8 +173,12 @@ func (rm *rootModule) StartLoading() {
 	ctx, cancelFunc := context.WithCancel(context.Background())
 	rm.cancelLoading = cancelFunc
 
+	// loading state is set early, so that LoadingDone
+	// can be waited on as soon as loading is started
+	rm.setLoadingState(true)
+
 	go func(ctx context.Context) {
-		rm.setLoadErr(rm.load(ctx))
+		rm.load(ctx)
 	}(ctx)
 }
 
@@ -166,7 +189,22 @@ func (rm *rootModule) CancelLoading() {
 	rm.setLoadingState(false)
 }
 
+// load loads the root module, after any other loading
+// (e.g. one just cancelled) has finished, as loading
+// mutates internal state without further locking
 func (rm *rootModule) load(ctx context.Context) error {
+	rm.loadMu.Lock()
+	defer rm.loadMu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		// cancelled (e.g. by reload) while waiting
+		return err
+	}
+
+	return rm.doLoad(ctx)
+}
+
+func (rm *rootModule) doLoad(ctx context.Context) error {
 	var errs *multierror.Error
 	defer rm.CancelLoading()
 
@@ -196,15 +234,42 @@ func (rm *rootModule) load(ctx context.Context) error {
 	err = rm.UpdateSchemaCache(ctx, rm.pluginLockFile)
 	errs = multierror.Append(errs, err)
 
-	return errs.ErrorOrNil()
+	// load error is set before loading is marked as done
+	err = errs.ErrorOrNil()
+	rm.setLoadErr(err)
+
+	return err
 }
 
 func (rm *rootModule) setLoadingState(isLoading bool) {
 	rm.isLoadingMu.Lock()
 	defer rm.isLoadingMu.Unlock()
+
+	if isLoading && !rm.isLoading {
+		rm.loadingDoneCh = make(chan struct{})
+	}
+	if !isLoading && rm.isLoading {
+		close(rm.loadingDoneCh)
+	}
+
 	rm.isLoading = isLoading
 }
 
+// LoadingDone returns a channel which is closed
+// once the current (if any) loading is finished
+func (rm *rootModule) LoadingDone() <-chan struct{} {
+	rm.isLoadingMu.RLock()
+	defer rm.isLoadingMu.RUnlock()
+
+	if !rm.isLoading {
+		doneCh := make(chan struct{})
+		close(doneCh)
+		return doneCh
+	}
+
+	return rm.loadingDoneCh
+}
+
 func (rm *rootModule) IsLoadingDone() bool {
 	rm.isLoadingMu.RLock()
 	defer rm.isLoadingMu.RUnlock()
@@ -260,15 +325,32 @@ func (rm *rootModule) discoverTerraformVersion(ctx context.Context) error {
 
 func (rm *rootModule) findCompatibleStateStorage() error {
 	if rm.tfVersion == "" {
-		return errors.New("unknown terraform version - unable to find state storage")
+		if rm.fallbackSchemas == nil {
+			return errors.New("unknown terraform version - unable to find state storage")
+		}
+		// fallback schemas don't require Terraform
+		rm.logger.Printf("unknown terraform version, using fallback schemas for %s", rm.Path())
+	} else {
+		err := schema.SchemaSupportsTerraform(rm.tfVersion)
+		if err != nil {
+			return err
+		}
 	}
 
-	err := schema.SchemaSupportsTerraform(rm.tfVersion)
-	if err != nil {
-		return err
+	if rm.schemaStorage != nil {
+		rm.schemaStorage.ReleaseSchemas()
 	}
 	rm.schemaStorage = rm.newSchemaStorage()
 	rm.schemaStorage.SetLogger(rm.logger)
+	if rm.schemaCache != nil {
+		rm.schemaStorage.SetCache(rm.schemaCache)
+	}
+	if rm.schemaRegistry != nil {
+		rm.schemaStorage.SetRegistry(rm.schemaRegistry)
+	}
+	if rm.fallbackSchemas != nil {
+		rm.schemaStorage.SetFallbackSchemas(rm.fallbackSchemas)
+	}
 	return nil
 }
 
@@ -277,13 +359,19 @@ func (rm *rootModule) findCompatibleLangParser() error {
 		rm.setParserLoaded(true)
 	}()
 
+	var p lang.Parser
 	if rm.tfVersion == "" {
-		return errors.New("unknown terraform version - unable to find parser")
-	}
-
-	p, err := lang.FindCompatibleParser(rm.tfVersion)
-	if err != nil {
-		return err
+		if rm.fallbackSchemas == nil {
+			return errors.New("unknown terraform version - unable to find parser")
+		}
+		// parser for unknown version, to make use of fallback schemas
+		p = lang.NewParser()
+	} else {
+		var err error
+		p, err = lang.FindCompatibleParser(rm.tfVersion)
+		if err != nil {
+			return err
+		}
 	}
 	p.SetLogger(rm.logger)
 
@@ -296,10 +384,17 @@ func (rm *rootModule) findCompatibleLangParser() error {
 	return nil
 }
 
+// LoadError returns any error which occurred during loading,
+// incl. failure to obtain provider schemas, which may
+// also be (re)obtained later, after loading
 func (rm *rootModule) LoadError() error {
 	rm.loadErrMu.RLock()
 	defer rm.loadErrMu.RUnlock()
-	return rm.loadErr
+
+	if rm.schemaErr == nil {
+		return rm.loadErr
+	}
+	return multierror.Append(rm.loadErr, rm.schemaErr)
 }
 
 func (rm *rootModule) setLoadErr(err error) {
@@ -308,6 +403,12 @@ func (rm *rootModule) setLoadErr(err error) {
 	rm.loadErr = err
 }
 
+func (rm *rootModule) setSchemaErr(err error) {
+	rm.loadErrMu.Lock()
+	defer rm.loadErrMu.Unlock()
+	rm.schemaErr = err
+}
+
 func (rm *rootModule) Path() string {
 	return rm.path
 }
@@ -329,6 +430,7 @@ func (rm *rootModule) UpdateModuleManifest(lockFile File) error {
 	}
 
 	rm.moduleManifest = mm
+	rm.resetModuleIndexes()
 	rm.logger.Printf("updated module manifest - %d references parsed for %s",
 		len(mm.Records), rm.Path())
 	return nil
@@ -355,6 +457,192 @@ func (rm *rootModule) IsParserLoaded() bool {
 	return rm.parserLoaded
 }
 
+// ModuleIndex returns an index of declarations and references
+// across all configuration files of the given module directory,
+// i.e. the root module itself or any module it references
+//
+// Indexes of modules called from the given directory are attached
+// as long as they are known from the module manifest
+//
+// Indexes are cached until invalidated via InvalidateModuleIndex
+// or until the module manifest changes
+func (rm *rootModule) ModuleIndex(dir string) (*lang.ModuleIndex, error) {
+	rm.moduleMu.RLock()
+	defer rm.moduleMu.RUnlock()
+
+	rm.moduleIndexesMu.Lock()
+	defer rm.moduleIndexesMu.Unlock()
+
+	return rm.moduleIndex(dir, make(map[string]bool, 0))
+}
+
+func (rm *rootModule) moduleIndex(dir string, indexing map[string]bool) (*lang.ModuleIndex, error) {
+	key := pathKey(dir)
+	if mi, ok := rm.moduleIndexes[key]; ok {
+		return mi, nil
+	}
+
+	mi, err := buildModuleIndex(rm.fs, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	mm := rm.moduleManifest
+	if mm == nil {
+		rm.moduleIndexes[key] = mi
+		return mi, nil
+	}
+
+	indexing[key] = true
+	defer delete(indexing, key)
+
+	for _, m := range mm.Records {
+		if m.IsRoot() {
+			continue
+		}
+
+		keyParts := strings.Split(m.Key, ".")
+		parentKey := strings.Join(keyParts[:len(keyParts)-1], ".")
+		parentDir, ok := mm.moduleDir(parentKey)
+		if !ok || !pathEquals(parentDir, dir) {
+			continue
+		}
+
+		childDir := filepath.Join(mm.rootDir, m.Dir)
+		if indexing[pathKey(childDir)] {
+			// module (indirectly) calling itself
+			continue
+		}
+		child, err := rm.moduleIndex(childDir, indexing)
+		if err != nil {
+			rm.logger.Printf("failed to index module %q at %s: %s", m.Key, childDir, err)
+			continue
+		}
+		mi.AddCalledModule(keyParts[len(keyParts)-1], child)
+	}
+
+	rm.moduleIndexes[key] = mi
+	return mi, nil
+}
+
+// InvalidateModuleIndex discards the cached index of the given
+// module directory, e.g. after any of its files changed,
+// along with indexes of modules calling it
+func (rm *rootModule) InvalidateModuleIndex(dir string) {
+	rm.moduleMu.RLock()
+	defer rm.moduleMu.RUnlock()
+
+	rm.moduleIndexesMu.Lock()
+	defer rm.moduleIndexesMu.Unlock()
+
+	rm.invalidateModuleIndex(dir, make(map[string]bool, 0))
+}
+
+func (rm *rootModule) invalidateModuleIndex(dir string, invalidated map[string]bool) {
+	key := pathKey(dir)
+	if invalidated[key] {
+		return
+	}
+	invalidated[key] = true
+	delete(rm.moduleIndexes, key)
+
+	mm := rm.moduleManifest
+	if mm == nil {
+		return
+	}
+
+	for _, m := range mm.Records {
+		if m.IsRoot() {
+			continue
+		}
+		if !pathEquals(filepath.Join(mm.rootDir, m.Dir), dir) {
+			continue
+		}
+
+		keyParts := strings.Split(m.Key, ".")
+		parentKey := strings.Join(keyParts[:len(keyParts)-1], ".")
+		parentDir, ok := mm.moduleDir(parentKey)
+		if !ok {
+			continue
+		}
+		rm.invalidateModuleIndex(parentDir, invalidated)
+	}
+}
+
+func (rm *rootModule) resetModuleIndexes() {
+	rm.moduleIndexesMu.Lock()
+	defer rm.moduleIndexesMu.Unlock()
+	rm.moduleIndexes = make(map[string]*lang.ModuleIndex, 0)
+}
+
+// ModuleCalls returns module blocks calling the given module
+// directory, as recorded in the module manifest
+func (rm *rootModule) ModuleCalls(dir string) []ModuleCall {
+	rm.moduleMu.RLock()
+	defer rm.moduleMu.RUnlock()
+
+	calls := make([]ModuleCall, 0)
+	if rm.moduleManifest == nil {
+		return calls
+	}
+
+	mm := rm.moduleManifest
+	for _, m := range mm.Records {
+		if m.IsRoot() {
+			continue
+		}
+		if !pathEquals(filepath.Join(mm.rootDir, m.Dir), dir) {
+			continue
+		}
+
+		keyParts := strings.Split(m.Key, ".")
+		parentKey := strings.Join(keyParts[:len(keyParts)-1], ".")
+		parentDir, ok := mm.moduleDir(parentKey)
+		if !ok {
+			continue
+		}
+
+		calls = append(calls, ModuleCall{
+			Name: keyParts[len(keyParts)-1],
+			Dir:  parentDir,
+		})
+	}
+
+	return calls
+}
+
+// Modules returns the root module itself and all modules
+// it calls, as recorded in the module manifest
+func (rm *rootModule) Modules() []Module {
+	modules := []Module{
+		{Dir: rm.Path()},
+	}
+
+	rm.moduleMu.RLock()
+	defer rm.moduleMu.RUnlock()
+
+	if rm.moduleManifest == nil {
+		return modules
+	}
+
+	mm := rm.moduleManifest
+	for _, m := range mm.Records {
+		if m.IsRoot() {
+			continue
+		}
+		modules = append(modules, Module{
+			Address: "module." + strings.ReplaceAll(m.Key, ".", ".module."),
+			Dir:     filepath.Join(mm.rootDir, m.Dir),
+		})
+	}
+
+	sort.Slice(modules, func(i, j int) bool {
+		return modules[i].Address < modules[j].Address
+	})
+
+	return modules
+}
+
 func (rm *rootModule) setParserLoaded(isLoaded bool) {
 	rm.parserLoadedMu.Lock()
 	defer rm.parserLoadedMu.Unlock()
@@ -409,6 +697,64 @@ func (rm *rootModule) TerraformFormatter() (exec.Formatter, error) {
 	return rm.tfExec.FormatterForVersion(rm.tfVersion)
 }
 
+// ExecuteTerraformValidate runs terraform validate in the root module
+// and returns diagnostics keyed by absolute paths of affected files,
+// where diagnostics not related to any file are keyed by empty path
+func (rm *rootModule) ExecuteTerraformValidate(ctx context.Context) (map[string]hcl.Diagnostics, error) {
+	if !rm.IsTerraformLoaded() {
+		return nil, fmt.Errorf("terraform executor is not loaded yet")
+	}
+
+	if rm.tfExec == nil {
+		return nil, fmt.Errorf("no terraform executor available")
+	}
+
+	diags, err := rm.tfExec.Validate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return validateDiagsToHCL(rm.Path(), diags), nil
+}
+
+// ExecuteTerraformInit runs terraform init in the root module
+// and reloads it afterwards, so that any newly installed
+// modules and providers are picked up
+func (rm *rootModule) ExecuteTerraformInit(ctx context.Context) error {
+	if !rm.IsTerraformLoaded() {
+		return fmt.Errorf("terraform executor is not loaded yet")
+	}
+
+	if rm.tfExec == nil {
+		return fmt.Errorf("no terraform executor available")
+	}
+
+	err := rm.tfExec.Init(ctx)
+	if err != nil {
+		return err
+	}
+
+	return rm.Reload(ctx)
+}
+
+// Reload cancels any ongoing loading and synchronously loads
+// the root module again, including discovery of module manifest
+// and plugin lock file which may have been created since
+func (rm *rootModule) Reload(ctx context.Context) error {
+	rm.CancelLoading()
+
+	// cancelled loading may still be running
+	rm.loadMu.Lock()
+	defer rm.loadMu.Unlock()
+
+	err := rm.discoverCaches(ctx, rm.Path())
+	if err != nil {
+		return err
+	}
+
+	return rm.doLoad(ctx)
+}
+
 func (rm *rootModule) IsTerraformLoaded() bool {
 	rm.tfLoadedMu.RLock()
 	defer rm.tfLoadedMu.RUnlock()
@@ -433,9 +779,14 @@ func (rm *rootModule) UpdateSchemaCache(ctx context.Context, lockFile File) erro
 		rm.setSchemaLoaded(true)
 	}()
 
+	rm.setSchemaErr(nil)
+
 	if lockFile == nil {
 		rm.logger.Printf("ignoring schema cache update as no lock file was found for %s",
 			rm.Path())
+		if rm.fallbackSchemas == nil {
+			rm.setSchemaErr(&NotInitializedErr{Dir: rm.Path()})
+		}
 		return nil
 	}
 
@@ -445,17 +796,81 @@ func (rm *rootModule) UpdateSchemaCache(ctx context.Context, lockFile File) erro
 
 	rm.pluginLockFile = lockFile
 
-	err := rm.schemaStorage.ObtainSchemasForModule(ctx,
-		rm.tfExec, rootModuleDirFromFilePath(lockFile.Path()))
+	if rm.tfExec == nil {
+		// schema storage is only available with fallback schemas here
+		rm.logger.Printf("cannot obtain schemas without terraform executor, using fallback schemas for %s",
+			rm.Path())
+		if isDependencyLockFile(lockFile.Path()) {
+			rm.updateProviderLocks(lockFile.Path())
+		}
+		return nil
+	}
+
+	dir := rootModuleDirFromFilePath(lockFile.Path())
+	var err error
+	if rm.schemaCache != nil || rm.schemaRegistry != nil {
+		var key schema.CacheKey
+		key, err = rm.schemaCacheKey(lockFile)
+		if err == nil {
+			err = rm.schemaStorage.ObtainCachedSchemasForModule(ctx, rm.tfExec, dir, key)
+		}
+	} else {
+		err = rm.schemaStorage.ObtainSchemasForModule(ctx, rm.tfExec, dir)
+	}
 	if err != nil {
-		// We fail silently here to still allow tracking the module
+		// The error is only recorded to still allow tracking the module
 		// The schema can be loaded later via watcher
 		rm.logger.Printf("failed to update plugin cache for %s: %s", rm.Path(), err.Error())
+		rm.setSchemaErr(fmt.Errorf("failed to obtain provider schemas: %w", err))
+	}
+
+	if isDependencyLockFile(lockFile.Path()) {
+		rm.updateProviderLocks(lockFile.Path())
 	}
 
 	return nil
 }
 
+// ReloadSchemaCache obtains schemas again for the current plugin
+// lock file, e.g. after plugins were installed by terraform init
+// which left the lock file itself unchanged
+func (rm *rootModule) ReloadSchemaCache(ctx context.Context) error {
+	err := rm.discoverPluginCache(rm.Path())
+	if err != nil {
+		return err
+	}
+
+	rm.pluginMu.RLock()
+	lockFile := rm.pluginLockFile
+	rm.pluginMu.RUnlock()
+
+	return rm.UpdateSchemaCache(ctx, lockFile)
+}
+
+// updateProviderLocks makes provider selections from
+// the given dependency lock file available to the parser
+func (rm *rootModule) updateProviderLocks(path string) {
+	if rm.parser == nil {
+		rm.logger.Printf("ignoring provider locks as no parser is available for %s", rm.Path())
+		return
+	}
+
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		rm.logger.Printf("failed to read provider locks for %s: %s", rm.Path(), err)
+		return
+	}
+
+	locks, diags := lang.ParseProviderLocks(path, src)
+	if diags.HasErrors() {
+		rm.logger.Printf("failed to parse provider locks for %s: %s", rm.Path(), diags)
+	}
+
+	rm.parser.SetProviderLocks(locks)
+	rm.logger.Printf("updated provider locks - %d providers parsed for %s",
+		len(locks), rm.Path())
+}
+
 func (rm *rootModule) PathsToWatch() []string {
 	rm.pluginMu.RLock()
 	rm.moduleMu.RLock()
@@ -466,6 +881,11 @@ func (rm *rootModule) PathsToWatch() []string {
 	if rm.pluginLockFile != nil {
 		files = append(files, rm.pluginLockFile.Path())
 	}
+	for _, dir := range pluginDirPaths(rm.Path()) {
+		if _, err := os.Stat(dir); err == nil {
+			files = append(files, dir)
+		}
+	}
 	if rm.moduleManifestFile != nil {
 		files = append(files, rm.moduleManifestFile.Path())
 	}
@@ -484,6 +904,17 @@ func (rm *rootModule) IsKnownModuleManifestFile(path string) bool {
 	return pathEquals(rm.moduleManifestFile.Path(), path)
 }
 
+// IsKnownPluginDir reports whether the path is a directory
+// into which Terraform installs plugins for the root module
+func (rm *rootModule) IsKnownPluginDir(path string) bool {
+	for _, dir := range pluginDirPaths(rm.Path()) {
+		if pathEquals(dir, path) {
+			return true
+		}
+	}
+	return false
+}
+
 func (rm *rootModule) IsKnownPluginLockFile(path string) bool {
 	rm.pluginMu.RLock()
 	defer rm.pluginMu.RUnlock()
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
 	"github.com/hashicorp/terraform-ls/internal/terraform/discovery"
 	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
 	"github.com/hashicorp/terraform-ls/internal/terraform/lang"
@@ -18,10 +19,19 @@ import (
 type rootModuleManager struct {
 	rms           []*rootModule
 	newRootModule RootModuleFactory
+	fs            filesystem.Filesystem
 
 	syncLoading bool
 	logger      *log.Logger
 
+	// schema cache and registry shared by all root modules
+	schemaCache    *schema.Cache
+	schemaRegistry *schema.Registry
+
+	// fallbackSchemas are used by root modules
+	// which have no schemas available otherwise
+	fallbackSchemas *schema.Index
+
 	// terraform discovery
 	tfDiscoFunc discovery.DiscoveryFunc
 
@@ -32,17 +42,19 @@ type rootModuleManager struct {
 	tfExecLogPath string
 }
 
-func NewRootModuleManager() RootModuleManager {
-	return newRootModuleManager()
+func NewRootModuleManager(fs filesystem.Filesystem) RootModuleManager {
+	return newRootModuleManager(fs)
 }
 
-func newRootModuleManager() *rootModuleManager {
+func newRootModuleManager(fs filesystem.Filesystem) *rootModuleManager {
 	d := &discovery.Discovery{}
 	rmm := &rootModuleManager{
-		rms:           make([]*rootModule, 0),
-		logger:        defaultLogger,
-		tfDiscoFunc:   d.LookPath,
-		tfNewExecutor: exec.NewExecutor,
+		rms:            make([]*rootModule, 0),
+		fs:             fs,
+		logger:         defaultLogger,
+		tfDiscoFunc:    d.LookPath,
+		tfNewExecutor:  exec.NewExecutor,
+		schemaRegistry: schema.DefaultRegistry(),
 	}
 	rmm.newRootModule = rmm.defaultRootModuleFactory
 	return rmm
@@ -52,11 +64,15 @@ func (rmm *rootModuleManager) defaultRootModuleFactory(ctx context.Context, dir
 	rm := newRootModule(dir)
 
 	rm.SetLogger(rmm.logger)
+	rm.fs = rmm.fs
 
 	d := &discovery.Discovery{}
 	rm.tfDiscoFunc = d.LookPath
 	rm.tfNewExecutor = exec.NewExecutor
 	rm.newSchemaStorage = schema.NewStorage
+	rm.schemaCache = rmm.schemaCache
+	rm.schemaRegistry = rmm.schemaRegistry
+	rm.fallbackSchemas = rmm.fallbackSchemas
 
 	rm.tfExecPath = rmm.tfExecPath
 	rm.tfExecTimeout = rmm.tfExecTimeout
@@ -77,11 +93,57 @@ func (rmm *rootModuleManager) SetTerraformExecTimeout(timeout time.Duration) {
 	rmm.tfExecTimeout = timeout
 }
 
+func (rmm *rootModuleManager) SetSchemaCacheDir(dir string) {
+	rmm.schemaCache = schema.NewCache(dir)
+	rmm.schemaCache.SetLogger(rmm.logger)
+}
+
+func (rmm *rootModuleManager) SetFallbackSchemas(idx *schema.Index) {
+	rmm.fallbackSchemas = idx
+}
+
+// HasFallbackSchemas reports whether fallback schemas are available,
+// i.e. whether root modules are useful even without being initialized
+func (rmm *rootModuleManager) HasFallbackSchemas() bool {
+	return rmm.fallbackSchemas != nil
+}
+
 func (rmm *rootModuleManager) SetLogger(logger *log.Logger) {
 	rmm.logger = logger
+	if rmm.schemaCache != nil {
+		rmm.schemaCache.SetLogger(logger)
+	}
 }
 
 func (rmm *rootModuleManager) AddAndStartLoadingRootModule(ctx context.Context, dir string) (RootModule, error) {
+	rm, err := rmm.addRootModule(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if rmm.syncLoading {
+		rmm.logger.Printf("synchronously loading root module %s", rm.Path())
+		return rm, rm.load(ctx)
+	}
+
+	rmm.logger.Printf("asynchronously loading root module %s", rm.Path())
+	rm.StartLoading()
+
+	return rm, nil
+}
+
+// AddAndLoadRootModule adds a root module and loads it synchronously
+func (rmm *rootModuleManager) AddAndLoadRootModule(ctx context.Context, dir string) (RootModule, error) {
+	rm, err := rmm.addRootModule(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	rmm.logger.Printf("synchronously loading root module %s", rm.Path())
+	return rm, rm.load(ctx)
+}
+
+func (rmm *rootModuleManager) addRootModule(dir string) (*rootModule, error) {
 	dir = filepath.Clean(dir)
 
 	// TODO: Follow symlinks (requires proper test data)
@@ -97,14 +159,6 @@ func (rmm *rootModuleManager) AddAndStartLoadingRootModule(ctx context.Context,
 
 	rmm.rms = append(rmm.rms, rm)
 
-	if rmm.syncLoading {
-		rmm.logger.Printf("synchronously loading root module %s", dir)
-		return rm, rm.load(ctx)
-	}
-
-	rmm.logger.Printf("asynchronously loading root module %s", dir)
-	rm.StartLoading()
-
 	return rm, nil
 }
 
@@ -154,6 +208,14 @@ func (rmm *rootModuleManager) RootModuleByPath(path string) (RootModule, error)
 	return nil, &RootModuleNotFoundErr{path}
 }
 
+func (rmm *rootModuleManager) ListRootModules() RootModules {
+	rms := make(RootModules, len(rmm.rms))
+	for i, rm := range rmm.rms {
+		rms[i] = rm
+	}
+	return rms
+}
+
 func (rmm *rootModuleManager) ParserForDir(path string) (lang.Parser, error) {
 	rm, err := rmm.RootModuleByPath(path)
 	if err != nil {
@@ -181,6 +243,36 @@ func (rmm *rootModuleManager) IsSchemaLoaded(path string) (bool, error) {
 	return rm.IsSchemaLoaded(), nil
 }
 
+func (rmm *rootModuleManager) ModuleIndexForDir(path string) (*lang.ModuleIndex, error) {
+	rm, err := rmm.RootModuleByPath(path)
+	if err != nil {
+		if IsRootModuleNotFound(err) {
+			// index standalone modules too, as the index
+			// doesn't depend on anything from the root module
+			return buildModuleIndex(rmm.fs, path)
+		}
+		return nil, err
+	}
+
+	return rm.ModuleIndex(path)
+}
+
+// InvalidateModuleIndex discards cached indexes of the given
+// module directory within all root modules
+func (rmm *rootModuleManager) InvalidateModuleIndex(path string) {
+	for _, rm := range rmm.rms {
+		rm.InvalidateModuleIndex(path)
+	}
+}
+
+func (rmm *rootModuleManager) ModuleCallsForDir(path string) ([]ModuleCall, error) {
+	calls := make([]ModuleCall, 0)
+	for _, rm := range rmm.RootModuleCandidatesByPath(path) {
+		calls = append(calls, rm.ModuleCalls(path)...)
+	}
+	return calls, nil
+}
+
 func (rmm *rootModuleManager) TerraformFormatterForDir(ctx context.Context, path string) (exec.Formatter, error) {
 	rm, err := rmm.RootModuleByPath(path)
 	if err != nil {
@@ -284,6 +376,13 @@ func rootModuleDirFromFilePath(filePath string) string {
 		return strings.TrimSuffix(filePath, moduleManifestSuffix)
 	}
 
+	pluginDirSuffixes := pluginDirPaths(string(os.PathSeparator))
+	for _, s := range pluginDirSuffixes {
+		if strings.HasSuffix(filePath, s) {
+			return strings.TrimSuffix(filePath, s)
+		}
+	}
+
 	return filePath
 }
 
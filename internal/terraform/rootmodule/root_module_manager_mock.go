@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
 	"github.com/hashicorp/terraform-ls/internal/terraform/discovery"
 	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
 )
@@ -12,6 +13,7 @@ import (
 type RootModuleMockFactory struct {
 	rmm    map[string]*RootModuleMock
 	logger *log.Logger
+	fs     filesystem.Filesystem
 }
 
 func (rmf *RootModuleMockFactory) New(ctx context.Context, dir string) (*rootModule, error) {
@@ -22,6 +24,7 @@ func (rmf *RootModuleMockFactory) New(ctx context.Context, dir string) (*rootMod
 
 	mock := NewRootModuleMock(rmm, dir)
 	mock.SetLogger(rmf.logger)
+	mock.fs = rmf.fs
 	return mock, mock.discoverCaches(ctx, dir)
 }
 
@@ -31,12 +34,13 @@ type RootModuleManagerMockInput struct {
 }
 
 func NewRootModuleManagerMock(input *RootModuleManagerMockInput) RootModuleManagerFactory {
-	rmm := newRootModuleManager()
+	rmm := newRootModuleManager(filesystem.NewFilesystem())
 	rmm.syncLoading = true
 
 	rmf := &RootModuleMockFactory{
 		rmm:    make(map[string]*RootModuleMock, 0),
 		logger: rmm.logger,
+		fs:     rmm.fs,
 	}
 
 	// mock terraform discovery
@@ -54,7 +58,9 @@ func NewRootModuleManagerMock(input *RootModuleManagerMockInput) RootModuleManag
 
 	rmm.newRootModule = rmf.New
 
-	return func() RootModuleManager {
+	return func(fs filesystem.Filesystem) RootModuleManager {
+		rmm.fs = fs
+		rmf.fs = fs
 		return rmm
 	}
 }
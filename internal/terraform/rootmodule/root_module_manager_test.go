@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
 	"github.com/hashicorp/terraform-ls/internal/terraform/discovery"
 	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
 )
@@ -451,8 +452,149 @@ func TestRootModuleManager_RootModuleCandidatesByPath(t *testing.T) {
 	}
 }
 
+func TestRootModuleManager_ModuleCallsForDir(t *testing.T) {
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootDir := filepath.Join(testData, "single-root-local-modules-only")
+
+	rmm := testRootModuleManager(t)
+	w := MockWalker()
+	err = w.StartWalking(rootDir, func(ctx context.Context, rmPath string) error {
+		_, err := rmm.AddAndStartLoadingRootModule(ctx, rmPath)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls, err := rmm.ModuleCallsForDir(filepath.Join(rootDir, "alpha"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedCalls := []ModuleCall{
+		{Name: "first", Dir: rootDir},
+		{Name: "second", Dir: rootDir},
+	}
+	if diff := cmp.Diff(expectedCalls, calls); diff != "" {
+		t.Fatalf("module calls don't match: %s", diff)
+	}
+
+	calls, err = rmm.ModuleCallsForDir(rootDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 0 {
+		t.Fatalf("expected no calls of root module, given: %#v", calls)
+	}
+}
+
+func TestRootModuleManager_ListRootModules(t *testing.T) {
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootDir := filepath.Join(testData, "single-root-local-modules-only")
+
+	rmm := testRootModuleManager(t)
+	w := MockWalker()
+	err = w.StartWalking(rootDir, func(ctx context.Context, rmPath string) error {
+		_, err := rmm.AddAndStartLoadingRootModule(ctx, rmPath)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rms := rmm.ListRootModules()
+	if diff := cmp.Diff([]string{rootDir}, rms.Paths()); diff != "" {
+		t.Fatalf("root modules don't match: %s", diff)
+	}
+
+	expectedModules := []Module{
+		{Address: "", Dir: rootDir},
+		{Address: "module.first", Dir: filepath.Join(rootDir, "alpha")},
+		{Address: "module.second", Dir: filepath.Join(rootDir, "alpha")},
+		{Address: "module.three", Dir: filepath.Join(rootDir, "beta")},
+	}
+	if diff := cmp.Diff(expectedModules, rms[0].Modules()); diff != "" {
+		t.Fatalf("modules don't match: %s", diff)
+	}
+
+	mi, err := rms[0].ModuleIndex(rootDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	child, ok := mi.CalledModule("three")
+	if !ok {
+		t.Fatal("expected module.three to be indexed")
+	}
+	if _, ok := child.Declaration("azurerm_resource_group.example"); !ok {
+		t.Fatal("expected azurerm_resource_group.example to be declared in module.three")
+	}
+}
+
+func TestRootModuleManager_InvalidateModuleIndex(t *testing.T) {
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootDir := filepath.Join(testData, "single-root-local-modules-only")
+
+	rmm := testRootModuleManager(t)
+	w := MockWalker()
+	err = w.StartWalking(rootDir, func(ctx context.Context, rmPath string) error {
+		_, err := rmm.AddAndStartLoadingRootModule(ctx, rmPath)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := filesystem.NewFilesystem()
+	rm := rmm.rms[0]
+	rm.fs = fs
+
+	mi, err := rm.ModuleIndex(rootDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cachedMi, err := rm.ModuleIndex(rootDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cachedMi != mi {
+		t.Fatal("expected module index to be cached")
+	}
+
+	// mimic opening a file of a called module with unsaved changes
+	betaDir := filepath.Join(rootDir, "beta")
+	err = fs.Open(filesystem.NewFile(filepath.Join(betaDir, "main.tf"),
+		[]byte(`variable "added" {}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rmm.InvalidateModuleIndex(betaDir)
+
+	mi, err = rm.ModuleIndex(rootDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mi == cachedMi {
+		t.Fatal("expected index of calling module to be invalidated")
+	}
+	child, ok := mi.CalledModule("three")
+	if !ok {
+		t.Fatal("expected module.three to be indexed")
+	}
+	if _, ok := child.Declaration("var.added"); !ok {
+		t.Fatal("expected var.added to be declared in module.three")
+	}
+}
+
 func testRootModuleManager(t *testing.T) *rootModuleManager {
-	rmm := newRootModuleManager()
+	rmm := newRootModuleManager(filesystem.NewFilesystem())
 	rmm.syncLoading = true
 	rmm.logger = testLogger()
 	rmm.newRootModule = func(ctx context.Context, dir string) (*rootModule, error) {
@@ -0,0 +1,549 @@
+package rootmodule
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+	"github.com/hashicorp/terraform-ls/internal/terraform/discovery"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/hashicorp/terraform-ls/internal/terraform/schema"
+)
+
+func TestRootModule_dependencyLockFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rootmodule")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	lockFilePath := filepath.Join(dir, ".terraform.lock.hcl")
+	err = ioutil.WriteFile(lockFilePath, []byte(`provider "registry.terraform.io/hashicorp/aws" {
+  version     = "3.20.0"
+  constraints = "~> 3.0"
+  hashes = [
+    "h1:fakeh1hash=",
+  ]
+}
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	rm := NewRootModuleMock(&RootModuleMock{
+		TerraformExecQueue: &exec.MockQueue{
+			Q: []*exec.MockItem{
+				{
+					Args:   []string{"version"},
+					Stdout: "Terraform v0.14.0\n",
+				},
+				{
+					Args:   []string{"providers", "schema", "-json"},
+					Stdout: "{\"format_version\":\"0.1\"}\n",
+				},
+			},
+		},
+	}, dir)
+	rm.logger = testLogger()
+
+	err = rm.discoverCaches(ctx, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = rm.load(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff([]string{lockFilePath}, rm.PathsToWatch()); diff != "" {
+		t.Fatalf("paths to watch don't match: %s", diff)
+	}
+	if !rm.IsKnownPluginLockFile(lockFilePath) {
+		t.Fatalf("expected %s to be known plugin lock file", lockFilePath)
+	}
+	if rmDir := rootModuleDirFromFilePath(lockFilePath); rmDir != dir {
+		t.Fatalf("root module dir doesn't match, expected: %q, given: %q", dir, rmDir)
+	}
+
+	p, err := rm.Parser()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hoverData, err := p.HoverAtPos(ihcl.NewTestFile([]byte(`terraform {
+  required_providers {
+    aws = "~> 3.0"
+  }
+}
+`)), nil, hcl.Pos{Line: 3, Column: 5, Byte: 39})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hoverData == nil {
+		t.Fatal("expected hover data with provider lock")
+	}
+	expectedContent := "aws (hashicorp/aws)\n\nLocked version: 3.20.0\nConstraints: ~> 3.0\n\nHashes:\n- h1:fakeh1hash="
+	if diff := cmp.Diff(expectedContent, hoverData.Content.Value()); diff != "" {
+		t.Fatalf("hover content doesn't match: %s", diff)
+	}
+}
+
+func TestRootModule_schemaCache(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "schemas")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(cacheDir)
+	cache := schema.NewCache(cacheDir)
+	cache.SetLogger(testLogger())
+
+	lockFile := []byte(`provider "registry.terraform.io/hashicorp/aws" {
+  version = "3.20.0"
+}
+`)
+	schemaJson := `{"format_version":"0.1","provider_schemas":{` +
+		`"registry.terraform.io/hashicorp/aws":{"provider":{"version":0,"block":{}}}}}`
+
+	queues := [][]*exec.MockItem{
+		{
+			{
+				Args:   []string{"version"},
+				Stdout: "Terraform v0.14.0\n",
+			},
+			{
+				Args:   []string{"providers", "schema", "-json"},
+				Stdout: schemaJson + "\n",
+			},
+		},
+		// schema of the second module is expected to come from cache
+		{
+			{
+				Args:   []string{"version"},
+				Stdout: "Terraform v0.14.0\n",
+			},
+		},
+	}
+
+	ctx := context.Background()
+	for i, q := range queues {
+		dir, err := ioutil.TempDir("", "rootmodule")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+
+		err = ioutil.WriteFile(filepath.Join(dir, ".terraform.lock.hcl"), lockFile, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rm := NewRootModuleMock(&RootModuleMock{
+			TerraformExecQueue: &exec.MockQueue{Q: q},
+		}, dir)
+		rm.logger = testLogger()
+		rm.schemaCache = cache
+
+		err = rm.discoverCaches(ctx, dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = rm.load(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		providers, err := rm.schemaStorage.Providers()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"aws"}, providers); diff != "" {
+			t.Fatalf("%d: providers don't match: %s", i, diff)
+		}
+	}
+}
+
+func TestRootModule_fallbackSchemas(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rootmodule")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	rm := NewRootModuleMock(&RootModuleMock{
+		TerraformExecQueue: &exec.MockQueue{
+			Q: []*exec.MockItem{
+				{
+					Args:   []string{"version"},
+					Stdout: "Terraform v0.14.0\n",
+				},
+			},
+		},
+	}, dir)
+	rm.logger = testLogger()
+	raw, err := schema.ParseRawProviderSchemas([]byte(`{"format_version":"0.1","provider_schemas":{` +
+		`"registry.terraform.io/hashicorp/aws":{"provider":{"version":0,"block":{}}}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rm.fallbackSchemas, err = schema.NewIndex(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = rm.discoverCaches(ctx, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = rm.load(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	providers, err := rm.schemaStorage.Providers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"aws"}, providers); diff != "" {
+		t.Fatalf("providers don't match: %s", diff)
+	}
+}
+
+func TestRootModule_fallbackSchemas_withoutTerraform(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rootmodule")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// e.g. lock file committed to VCS in a fresh clone
+	err = ioutil.WriteFile(filepath.Join(dir, ".terraform.lock.hcl"), []byte(`provider "registry.terraform.io/hashicorp/aws" {
+  version = "3.20.0"
+}
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	rm := NewRootModuleMock(&RootModuleMock{
+		TerraformExecQueue: &exec.MockQueue{},
+	}, dir)
+	rm.logger = testLogger()
+	rm.tfDiscoFunc = func() (string, error) {
+		return "", &discovery.ExecutableNotFoundErr{Name: "terraform"}
+	}
+	raw, err := schema.ParseRawProviderSchemas([]byte(`{"format_version":"0.1","provider_schemas":{` +
+		`"registry.terraform.io/hashicorp/aws":{"provider":{"version":0,"block":{}}}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rm.fallbackSchemas, err = schema.NewIndex(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = rm.discoverCaches(ctx, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = rm.load(ctx)
+	var notFoundErr *discovery.ExecutableNotFoundErr
+	if !errors.As(err, &notFoundErr) {
+		t.Fatalf("expected terraform not to be found, given: %#v", err)
+	}
+
+	if _, err := rm.Parser(); err != nil {
+		t.Fatal(err)
+	}
+	providers, err := rm.schemaStorage.Providers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"aws"}, providers); diff != "" {
+		t.Fatalf("providers don't match: %s", diff)
+	}
+}
+
+func TestRootModule_loadError_schemas(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rootmodule")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	queue := &exec.MockQueue{
+		Q: []*exec.MockItem{
+			{
+				Args:   []string{"version"},
+				Stdout: "Terraform v0.14.0\n",
+			},
+			{
+				Args:     []string{"providers", "schema", "-json"},
+				Stderr:   "Error: Could not load plugin\n",
+				ExitCode: 1,
+			},
+			{
+				Args:   []string{"providers", "schema", "-json"},
+				Stdout: "{\"format_version\":\"0.1\"}\n",
+			},
+		},
+	}
+	rm := NewRootModuleMock(&RootModuleMock{
+		TerraformExecQueue: queue,
+	}, dir)
+	rm.logger = testLogger()
+
+	err = rm.load(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var notInitErr *NotInitializedErr
+	if !errors.As(rm.LoadError(), &notInitErr) {
+		t.Fatalf("expected not initialized error, given: %#v", rm.LoadError())
+	}
+
+	lockFilePath := filepath.Join(dir, ".terraform.lock.hcl")
+	err = ioutil.WriteFile(lockFilePath, []byte{}, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// e.g. plugins not installed yet
+	err = rm.ReloadSchemaCache(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rm.LoadError() == nil {
+		t.Fatal("expected load error for failed schema retrieval")
+	}
+
+	err = rm.ReloadSchemaCache(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rm.LoadError() != nil {
+		t.Fatalf("expected no load error, given: %s", rm.LoadError())
+	}
+}
+
+func TestRootModule_pluginDirs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rootmodule")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	lockFilePath := filepath.Join(dir, ".terraform.lock.hcl")
+	err = ioutil.WriteFile(lockFilePath, []byte(`provider "registry.terraform.io/hashicorp/aws" {
+  version = "3.20.0"
+}
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	providersDir := filepath.Join(dir, ".terraform", "providers")
+	err = os.MkdirAll(providersDir, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	queue := &exec.MockQueue{
+		Q: []*exec.MockItem{
+			{
+				Args:   []string{"version"},
+				Stdout: "Terraform v0.14.0\n",
+			},
+			{
+				Args:   []string{"providers", "schema", "-json"},
+				Stdout: "{\"format_version\":\"0.1\"}\n",
+			},
+			// schema obtained again after plugins were installed
+			{
+				Args:   []string{"providers", "schema", "-json"},
+				Stdout: "{\"format_version\":\"0.1\"}\n",
+			},
+		},
+	}
+	rm := NewRootModuleMock(&RootModuleMock{
+		TerraformExecQueue: queue,
+	}, dir)
+	rm.logger = testLogger()
+
+	err = rm.discoverCaches(ctx, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = rm.load(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPaths := []string{
+		lockFilePath,
+		filepath.Join(dir, ".terraform"),
+		providersDir,
+	}
+	if diff := cmp.Diff(expectedPaths, rm.PathsToWatch()); diff != "" {
+		t.Fatalf("paths to watch don't match: %s", diff)
+	}
+	if !rm.IsKnownPluginDir(providersDir) {
+		t.Fatalf("expected %s to be known plugin dir", providersDir)
+	}
+	if rmDir := rootModuleDirFromFilePath(providersDir); rmDir != dir {
+		t.Fatalf("root module dir doesn't match, expected: %q, given: %q", dir, rmDir)
+	}
+
+	err = rm.ReloadSchemaCache(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queue.Q) != 0 {
+		t.Fatalf("expected schema to be obtained again, %d calls left", len(queue.Q))
+	}
+}
+
+func TestRootModule_terraformInit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rootmodule")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	rm := NewRootModuleMock(&RootModuleMock{
+		TerraformExecQueue: &exec.MockQueue{
+			Q: []*exec.MockItem{
+				{
+					Args:   []string{"version"},
+					Stdout: "Terraform v0.14.0\n",
+				},
+				{
+					Args:   []string{"init", "-input=false", "-no-color"},
+					Stdout: "Terraform has been successfully initialized!\n",
+				},
+				{
+					Args:   []string{"version"},
+					Stdout: "Terraform v0.14.0\n",
+				},
+				{
+					Args:   []string{"providers", "schema", "-json"},
+					Stdout: "{\"format_version\":\"0.1\"}\n",
+				},
+			},
+		},
+	}, dir)
+	rm.logger = testLogger()
+
+	err = rm.discoverCaches(ctx, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = rm.load(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if paths := rm.PathsToWatch(); len(paths) != 0 {
+		t.Fatalf("expected no paths to watch before init, given: %q", paths)
+	}
+
+	// mimic the lock file created by terraform init
+	lockFilePath := filepath.Join(dir, ".terraform.lock.hcl")
+	err = ioutil.WriteFile(lockFilePath, []byte(`provider "registry.terraform.io/hashicorp/aws" {
+  version = "3.20.0"
+}
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = rm.ExecuteTerraformInit(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !rm.IsKnownPluginLockFile(lockFilePath) {
+		t.Fatalf("expected %s to be known plugin lock file after init", lockFilePath)
+	}
+}
+
+func TestRootModule_reloadWhileLoading(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rootmodule")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	rm := NewRootModuleMock(&RootModuleMock{
+		TerraformExecQueue: &exec.MockQueue{
+			Q: []*exec.MockItem{
+				{
+					Args:          []string{"version"},
+					Stdout:        "Terraform v0.14.0\n",
+					SleepDuration: 200 * time.Millisecond,
+				},
+				{
+					Args:   []string{"version"},
+					Stdout: "Terraform v0.14.0\n",
+				},
+			},
+		},
+	}, dir)
+	rm.logger = testLogger()
+
+	rm.StartLoading()
+	err = rm.Reload(context.Background())
+	if err != nil {
+		var notInitErr *NotInitializedErr
+		if !errors.As(err, &notInitErr) {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := rm.Parser(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRootModule_loadingDone(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rootmodule")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	rm := NewRootModuleMock(&RootModuleMock{
+		TerraformExecQueue: &exec.MockQueue{
+			Q: []*exec.MockItem{
+				{
+					Args:   []string{"version"},
+					Stdout: "Terraform v0.11.0\n",
+				},
+			},
+		},
+	}, dir)
+	rm.logger = testLogger()
+
+	rm.StartLoading()
+	select {
+	case <-rm.LoadingDone():
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for loading")
+	}
+
+	if !rm.IsLoadingDone() {
+		t.Fatal("expected loading to be done")
+	}
+	if rm.LoadError() == nil {
+		t.Fatal("expected load error for unsupported version")
+	}
+}
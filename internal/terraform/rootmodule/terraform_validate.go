@@ -0,0 +1,50 @@
+package rootmodule
+
+import (
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+)
+
+// validateDiagsToHCL converts diagnostics reported by terraform validate
+// to HCL diagnostics keyed by absolute paths of files
+func validateDiagsToHCL(dir string, diags []exec.Diagnostic) map[string]hcl.Diagnostics {
+	fileDiags := make(map[string]hcl.Diagnostics, 0)
+
+	for _, d := range diags {
+		diag := &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  d.Summary,
+			Detail:   d.Detail,
+		}
+		if d.Severity == "warning" {
+			diag.Severity = hcl.DiagWarning
+		}
+
+		path := ""
+		if d.Range != nil {
+			path = d.Range.Filename
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(dir, path)
+			}
+			diag.Subject = &hcl.Range{
+				Filename: path,
+				Start: hcl.Pos{
+					Line:   d.Range.Start.Line,
+					Column: d.Range.Start.Column,
+					Byte:   d.Range.Start.Byte,
+				},
+				End: hcl.Pos{
+					Line:   d.Range.End.Line,
+					Column: d.Range.End.Column,
+					Byte:   d.Range.End.Byte,
+				},
+			}
+		}
+
+		fileDiags[path] = append(fileDiags[path], diag)
+	}
+
+	return fileDiags
+}
@@ -0,0 +1,55 @@
+package rootmodule
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+)
+
+func TestValidateDiagsToHCL(t *testing.T) {
+	dir := filepath.Join("tmp", "rootmodule")
+	diags := validateDiagsToHCL(dir, []exec.Diagnostic{
+		{
+			Severity: "error",
+			Summary:  "Missing required argument",
+			Detail:   `The argument "ami" is required.`,
+			Range: &exec.DiagnosticRange{
+				Filename: "main.tf",
+				Start:    exec.DiagnosticPos{Line: 1, Column: 28, Byte: 27},
+				End:      exec.DiagnosticPos{Line: 1, Column: 29, Byte: 28},
+			},
+		},
+		{
+			Severity: "warning",
+			Summary:  "Provider without configuration",
+		},
+	})
+
+	mainPath := filepath.Join(dir, "main.tf")
+	expectedDiags := map[string]hcl.Diagnostics{
+		mainPath: {
+			{
+				Severity: hcl.DiagError,
+				Summary:  "Missing required argument",
+				Detail:   `The argument "ami" is required.`,
+				Subject: &hcl.Range{
+					Filename: mainPath,
+					Start:    hcl.Pos{Line: 1, Column: 28, Byte: 27},
+					End:      hcl.Pos{Line: 1, Column: 29, Byte: 28},
+				},
+			},
+		},
+		"": {
+			{
+				Severity: hcl.DiagWarning,
+				Summary:  "Provider without configuration",
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedDiags, diags); diff != "" {
+		t.Fatalf("diagnostics don't match: %s", diff)
+	}
+}
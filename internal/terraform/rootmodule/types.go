@@ -5,8 +5,11 @@ import (
 	"log"
 	"time"
 
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
 	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
 	"github.com/hashicorp/terraform-ls/internal/terraform/lang"
+	"github.com/hashicorp/terraform-ls/internal/terraform/schema"
 )
 
 type File interface {
@@ -19,6 +22,33 @@ type ParserFinder interface {
 	IsSchemaLoaded(path string) (bool, error)
 }
 
+type ModuleIndexFinder interface {
+	ModuleIndexForDir(path string) (*lang.ModuleIndex, error)
+	ModuleCallsForDir(path string) ([]ModuleCall, error)
+	InvalidateModuleIndex(path string)
+}
+
+// ModuleCall represents a module block which calls
+// (i.e. sources) a particular module directory
+type ModuleCall struct {
+	// Name is the label of the module block
+	Name string
+
+	// Dir is the directory of the module declaring the module block
+	Dir string
+}
+
+// Module represents a module directory as installed within
+// a root module, i.e. the root module itself or any module it calls
+type Module struct {
+	// Address is empty for the root module itself
+	// or the address of the module call, e.g. module.vpc
+	Address string
+
+	// Dir is the path to the module directory
+	Dir string
+}
+
 type TerraformFormatterFinder interface {
 	TerraformFormatterForDir(ctx context.Context, path string) (exec.Formatter, error)
 	IsTerraformLoaded(path string) (bool, error)
@@ -32,6 +62,7 @@ type RootModuleLoader func(dir string) (RootModule, error)
 
 type RootModuleManager interface {
 	ParserFinder
+	ModuleIndexFinder
 	TerraformFormatterFinder
 	RootModuleCandidateFinder
 
@@ -40,10 +71,15 @@ type RootModuleManager interface {
 	SetTerraformExecPath(path string)
 	SetTerraformExecLogPath(logPath string)
 	SetTerraformExecTimeout(timeout time.Duration)
+	SetSchemaCacheDir(dir string)
+	SetFallbackSchemas(idx *schema.Index)
+	HasFallbackSchemas() bool
 
 	AddAndStartLoadingRootModule(ctx context.Context, dir string) (RootModule, error)
+	AddAndLoadRootModule(ctx context.Context, dir string) (RootModule, error)
 	PathsToWatch() []string
 	RootModuleByPath(path string) (RootModule, error)
+	ListRootModules() RootModules
 	CancelLoading()
 }
 
@@ -62,20 +98,30 @@ type RootModule interface {
 	LoadError() error
 	StartLoading()
 	IsLoadingDone() bool
+	LoadingDone() <-chan struct{}
 	IsKnownPluginLockFile(path string) bool
+	IsKnownPluginDir(path string) bool
 	IsKnownModuleManifestFile(path string) bool
 	PathsToWatch() []string
 	UpdateSchemaCache(ctx context.Context, lockFile File) error
+	ReloadSchemaCache(ctx context.Context) error
 	IsSchemaLoaded() bool
 	UpdateModuleManifest(manifestFile File) error
 	Parser() (lang.Parser, error)
 	IsParserLoaded() bool
+	ModuleIndex(dir string) (*lang.ModuleIndex, error)
+	InvalidateModuleIndex(dir string)
+	ModuleCalls(dir string) []ModuleCall
+	Modules() []Module
 	TerraformFormatter() (exec.Formatter, error)
+	ExecuteTerraformValidate(ctx context.Context) (map[string]hcl.Diagnostics, error)
+	ExecuteTerraformInit(ctx context.Context) error
+	Reload(ctx context.Context) error
 	IsTerraformLoaded() bool
 }
 
 type RootModuleFactory func(context.Context, string) (*rootModule, error)
 
-type RootModuleManagerFactory func() RootModuleManager
+type RootModuleManagerFactory func(filesystem.Filesystem) RootModuleManager
 
 type WalkerFactory func() *Walker
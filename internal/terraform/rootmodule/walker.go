@@ -29,6 +29,7 @@ type Walker struct {
 
 	cancelFunc context.CancelFunc
 	doneCh     chan struct{}
+	doneHooks  []DoneHook
 }
 
 func NewWalker() *Walker {
@@ -44,6 +45,14 @@ func (w *Walker) SetLogger(logger *log.Logger) {
 
 type WalkFunc func(ctx context.Context, rootModulePath string) error
 
+// DoneHook is called when walking finishes,
+// with any error which ended the walk early
+type DoneHook func(err error)
+
+func (w *Walker) AddDoneHook(h DoneHook) {
+	w.doneHooks = append(w.doneHooks, h)
+}
+
 func (w *Walker) Stop() {
 	if w.cancelFunc != nil {
 		w.cancelFunc()
@@ -124,6 +133,11 @@ func (w *Walker) walk(ctx context.Context, rootPath string, wf WalkFunc) error {
 	})
 	w.logger.Printf("walking of %s finished", rootPath)
 	w.walking = false
+
+	for _, h := range w.doneHooks {
+		h(err)
+	}
+
 	return err
 }
 
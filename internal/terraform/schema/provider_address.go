@@ -0,0 +1,100 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// DefaultProviderRegistryHost is the hostname assumed
+	// for provider source addresses which don't specify any
+	DefaultProviderRegistryHost = "registry.terraform.io"
+
+	// legacyProviderNamespace is used by Terraform 0.13
+	// for providers which were installed without a source
+	// (i.e. addressed by type only, as in 0.12)
+	legacyProviderNamespace = "-"
+
+	defaultProviderNamespace = "hashicorp"
+)
+
+// ProviderAddress represents an address of a provider
+// as used in keys of provider schemas, which is just the type
+// in Terraform 0.12 (e.g. aws) and fully qualified source address
+// in 0.13+ (e.g. registry.terraform.io/hashicorp/aws)
+type ProviderAddress struct {
+	Hostname  string
+	Namespace string
+	Type      string
+}
+
+// ParseProviderAddress parses the given key of provider schemas
+func ParseProviderAddress(key string) (ProviderAddress, error) {
+	parts := strings.Split(key, "/")
+	for _, part := range parts {
+		if part == "" {
+			return ProviderAddress{}, fmt.Errorf("invalid provider address: %q", key)
+		}
+	}
+
+	switch len(parts) {
+	case 1:
+		return ProviderAddress{
+			Hostname:  DefaultProviderRegistryHost,
+			Namespace: legacyProviderNamespace,
+			Type:      parts[0],
+		}, nil
+	case 2:
+		return ProviderAddress{
+			Hostname:  DefaultProviderRegistryHost,
+			Namespace: parts[0],
+			Type:      parts[1],
+		}, nil
+	case 3:
+		return ProviderAddress{
+			Hostname:  parts[0],
+			Namespace: parts[1],
+			Type:      parts[2],
+		}, nil
+	}
+
+	return ProviderAddress{}, fmt.Errorf("invalid provider address: %q", key)
+}
+
+// IsLegacy reports whether the address has no namespace,
+// i.e. comes from Terraform 0.12 or a provider installed without source
+func (pa ProviderAddress) IsLegacy() bool {
+	return pa.Namespace == legacyProviderNamespace
+}
+
+// Source returns the source address as written in required_providers,
+// i.e. without the hostname if it's the default registry.
+//
+// Legacy addresses are assumed to refer to official providers
+// which is what Terraform 0.13 assumes when upgrading.
+func (pa ProviderAddress) Source() string {
+	namespace := pa.Namespace
+	if pa.IsLegacy() {
+		namespace = defaultProviderNamespace
+	}
+
+	if pa.Hostname == DefaultProviderRegistryHost {
+		return fmt.Sprintf("%s/%s", namespace, pa.Type)
+	}
+	return fmt.Sprintf("%s/%s/%s", pa.Hostname, namespace, pa.Type)
+}
+
+func (pa ProviderAddress) String() string {
+	return fmt.Sprintf("%s/%s/%s", pa.Hostname, pa.Namespace, pa.Type)
+}
+
+// providerLocalName returns the name under which the provider
+// identified by the given key of provider schemas is referred to
+// in the configuration, i.e. its type
+func providerLocalName(key string) string {
+	pa, err := ParseProviderAddress(key)
+	if err != nil {
+		return key
+	}
+	return pa.Type
+}
@@ -0,0 +1,83 @@
+package schema
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseProviderAddress(t *testing.T) {
+	testCases := []struct {
+		key            string
+		expectedAddr   ProviderAddress
+		expectedSource string
+		expectedErr    bool
+	}{
+		{
+			"aws",
+			ProviderAddress{"registry.terraform.io", "-", "aws"},
+			"hashicorp/aws",
+			false,
+		},
+		{
+			"registry.terraform.io/hashicorp/aws",
+			ProviderAddress{"registry.terraform.io", "hashicorp", "aws"},
+			"hashicorp/aws",
+			false,
+		},
+		{
+			"registry.terraform.io/-/aws",
+			ProviderAddress{"registry.terraform.io", "-", "aws"},
+			"hashicorp/aws",
+			false,
+		},
+		{
+			"example.com/acme/widget",
+			ProviderAddress{"example.com", "acme", "widget"},
+			"example.com/acme/widget",
+			false,
+		},
+		{
+			"acme/widget",
+			ProviderAddress{"registry.terraform.io", "acme", "widget"},
+			"acme/widget",
+			false,
+		},
+		{
+			"registry.terraform.io//aws",
+			ProviderAddress{},
+			"",
+			true,
+		},
+		{
+			"a/b/c/d",
+			ProviderAddress{},
+			"",
+			true,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.key), func(t *testing.T) {
+			pa, err := ParseProviderAddress(tc.key)
+			if err != nil {
+				if tc.expectedErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if tc.expectedErr {
+				t.Fatalf("Expected error for %q", tc.key)
+			}
+
+			if diff := cmp.Diff(tc.expectedAddr, pa); diff != "" {
+				t.Fatalf("Address doesn't match.\n%s", diff)
+			}
+			if pa.Source() != tc.expectedSource {
+				t.Fatalf("Source doesn't match.\nexpected: %q\ngiven: %q",
+					tc.expectedSource, pa.Source())
+			}
+		})
+	}
+}
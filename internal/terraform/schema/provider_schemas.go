@@ -0,0 +1,236 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// RawProviderSchemas represents output of terraform providers schema -json
+// where schemas of individual providers are left undecoded
+type RawProviderSchemas struct {
+	FormatVersion string                     `json:"format_version"`
+	Schemas       map[string]json.RawMessage `json:"provider_schemas,omitempty"`
+}
+
+// ParseRawProviderSchemas parses output of terraform providers schema -json
+// without decoding schemas of individual providers
+func ParseRawProviderSchemas(b []byte) (*RawProviderSchemas, error) {
+	var raw RawProviderSchemas
+	err := json.Unmarshal(b, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the format version is validated as that's all tfjson checks
+	err = (&tfjson.ProviderSchemas{FormatVersion: raw.FormatVersion}).Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	if raw.Schemas == nil {
+		raw.Schemas = make(map[string]json.RawMessage, 0)
+	}
+
+	return &raw, nil
+}
+
+// Index holds schemas of providers indexed by provider address
+// and local name, resource type and data source type.
+//
+// The index is built at load time, whereas schemas of individual
+// providers are only decoded on first use.
+type Index struct {
+	providers map[string]*providerSchema
+
+	// localNames, resources and dataSources map
+	// to addresses (keys) of providers
+	localNames  map[string]string
+	resources   map[string]string
+	dataSources map[string]string
+}
+
+// providerSchemaTypes is used to index resources and data sources
+// of a provider without decoding their schemas
+type providerSchemaTypes struct {
+	ResourceSchemas   map[string]struct{} `json:"resource_schemas"`
+	DataSourceSchemas map[string]struct{} `json:"data_source_schemas"`
+}
+
+// NewIndex indexes the given schemas
+func NewIndex(raw *RawProviderSchemas) (*Index, error) {
+	idx := newIndex()
+
+	for _, addr := range sortedKeys(raw.Schemas) {
+		b := raw.Schemas[addr]
+
+		var types providerSchemaTypes
+		err := json.Unmarshal(b, &types)
+		if err != nil {
+			return nil, fmt.Errorf("failed to index schema of %q: %w", addr, err)
+		}
+
+		resources := make([]string, 0, len(types.ResourceSchemas))
+		for rType := range types.ResourceSchemas {
+			resources = append(resources, rType)
+		}
+		dataSources := make([]string, 0, len(types.DataSourceSchemas))
+		for dsType := range types.DataSourceSchemas {
+			dataSources = append(dataSources, dsType)
+		}
+
+		idx.add(addr, &providerSchema{
+			raw:  b,
+			once: &sync.Once{},
+		}, resources, dataSources)
+	}
+
+	return idx, nil
+}
+
+// newDecodedIndex indexes already decoded schemas
+func newDecodedIndex(ps *tfjson.ProviderSchemas) *Index {
+	idx := newIndex()
+
+	addrs := make([]string, 0, len(ps.Schemas))
+	for addr := range ps.Schemas {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+
+	for _, addr := range addrs {
+		schema := ps.Schemas[addr]
+
+		resources := make([]string, 0, len(schema.ResourceSchemas))
+		for rType := range schema.ResourceSchemas {
+			resources = append(resources, rType)
+		}
+		dataSources := make([]string, 0, len(schema.DataSourceSchemas))
+		for dsType := range schema.DataSourceSchemas {
+			dataSources = append(dataSources, dsType)
+		}
+
+		idx.add(addr, &providerSchema{
+			schema: schema,
+			once:   &sync.Once{},
+		}, resources, dataSources)
+	}
+
+	return idx
+}
+
+func newIndex() *Index {
+	return &Index{
+		providers:   make(map[string]*providerSchema, 0),
+		localNames:  make(map[string]string, 0),
+		resources:   make(map[string]string, 0),
+		dataSources: make(map[string]string, 0),
+	}
+}
+
+// add indexes schema of a provider, where the first provider
+// (in order of addresses) wins in case of conflicting names
+func (idx *Index) add(addr string, ps *providerSchema, resources, dataSources []string) {
+	idx.providers[addr] = ps
+
+	name := providerLocalName(addr)
+	if _, ok := idx.localNames[name]; !ok {
+		idx.localNames[name] = addr
+	}
+	for _, rType := range resources {
+		if _, ok := idx.resources[rType]; !ok {
+			idx.resources[rType] = addr
+		}
+	}
+	for _, dsType := range dataSources {
+		if _, ok := idx.dataSources[dsType]; !ok {
+			idx.dataSources[dsType] = addr
+		}
+	}
+}
+
+// withProviders returns copy of the index referring
+// to the given schemas of providers
+func (idx *Index) withProviders(providers map[string]*providerSchema) *Index {
+	return &Index{
+		providers:   providers,
+		localNames:  idx.localNames,
+		resources:   idx.resources,
+		dataSources: idx.dataSources,
+	}
+}
+
+// Addresses returns addresses of all indexed providers
+// as used in keys of provider schemas
+func (idx *Index) Addresses() []string {
+	addrs := make([]string, 0, len(idx.providers))
+	for addr := range idx.providers {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+	return addrs
+}
+
+// provider finds schema of a provider by its name
+// which is either the key of provider schemas (as in 0.12)
+// or the type of a provider in 0.13+ (e.g. aws for
+// registry.terraform.io/hashicorp/aws)
+func (idx *Index) provider(name string) (*tfjson.ProviderSchema, bool, error) {
+	addr, ok := name, false
+	if _, ok = idx.providers[name]; !ok {
+		addr, ok = idx.localNames[name]
+		if !ok {
+			return nil, false, nil
+		}
+	}
+	return idx.decodedProvider(addr)
+}
+
+func (idx *Index) decodedProvider(addr string) (*tfjson.ProviderSchema, bool, error) {
+	ps, ok := idx.providers[addr]
+	if !ok {
+		return nil, false, nil
+	}
+	schema, err := ps.decoded()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to decode schema of %q: %w", addr, err)
+	}
+	return schema, true, nil
+}
+
+// providerSchema holds schema of a single provider,
+// decoding it from raw JSON on first use
+type providerSchema struct {
+	raw    json.RawMessage
+	once   *sync.Once
+	schema *tfjson.ProviderSchema
+	err    error
+}
+
+func (ps *providerSchema) decoded() (*tfjson.ProviderSchema, error) {
+	ps.once.Do(func() {
+		if ps.raw == nil {
+			return
+		}
+		var schema tfjson.ProviderSchema
+		ps.err = json.Unmarshal(ps.raw, &schema)
+		if ps.err == nil {
+			ps.schema = &schema
+		}
+		// raw schema is no longer needed
+		ps.raw = nil
+	})
+	return ps.schema, ps.err
+}
+
+func sortedKeys(m map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
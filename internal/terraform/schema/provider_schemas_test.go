@@ -0,0 +1,243 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestIndex_lazyDecoding(t *testing.T) {
+	idx, err := NewIndex(testProviderSchemas(
+		"registry.terraform.io/hashicorp/aws",
+		"registry.terraform.io/hashicorp/null",
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewStorage()
+	s.index = idx
+
+	if _, err := s.ResourceSchema("aws_thing"); err != nil {
+		t.Fatal(err)
+	}
+	if idx.providers["registry.terraform.io/hashicorp/aws"].schema == nil {
+		t.Fatal("expected schema of aws provider to be decoded")
+	}
+	if idx.providers["registry.terraform.io/hashicorp/null"].schema != nil {
+		t.Fatal("expected schema of null provider not to be decoded")
+	}
+
+	_, err = s.DataSourceSchema("aws_thing")
+	if err == nil {
+		t.Fatal("expected unknown data source to fail")
+	}
+}
+
+func TestStorage_typesWithoutDecoding(t *testing.T) {
+	idx, err := NewIndex(testProviderSchemas(
+		"registry.terraform.io/hashicorp/aws",
+		"registry.terraform.io/hashicorp/null",
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewStorage()
+	s.index = idx
+
+	resources, err := s.Resources()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resources) != 2 {
+		t.Fatalf("expected 2 resources, given: %#v", resources)
+	}
+	if _, err := s.DataSources(); err != nil {
+		t.Fatal(err)
+	}
+
+	for addr, ps := range idx.providers {
+		if ps.schema != nil {
+			t.Fatalf("expected schema of %q not to be decoded", addr)
+		}
+	}
+}
+
+func TestNewIndex_invalidProviderSchema(t *testing.T) {
+	_, err := NewIndex(&RawProviderSchemas{
+		FormatVersion: "0.1",
+		Schemas: map[string]json.RawMessage{
+			"registry.terraform.io/hashicorp/aws": json.RawMessage(`[]`),
+		},
+	})
+	if err == nil {
+		t.Fatal("expected invalid provider schema to fail")
+	}
+}
+
+// benchmarkProviders mimics a module mixing five large providers
+var benchmarkProviders = []string{
+	"registry.terraform.io/hashicorp/aws",
+	"registry.terraform.io/hashicorp/azurerm",
+	"registry.terraform.io/hashicorp/google",
+	"registry.terraform.io/hashicorp/kubernetes",
+	"registry.terraform.io/hashicorp/random",
+}
+
+func BenchmarkNewIndex(b *testing.B) {
+	raw := benchmarkProviderSchemas(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_, err := NewIndex(raw)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeProviderSchemas(b *testing.B) {
+	raw := benchmarkProviderSchemas(b)
+	out, err := json.Marshal(raw)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		var ps tfjson.ProviderSchemas
+		err := json.Unmarshal(out, &ps)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStorage_ResourceSchema(b *testing.B) {
+	idx, err := NewIndex(benchmarkProviderSchemas(b))
+	if err != nil {
+		b.Fatal(err)
+	}
+	s := NewStorage()
+	s.index = idx
+
+	// decode the provider upfront to measure lookup only
+	if _, err := s.ResourceSchema("random_resource_499"); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_, err := s.ResourceSchema("random_resource_499")
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStorage_memory(b *testing.B) {
+	raw := benchmarkProviderSchemas(b)
+
+	b.Run("index", func(b *testing.B) {
+		reportHeapInUse(b, func() interface{} {
+			idx, err := NewIndex(raw)
+			if err != nil {
+				b.Fatal(err)
+			}
+			s := NewStorage()
+			s.index = idx
+			_, err = s.ResourceSchema("aws_resource_0")
+			if err != nil {
+				b.Fatal(err)
+			}
+			return s
+		})
+	})
+	b.Run("decoded", func(b *testing.B) {
+		out, err := json.Marshal(raw)
+		if err != nil {
+			b.Fatal(err)
+		}
+		reportHeapInUse(b, func() interface{} {
+			var ps tfjson.ProviderSchemas
+			err := json.Unmarshal(out, &ps)
+			if err != nil {
+				b.Fatal(err)
+			}
+			return &ps
+		})
+	})
+}
+
+// reportHeapInUse reports heap memory retained by the value
+// returned from f, excluding garbage produced along the way
+func reportHeapInUse(b *testing.B, f func() interface{}) {
+	var total uint64
+	for n := 0; n < b.N; n++ {
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+
+		v := f()
+
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		runtime.KeepAlive(v)
+
+		if after.HeapAlloc > before.HeapAlloc {
+			total += after.HeapAlloc - before.HeapAlloc
+		}
+	}
+	b.ReportMetric(float64(total)/float64(b.N), "heap-B/op")
+}
+
+func benchmarkProviderSchemas(b *testing.B) *RawProviderSchemas {
+	ps := &RawProviderSchemas{
+		FormatVersion: "0.1",
+		Schemas:       make(map[string]json.RawMessage, 0),
+	}
+	for _, addr := range benchmarkProviders {
+		schema := &tfjson.ProviderSchema{
+			ConfigSchema:      benchmarkSchema(10),
+			ResourceSchemas:   make(map[string]*tfjson.Schema, 0),
+			DataSourceSchemas: make(map[string]*tfjson.Schema, 0),
+		}
+		name := providerLocalName(addr)
+		for i := 0; i < 500; i++ {
+			schema.ResourceSchemas[fmt.Sprintf("%s_resource_%d", name, i)] = benchmarkSchema(20)
+		}
+		for i := 0; i < 200; i++ {
+			schema.DataSourceSchemas[fmt.Sprintf("%s_data_%d", name, i)] = benchmarkSchema(20)
+		}
+
+		out, err := json.Marshal(schema)
+		if err != nil {
+			b.Fatal(err)
+		}
+		ps.Schemas[addr] = out
+	}
+	return ps
+}
+
+func benchmarkSchema(attributes int) *tfjson.Schema {
+	block := &tfjson.SchemaBlock{
+		Description: "Lorem ipsum dolor sit amet",
+		Attributes:  make(map[string]*tfjson.SchemaAttribute, attributes),
+	}
+	for i := 0; i < attributes; i++ {
+		block.Attributes[fmt.Sprintf("attribute_%d", i)] = &tfjson.SchemaAttribute{
+			AttributeType: cty.String,
+			Description:   "Consectetur adipiscing elit",
+			Optional:      true,
+		}
+	}
+	return &tfjson.Schema{Block: block}
+}
@@ -0,0 +1,270 @@
+package schema
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// CacheKey identifies provider schemas of a root module
+type CacheKey struct {
+	// LockFileSum is the (hex-encoded) checksum of the plugin lock file
+	LockFileSum string
+
+	// TerraformVersion is the version of Terraform providing
+	// built-in providers (e.g. terraform.io/builtin/terraform)
+	TerraformVersion string
+
+	// ProviderVersions maps provider addresses, as used in keys
+	// of provider schemas, to versions selected in the lock file
+	// (empty if versions are not recorded, as in Terraform 0.12)
+	ProviderVersions map[string]string
+}
+
+// Cache stores provider schemas on disk, such that these
+// can be reused across root modules and restarts without
+// running Terraform.
+//
+// Schemas of individual providers are stored by checksum of their
+// content and referenced by provider address and version, and by
+// checksum of the lock file from which they were obtained.
+type Cache struct {
+	dir    string
+	logger *log.Logger
+}
+
+// cachedProviderSchema is stored for each provider, as individual
+// provider schemas don't carry the format version
+type cachedProviderSchema struct {
+	FormatVersion string          `json:"format_version"`
+	Schema        json.RawMessage `json:"schema"`
+}
+
+// blobRefs maps keys of provider schemas to checksums of cached schemas
+type blobRefs map[string]string
+
+// DefaultCacheDir returns the directory within
+// the user cache directory to cache schemas in
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "terraform-ls", "schemas"), nil
+}
+
+func NewCache(dir string) *Cache {
+	return &Cache{
+		dir:    dir,
+		logger: defaultLogger,
+	}
+}
+
+func (c *Cache) SetLogger(logger *log.Logger) {
+	c.logger = logger
+}
+
+// Get returns schemas cached under the given lock file checksum,
+// or assembles these from schemas of the providers in selected
+// versions, if all of them are cached
+func (c *Cache) Get(key CacheKey) (*RawProviderSchemas, bool) {
+	if key.LockFileSum != "" {
+		var refs blobRefs
+		if c.readJSON(c.lockFilePath(key), &refs) {
+			if ps, ok := c.assemble(refs); ok {
+				return ps, true
+			}
+		}
+	}
+
+	if len(key.ProviderVersions) == 0 || key.TerraformVersion == "" {
+		return nil, false
+	}
+
+	// built-in providers are recorded (even if there are none)
+	// for any Terraform version schemas were obtained from
+	refs := make(blobRefs, 0)
+	if !c.readJSON(c.builtinProvidersPath(key.TerraformVersion), &refs) {
+		return nil, false
+	}
+	for addr, version := range key.ProviderVersions {
+		pa, err := ParseProviderAddress(addr)
+		if err != nil {
+			return nil, false
+		}
+		sum, err := ioutil.ReadFile(c.providerPath(pa, version))
+		if err != nil {
+			return nil, false
+		}
+		refs[addr] = string(sum)
+	}
+
+	ps, ok := c.assemble(refs)
+	if !ok {
+		return nil, false
+	}
+
+	if key.LockFileSum != "" {
+		err := c.writeJSON(c.lockFilePath(key), refs)
+		if err != nil {
+			c.logger.Printf("failed to cache schema references: %s", err)
+		}
+	}
+
+	return ps, true
+}
+
+// Put stores the given schemas under the key
+func (c *Cache) Put(key CacheKey, ps *RawProviderSchemas) error {
+	refs := make(blobRefs, 0)
+	builtinRefs := make(blobRefs, 0)
+
+	for addr, schema := range ps.Schemas {
+		sum, err := c.putBlob(&cachedProviderSchema{
+			FormatVersion: ps.FormatVersion,
+			Schema:        schema,
+		})
+		if err != nil {
+			return err
+		}
+		refs[addr] = sum
+
+		pa, err := ParseProviderAddress(addr)
+		if err != nil {
+			continue
+		}
+		if pa.Namespace == "builtin" {
+			builtinRefs[addr] = sum
+			continue
+		}
+		version, ok := key.ProviderVersions[addr]
+		if !ok {
+			continue
+		}
+		err = c.writeFile(c.providerPath(pa, version), []byte(sum))
+		if err != nil {
+			return err
+		}
+	}
+
+	if key.TerraformVersion != "" && len(key.ProviderVersions) > 0 {
+		err := c.writeJSON(c.builtinProvidersPath(key.TerraformVersion), builtinRefs)
+		if err != nil {
+			return err
+		}
+	}
+
+	if key.LockFileSum != "" {
+		return c.writeJSON(c.lockFilePath(key), refs)
+	}
+
+	return nil
+}
+
+func (c *Cache) assemble(refs blobRefs) (*RawProviderSchemas, bool) {
+	ps := &RawProviderSchemas{
+		Schemas: make(map[string]json.RawMessage, len(refs)),
+	}
+	for addr, sum := range refs {
+		var cps cachedProviderSchema
+		if !c.readJSON(c.blobPath(sum), &cps) || cps.Schema == nil {
+			return nil, false
+		}
+		ps.FormatVersion = cps.FormatVersion
+		ps.Schemas[addr] = cps.Schema
+	}
+	return ps, true
+}
+
+func (c *Cache) putBlob(cps *cachedProviderSchema) (string, error) {
+	b, err := json.Marshal(cps)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.Sum256(b)
+	sum := hex.EncodeToString(h[:])
+
+	path := c.blobPath(sum)
+	if _, err := os.Stat(path); err == nil {
+		// content-addressed, so already cached
+		return sum, nil
+	}
+
+	return sum, c.writeFile(path, b)
+}
+
+func (c *Cache) blobPath(sum string) string {
+	return filepath.Join(c.dir, "blobs", sum+".json")
+}
+
+// lockFilePath returns path to references of schemas obtained
+// for the lock file, which may differ between Terraform versions
+// due to built-in providers
+func (c *Cache) lockFilePath(key CacheKey) string {
+	return filepath.Join(c.dir, "locks", key.TerraformVersion, key.LockFileSum+".json")
+}
+
+func (c *Cache) providerPath(pa ProviderAddress, version string) string {
+	return filepath.Join(c.dir, "providers", pa.Hostname, pa.Namespace, pa.Type, version)
+}
+
+func (c *Cache) builtinProvidersPath(tfVersion string) string {
+	return filepath.Join(c.dir, "builtin", tfVersion+".json")
+}
+
+func (c *Cache) readJSON(path string, v interface{}) bool {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			c.logger.Printf("failed to read cached schema: %s", err)
+		}
+		return false
+	}
+	err = json.Unmarshal(b, v)
+	if err != nil {
+		c.logger.Printf("failed to decode cached schema %s: %s", path, err)
+		return false
+	}
+	return true
+}
+
+func (c *Cache) writeJSON(path string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.writeFile(path, b)
+}
+
+// writeFile writes the file atomically, as the cache
+// may be shared by multiple language server processes
+func (c *Cache) writeFile(path string, b []byte) error {
+	dir := filepath.Dir(path)
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create cache dir: %w", err)
+	}
+
+	f, err := ioutil.TempFile(dir, filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(b)
+	if err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	err = f.Close()
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,156 @@
+package schema
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+func TestCache_lockFileSum(t *testing.T) {
+	c := NewCache(tempCacheDir(t))
+
+	key := CacheKey{
+		LockFileSum:      "abc",
+		TerraformVersion: "0.12.29",
+	}
+	_, ok := c.Get(key)
+	if ok {
+		t.Fatal("expected empty cache to miss")
+	}
+
+	ps := testProviderSchemas("aws", "null")
+	err := c.Put(key, ps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cached, ok := c.Get(key)
+	if !ok {
+		t.Fatal("expected schemas to be cached")
+	}
+	if diff := cmp.Diff(ps, cached); diff != "" {
+		t.Fatalf("cached schemas don't match: %s", diff)
+	}
+
+	_, ok = c.Get(CacheKey{LockFileSum: "def", TerraformVersion: "0.12.29"})
+	if ok {
+		t.Fatal("expected different lock file to miss")
+	}
+	_, ok = c.Get(CacheKey{LockFileSum: "abc", TerraformVersion: "0.12.30"})
+	if ok {
+		t.Fatal("expected different Terraform version to miss")
+	}
+}
+
+func TestCache_providerVersions(t *testing.T) {
+	c := NewCache(tempCacheDir(t))
+
+	ps := testProviderSchemas(
+		"registry.terraform.io/hashicorp/aws",
+		"registry.terraform.io/hashicorp/null",
+		"terraform.io/builtin/terraform",
+	)
+	err := c.Put(CacheKey{
+		LockFileSum:      "abc",
+		TerraformVersion: "0.14.0",
+		ProviderVersions: map[string]string{
+			"registry.terraform.io/hashicorp/aws":  "3.10.0",
+			"registry.terraform.io/hashicorp/null": "3.0.0",
+		},
+	}, ps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// another root module with a subset of the same providers
+	key := CacheKey{
+		LockFileSum:      "def",
+		TerraformVersion: "0.14.0",
+		ProviderVersions: map[string]string{
+			"registry.terraform.io/hashicorp/null": "3.0.0",
+		},
+	}
+	cached, ok := c.Get(key)
+	if !ok {
+		t.Fatal("expected schemas to be assembled from cached providers")
+	}
+	expected := testProviderSchemas(
+		"registry.terraform.io/hashicorp/null",
+		"terraform.io/builtin/terraform",
+	)
+	if diff := cmp.Diff(expected, cached); diff != "" {
+		t.Fatalf("cached schemas don't match: %s", diff)
+	}
+
+	// references are recorded for the new lock file
+	cached, ok = c.Get(CacheKey{LockFileSum: "def", TerraformVersion: "0.14.0"})
+	if !ok {
+		t.Fatal("expected schemas to be cached under lock file checksum")
+	}
+	if diff := cmp.Diff(expected, cached); diff != "" {
+		t.Fatalf("cached schemas don't match: %s", diff)
+	}
+
+	_, ok = c.Get(CacheKey{
+		LockFileSum:      "ghi",
+		TerraformVersion: "0.14.0",
+		ProviderVersions: map[string]string{
+			"registry.terraform.io/hashicorp/null": "3.0.1",
+		},
+	})
+	if ok {
+		t.Fatal("expected different provider version to miss")
+	}
+
+	_, ok = c.Get(CacheKey{
+		LockFileSum:      "jkl",
+		TerraformVersion: "0.14.1",
+		ProviderVersions: map[string]string{
+			"registry.terraform.io/hashicorp/null": "3.0.0",
+		},
+	})
+	if ok {
+		t.Fatal("expected different Terraform version to miss")
+	}
+}
+
+func tempCacheDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "terraform-ls-schemas")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+	return dir
+}
+
+func testProviderSchemas(addrs ...string) *RawProviderSchemas {
+	ps := &RawProviderSchemas{
+		FormatVersion: "0.1",
+		Schemas:       make(map[string]json.RawMessage, 0),
+	}
+	for _, addr := range addrs {
+		b, err := json.Marshal(&tfjson.ProviderSchema{
+			ConfigSchema: &tfjson.Schema{
+				Block: &tfjson.SchemaBlock{
+					Description: addr + " provider",
+				},
+			},
+			ResourceSchemas: map[string]*tfjson.Schema{
+				providerLocalName(addr) + "_thing": {
+					Block: &tfjson.SchemaBlock{},
+				},
+			},
+		})
+		if err != nil {
+			panic(err)
+		}
+		ps.Schemas[addr] = b
+	}
+	return ps
+}
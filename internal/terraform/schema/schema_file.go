@@ -0,0 +1,35 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// ReadProviderSchemasFiles reads provider schemas from files generated
+// via terraform providers schema -json, merging these into one index
+// where schemas in later files take precedence
+func ReadProviderSchemasFiles(paths []string) (*Index, error) {
+	merged := &RawProviderSchemas{
+		Schemas: make(map[string]json.RawMessage, 0),
+	}
+
+	for _, path := range paths {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		ps, err := ParseRawProviderSchemas(b)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse provider schemas in %s: %w", path, err)
+		}
+
+		merged.FormatVersion = ps.FormatVersion
+		for addr, schema := range ps.Schemas {
+			merged.Schemas[addr] = schema
+		}
+	}
+
+	return NewIndex(merged)
+}
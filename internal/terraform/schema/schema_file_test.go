@@ -0,0 +1,85 @@
+package schema
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadProviderSchemasFiles(t *testing.T) {
+	dir := tempCacheDir(t)
+
+	first := filepath.Join(dir, "first.json")
+	writeTestFile(t, first, `{
+  "format_version": "0.1",
+  "provider_schemas": {
+    "registry.terraform.io/hashicorp/aws": {
+      "provider": {"version": 0, "block": {"description": "first"}}
+    }
+  }
+}`)
+	second := filepath.Join(dir, "second.json")
+	writeTestFile(t, second, `{
+  "format_version": "0.1",
+  "provider_schemas": {
+    "registry.terraform.io/hashicorp/aws": {
+      "provider": {"version": 0, "block": {"description": "second"}}
+    },
+    "registry.terraform.io/hashicorp/null": {
+      "provider": {"version": 0, "block": {}}
+    }
+  }
+}`)
+
+	ps, err := ReadProviderSchemasFiles([]string{first, second})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewStorage()
+	s.SetFallbackSchemas(ps)
+
+	providers, err := s.Providers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(providers)
+	if diff := cmp.Diff([]string{"aws", "null"}, providers); diff != "" {
+		t.Fatalf("providers don't match: %s", diff)
+	}
+
+	schema, err := s.ProviderConfigSchema("aws")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schema.Block.Description != "second" {
+		t.Fatalf("expected schema from the later file, given: %q", schema.Block.Description)
+	}
+}
+
+func TestReadProviderSchemasFiles_invalid(t *testing.T) {
+	dir := tempCacheDir(t)
+
+	invalid := filepath.Join(dir, "invalid.json")
+	writeTestFile(t, invalid, `{"format_version": "99.0"}`)
+
+	_, err := ReadProviderSchemasFiles([]string{invalid})
+	if err == nil {
+		t.Fatal("expected unsupported format version to fail")
+	}
+
+	_, err = ReadProviderSchemasFiles([]string{filepath.Join(dir, "missing.json")})
+	if err == nil {
+		t.Fatal("expected missing file to fail")
+	}
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,81 @@
+package schema
+
+import (
+	"sync"
+)
+
+// Registry deduplicates schemas of providers across root modules
+// which use identical provider versions, such that only one copy
+// of each schema is held in memory for the lifetime of the process.
+//
+// Schemas are reference-counted and dropped once no storage
+// refers to them anymore.
+type Registry struct {
+	mu      *sync.Mutex
+	entries map[registryKey]*registryEntry
+}
+
+type registryKey struct {
+	address string
+	version string
+}
+
+type registryEntry struct {
+	schema *providerSchema
+	refs   int
+}
+
+var defaultRegistry = NewRegistry()
+
+// DefaultRegistry returns the registry shared within the process
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		mu:      &sync.Mutex{},
+		entries: make(map[registryKey]*registryEntry, 0),
+	}
+}
+
+// acquire returns the registered schema of the provider in the given
+// version, registering the given schema if there is none yet
+func (r *Registry) acquire(address, version string, schema *providerSchema) *providerSchema {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := registryKey{address, version}
+	entry, ok := r.entries[key]
+	if !ok {
+		entry = &registryEntry{schema: schema}
+		r.entries[key] = entry
+	}
+	entry.refs++
+
+	return entry.schema
+}
+
+// release drops a reference to the schema of the provider
+// in the given version, as previously acquired
+func (r *Registry) release(address, version string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := registryKey{address, version}
+	entry, ok := r.entries[key]
+	if !ok {
+		return
+	}
+	entry.refs--
+	if entry.refs <= 0 {
+		delete(r.entries, key)
+	}
+}
+
+// Len returns the number of registered schemas
+func (r *Registry) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.entries)
+}
@@ -0,0 +1,94 @@
+package schema
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRegistry_acquireRelease(t *testing.T) {
+	r := NewRegistry()
+
+	first := &providerSchema{}
+	second := &providerSchema{}
+
+	if s := r.acquire("hashicorp/aws", "3.20.0", first); s != first {
+		t.Fatal("expected first schema to be registered")
+	}
+	if s := r.acquire("hashicorp/aws", "3.20.0", second); s != first {
+		t.Fatal("expected schema in identical version to be shared")
+	}
+	if s := r.acquire("hashicorp/aws", "3.21.0", second); s != second {
+		t.Fatal("expected schema in different version to be registered")
+	}
+	if r.Len() != 2 {
+		t.Fatalf("expected 2 schemas registered, given: %d", r.Len())
+	}
+
+	r.release("hashicorp/aws", "3.20.0")
+	r.release("hashicorp/aws", "3.21.0")
+	if r.Len() != 1 {
+		t.Fatalf("expected 1 schema registered, given: %d", r.Len())
+	}
+
+	r.release("hashicorp/aws", "3.20.0")
+	if r.Len() != 0 {
+		t.Fatalf("expected no schemas registered, given: %d", r.Len())
+	}
+
+	// unknown references are ignored
+	r.release("hashicorp/aws", "3.20.0")
+}
+
+func TestStorage_sharedSchemas(t *testing.T) {
+	c := NewCache(tempCacheDir(t))
+	r := NewRegistry()
+
+	awsAddr := "registry.terraform.io/hashicorp/aws"
+	nullAddr := "registry.terraform.io/hashicorp/null"
+	err := c.Put(CacheKey{
+		LockFileSum:      "abc",
+		TerraformVersion: "0.14.0",
+		ProviderVersions: map[string]string{
+			awsAddr:  "3.20.0",
+			nullAddr: "3.0.0",
+		},
+	}, testProviderSchemas(awsAddr, nullAddr, "terraform.io/builtin/terraform"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storages := make([]*Storage, 0)
+	for _, sum := range []string{"abc", "def"} {
+		s := NewStorage()
+		s.SetCache(c)
+		s.SetRegistry(r)
+		err := s.ObtainCachedSchemasForModule(context.Background(), nil, "dir-"+sum, CacheKey{
+			LockFileSum:      sum,
+			TerraformVersion: "0.14.0",
+			ProviderVersions: map[string]string{
+				awsAddr: "3.20.0",
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		storages = append(storages, s)
+	}
+
+	if storages[0].index.providers[awsAddr] != storages[1].index.providers[awsAddr] {
+		t.Fatal("expected schema of aws provider to be shared")
+	}
+	if storages[0].index.providers[nullAddr] == nil {
+		t.Fatal("expected schema of null provider (unknown version) to be kept")
+	}
+	if r.Len() != 2 {
+		t.Fatalf("expected aws and built-in provider to be registered, given: %d", r.Len())
+	}
+
+	for _, s := range storages {
+		s.ReleaseSchemas()
+	}
+	if r.Len() != 0 {
+		t.Fatalf("expected no schemas registered after release, given: %d", r.Len())
+	}
+}
@@ -17,6 +17,7 @@ import (
 type Reader interface {
 	ProviderConfigSchema(name string) (*tfjson.Schema, error)
 	Providers() ([]string, error)
+	ProviderAddresses() ([]ProviderAddress, error)
 	ResourceSchema(rType string) (*tfjson.Schema, error)
 	Resources() ([]Resource, error)
 	DataSourceSchema(dsType string) (*tfjson.Schema, error)
@@ -25,29 +26,36 @@ type Reader interface {
 
 type Writer interface {
 	ObtainSchemasForModule(context.Context, *exec.Executor, string) error
+	ObtainCachedSchemasForModule(context.Context, *exec.Executor, string, CacheKey) error
 }
 
 type Resource struct {
-	Name            string
-	Provider        string
-	Description     string
-	DescriptionKind tfjson.SchemaDescriptionKind
+	Name     string
+	Provider string
 }
 
 type DataSource struct {
-	Name            string
-	Provider        string
-	Description     string
-	DescriptionKind tfjson.SchemaDescriptionKind
+	Name     string
+	Provider string
 }
 
 type StorageFactory func() *Storage
 
 type Storage struct {
-	ps *tfjson.ProviderSchemas
+	index *Index
 
+	// fallback is used when no schemas could be obtained
+	// e.g. because terraform init hasn't been run yet
+	fallback *Index
+
+	cache  *Cache
 	logger *log.Logger
 
+	// registry shares schemas of providers in identical
+	// versions, registered maps their addresses to versions
+	registry   *Registry
+	registered map[string]string
+
 	// sem ensures atomic reading and obtaining of schemas
 	// as the process of obtaining it may not be thread-safe
 	sem *semaphore.Weighted
@@ -57,8 +65,9 @@ var defaultLogger = log.New(ioutil.Discard, "", 0)
 
 func NewStorage() *Storage {
 	return &Storage{
-		logger: defaultLogger,
-		sem:    semaphore.NewWeighted(1),
+		logger:     defaultLogger,
+		registered: make(map[string]string, 0),
+		sem:        semaphore.NewWeighted(1),
 	}
 }
 
@@ -104,13 +113,40 @@ func (s *Storage) SetLogger(logger *log.Logger) {
 	s.logger = logger
 }
 
+// SetCache makes the storage reuse schemas cached on disk
+// when obtaining schemas via ObtainCachedSchemasForModule
+func (s *Storage) SetCache(c *Cache) {
+	s.cache = c
+}
+
+// SetFallbackSchemas makes the storage provide the given schemas
+// until schemas are obtained for the module
+func (s *Storage) SetFallbackSchemas(idx *Index) {
+	s.fallback = idx
+}
+
+// SetRegistry makes the storage share schemas of providers
+// with other storages when obtaining schemas
+// via ObtainCachedSchemasForModule
+func (s *Storage) SetRegistry(r *Registry) {
+	s.registry = r
+}
+
 // ObtainSchemasForModule will obtain schema via tf
 // and store it for later consumption via Reader methods
 func (s *Storage) ObtainSchemasForModule(ctx context.Context, tf *exec.Executor, dir string) error {
-	return s.obtainSchemasForModule(ctx, tf, dir)
+	return s.obtainSchemasForModule(ctx, tf, dir, nil)
+}
+
+// ObtainCachedSchemasForModule will obtain schema from the cache
+// (if available under the given key) or via tf otherwise,
+// storing it in the cache for later reuse and sharing schemas
+// of providers in versions recorded in the key via the registry
+func (s *Storage) ObtainCachedSchemasForModule(ctx context.Context, tf *exec.Executor, dir string, key CacheKey) error {
+	return s.obtainSchemasForModule(ctx, tf, dir, &key)
 }
 
-func (s *Storage) obtainSchemasForModule(ctx context.Context, tf *exec.Executor, dir string) error {
+func (s *Storage) obtainSchemasForModule(ctx context.Context, tf *exec.Executor, dir string, key *CacheKey) error {
 	s.logger.Printf("Acquiring semaphore before retrieving schema for %q ...", dir)
 	err := s.sem.Acquire(context.Background(), 1)
 	if err != nil {
@@ -118,20 +154,109 @@ func (s *Storage) obtainSchemasForModule(ctx context.Context, tf *exec.Executor,
 	}
 	defer s.sem.Release(1)
 
+	if s.cache != nil && key != nil {
+		if raw, ok := s.cache.Get(*key); ok {
+			err = s.setSchemas(raw, key)
+			if err == nil {
+				s.logger.Printf("Schemas for %q found in cache", dir)
+				return nil
+			}
+			s.logger.Printf("Failed to index cached schemas for %q: %s", dir, err)
+		}
+	}
+
 	tf.SetWorkdir(dir)
 
 	s.logger.Printf("Retrieving schemas for %q ...", dir)
 	start := time.Now()
-	ps, err := tf.ProviderSchemas(ctx)
+	b, err := tf.ProviderSchemasJSON(ctx)
 	if err != nil {
 		return fmt.Errorf("Unable to retrieve schemas for %q: %w", dir, err)
 	}
-	s.ps = ps
+	raw, err := ParseRawProviderSchemas(b)
+	if err != nil {
+		return fmt.Errorf("Unable to parse schemas for %q: %w", dir, err)
+	}
 	s.logger.Printf("Schemas retrieved for %q in %s", dir, time.Since(start))
+
+	err = s.setSchemas(raw, key)
+	if err != nil {
+		return fmt.Errorf("Unable to index schemas for %q: %w", dir, err)
+	}
+
+	if s.cache != nil && key != nil {
+		err = s.cache.Put(*key, raw)
+		if err != nil {
+			// cache is just an optimization
+			s.logger.Printf("Failed to cache schemas for %q: %s", dir, err)
+		}
+	}
+
 	return nil
 }
 
-func (s *Storage) schema() (*tfjson.ProviderSchemas, error) {
+// setSchemas indexes and replaces schemas held by the storage, sharing
+// schemas of providers in known versions via the registry (if any)
+func (s *Storage) setSchemas(raw *RawProviderSchemas, key *CacheKey) error {
+	idx, err := NewIndex(raw)
+	if err != nil {
+		return err
+	}
+
+	s.releaseSchemas()
+
+	if s.registry == nil || key == nil {
+		s.index = idx
+		return nil
+	}
+
+	shared := make(map[string]*providerSchema, len(idx.providers))
+	for addr, ps := range idx.providers {
+		version, ok := registryVersion(addr, key)
+		if !ok {
+			shared[addr] = ps
+			continue
+		}
+		shared[addr] = s.registry.acquire(addr, version, ps)
+		s.registered[addr] = version
+	}
+	s.index = idx.withProviders(shared)
+
+	return nil
+}
+
+// registryVersion returns version under which the schema
+// of the given provider is shared, which is the Terraform version
+// in case of built-in providers
+func registryVersion(addr string, key *CacheKey) (string, bool) {
+	if version, ok := key.ProviderVersions[addr]; ok && version != "" {
+		return version, true
+	}
+	pa, err := ParseProviderAddress(addr)
+	if err == nil && pa.Namespace == "builtin" && key.TerraformVersion != "" {
+		return key.TerraformVersion, true
+	}
+	return "", false
+}
+
+// ReleaseSchemas drops schemas held by the storage,
+// releasing these from the registry (if any)
+func (s *Storage) ReleaseSchemas() {
+	s.sem.Acquire(context.Background(), 1)
+	defer s.sem.Release(1)
+
+	s.releaseSchemas()
+	s.index = nil
+}
+
+func (s *Storage) releaseSchemas() {
+	for addr, version := range s.registered {
+		s.registry.release(addr, version)
+	}
+	s.registered = make(map[string]string, 0)
+}
+
+func (s *Storage) schema() (*Index, error) {
 	s.logger.Println("Acquiring semaphore before reading schema")
 	acquired := s.sem.TryAcquire(1)
 	if !acquired {
@@ -139,26 +264,28 @@ func (s *Storage) schema() (*tfjson.ProviderSchemas, error) {
 	}
 	defer s.sem.Release(1)
 
-	if s.ps == nil {
+	if s.index == nil {
+		if s.fallback != nil {
+			return s.fallback, nil
+		}
 		return nil, &NoSchemaAvailableErr{}
 	}
-	return s.ps, nil
+	return s.index, nil
 }
 
 func (s *Storage) ProviderConfigSchema(name string) (*tfjson.Schema, error) {
 	s.logger.Printf("Reading %q provider schema", name)
 
-	ps, err := s.schema()
+	idx, err := s.schema()
 	if err != nil {
 		return nil, err
 	}
 
-	schema, ok := ps.Schemas[name]
-	if !ok {
-		return nil, &SchemaUnavailableErr{"provider", name}
+	schema, ok, err := idx.provider(name)
+	if err != nil {
+		return nil, err
 	}
-
-	if schema.ConfigSchema == nil {
+	if !ok || schema.ConfigSchema == nil {
 		return nil, &SchemaUnavailableErr{"provider", name}
 	}
 
@@ -166,56 +293,78 @@ func (s *Storage) ProviderConfigSchema(name string) (*tfjson.Schema, error) {
 }
 
 func (s *Storage) Providers() ([]string, error) {
-	ps, err := s.schema()
+	idx, err := s.schema()
 	if err != nil {
 		return nil, err
 	}
 
-	providers := make([]string, 0)
-	for name := range ps.Schemas {
+	providers := make([]string, 0, len(idx.localNames))
+	for name := range idx.localNames {
 		providers = append(providers, name)
 	}
 
 	return providers, nil
 }
 
+// ProviderAddresses returns addresses of all providers
+// with schemas available, such that they can be used
+// as source addresses in Terraform 0.13+
+func (s *Storage) ProviderAddresses() ([]ProviderAddress, error) {
+	idx, err := s.schema()
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := make([]ProviderAddress, 0)
+	for _, key := range idx.Addresses() {
+		pa, err := ParseProviderAddress(key)
+		if err != nil {
+			s.logger.Printf("skipping provider: %s", err)
+			continue
+		}
+		addrs = append(addrs, pa)
+	}
+
+	return addrs, nil
+}
+
 func (s *Storage) ResourceSchema(rType string) (*tfjson.Schema, error) {
-	// TODO: this is going to need to use provider identities, especially in 0.13
 	s.logger.Printf("Reading %q resource schema", rType)
 
-	ps, err := s.schema()
+	idx, err := s.schema()
 	if err != nil {
 		return nil, err
 	}
 
-	// Vast majority of resources should follow naming convention
-	// of <provider>_resource_name, but this is not enforced
-	// in any way so we have to check all providers
-	for _, schema := range ps.Schemas {
-		rSchema, ok := schema.ResourceSchemas[rType]
-		if ok {
-			return rSchema, nil
-		}
+	addr, ok := idx.resources[rType]
+	if !ok {
+		return nil, &SchemaUnavailableErr{"resource", rType}
+	}
+	schema, ok, err := idx.decodedProvider(addr)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, &SchemaUnavailableErr{"resource", rType}
 	}
 
-	return nil, &SchemaUnavailableErr{"resource", rType}
+	return schema.ResourceSchemas[rType], nil
 }
 
+// Resources returns all known resource types, which are taken
+// from the index, so that no schemas need to be decoded
 func (s *Storage) Resources() ([]Resource, error) {
-	ps, err := s.schema()
+	idx, err := s.schema()
 	if err != nil {
 		return nil, err
 	}
 
-	resources := make([]Resource, 0)
-	for provider, schema := range ps.Schemas {
-		for name, r := range schema.ResourceSchemas {
-			resources = append(resources, Resource{
-				Provider:    provider,
-				Name:        name,
-				Description: r.Block.Description,
-			})
-		}
+	resources := make([]Resource, 0, len(idx.resources))
+	for name, provider := range idx.resources {
+		resources = append(resources, Resource{
+			Provider: providerLocalName(provider),
+			Name:     name,
+		})
 	}
 
 	return resources, nil
@@ -224,39 +373,40 @@ func (s *Storage) Resources() ([]Resource, error) {
 func (s *Storage) DataSourceSchema(dsType string) (*tfjson.Schema, error) {
 	s.logger.Printf("Reading %q datasource schema", dsType)
 
-	ps, err := s.schema()
+	idx, err := s.schema()
 	if err != nil {
 		return nil, err
 	}
 
-	// Vast majority of Datasources should follow naming convention
-	// of <provider>_datasource_name, but this is not enforced
-	// in any way so we have to check all providers
-	for _, schema := range ps.Schemas {
-		rSchema, ok := schema.DataSourceSchemas[dsType]
-		if ok {
-			return rSchema, nil
-		}
+	addr, ok := idx.dataSources[dsType]
+	if !ok {
+		return nil, &SchemaUnavailableErr{"data", dsType}
+	}
+	schema, ok, err := idx.decodedProvider(addr)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, &SchemaUnavailableErr{"data", dsType}
 	}
 
-	return nil, &SchemaUnavailableErr{"data", dsType}
+	return schema.DataSourceSchemas[dsType], nil
 }
 
+// DataSources returns all known data source types, which are taken
+// from the index, so that no schemas need to be decoded
 func (s *Storage) DataSources() ([]DataSource, error) {
-	ps, err := s.schema()
+	idx, err := s.schema()
 	if err != nil {
 		return nil, err
 	}
 
-	dataSources := make([]DataSource, 0)
-	for provider, schema := range ps.Schemas {
-		for name, d := range schema.DataSourceSchemas {
-			dataSources = append(dataSources, DataSource{
-				Provider:    provider,
-				Name:        name,
-				Description: d.Block.Description,
-			})
-		}
+	dataSources := make([]DataSource, 0, len(idx.dataSources))
+	for name, provider := range idx.dataSources {
+		dataSources = append(dataSources, DataSource{
+			Provider: providerLocalName(provider),
+			Name:     name,
+		})
 	}
 
 	return dataSources, nil
@@ -10,7 +10,7 @@ func MockStorage(ps *tfjson.ProviderSchemas) StorageFactory {
 		if ps == nil {
 			ps = &tfjson.ProviderSchemas{}
 		}
-		s.ps = ps
+		s.index = newDecodedIndex(ps)
 		return s
 	}
 }
@@ -43,6 +43,13 @@ func (r *MockReader) Providers() ([]string, error) {
 	return r.storage().Providers()
 }
 
+func (r *MockReader) ProviderAddresses() ([]ProviderAddress, error) {
+	if r.ProviderSchemaErr != nil {
+		return nil, r.ProviderSchemaErr
+	}
+	return r.storage().ProviderAddresses()
+}
+
 func (r *MockReader) ResourceSchema(rType string) (*tfjson.Schema, error) {
 	if r.ResourceSchemaErr != nil {
 		return nil, r.ResourceSchemaErr
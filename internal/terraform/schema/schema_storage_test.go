@@ -3,9 +3,11 @@ package schema
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
 	tferr "github.com/hashicorp/terraform-ls/internal/terraform/errors"
 )
 
@@ -141,3 +143,43 @@ func TestResources_noSchema(t *testing.T) {
 		t.Fatalf("Error doesn't match: %s", diff)
 	}
 }
+
+func TestProviders_fullyQualifiedAddresses(t *testing.T) {
+	s := MockStorage(&tfjson.ProviderSchemas{
+		Schemas: map[string]*tfjson.ProviderSchema{
+			"registry.terraform.io/hashicorp/aws": {
+				ConfigSchema: &tfjson.Schema{},
+			},
+			"registry.terraform.io/-/null": {
+				ConfigSchema: &tfjson.Schema{},
+			},
+		},
+	})()
+
+	providers, err := s.Providers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(providers)
+	if diff := cmp.Diff([]string{"aws", "null"}, providers); diff != "" {
+		t.Fatalf("Providers don't match.\n%s", diff)
+	}
+
+	_, err = s.ProviderConfigSchema("aws")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addrs, err := s.ProviderAddresses()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sources := make([]string, 0)
+	for _, pa := range addrs {
+		sources = append(sources, pa.Source())
+	}
+	sort.Strings(sources)
+	if diff := cmp.Diff([]string{"hashicorp/aws", "hashicorp/null"}, sources); diff != "" {
+		t.Fatalf("Sources don't match.\n%s", diff)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 )
 
 func trackedFileFromPath(path string) (TrackedFile, error) {
@@ -13,7 +14,17 @@ func trackedFileFromPath(path string) (TrackedFile, error) {
 		return nil, err
 	}
 
-	b, err := fileSha256Sum(path)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var b []byte
+	if info.IsDir() {
+		b, err = dirSha256Sum(path)
+	} else {
+		b, err = fileSha256Sum(path)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -52,3 +63,30 @@ func fileSha256Sum(path string) ([]byte, error) {
 
 	return h.Sum(nil), nil
 }
+
+// dirSha256Sum computes checksum of names of entries within
+// the directory, so that only added or removed entries count
+// as a change, rather than changes of the entries themselves
+func dirSha256Sum(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	names, err := f.Readdirnames(-1)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	h := sha256.New()
+	for _, name := range names {
+		_, err := io.WriteString(h, name+"\n")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return h.Sum(nil), nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"io/ioutil"
 	"log"
+	"path/filepath"
 
 	"github.com/fsnotify/fsnotify"
 )
@@ -76,22 +77,18 @@ func (w *watcher) run(ctx context.Context) {
 			}
 
 			if event.Op&fsnotify.Write == fsnotify.Write {
-				w.logger.Printf("detected write into %s", event.Name)
-				oldTf := w.trackedFiles[event.Name]
-				newTf, err := trackedFileFromPath(event.Name)
-				if err != nil {
-					w.logger.Println("failed to track file, ignoring", err)
-					continue
+				if _, ok := w.trackedFiles[event.Name]; ok {
+					w.logger.Printf("detected write into %s", event.Name)
+					w.checkForChange(ctx, event.Name)
 				}
-				w.trackedFiles[event.Name] = newTf
-
-				if oldTf.Sha256Sum() != newTf.Sha256Sum() {
-					for _, h := range w.changeHooks {
-						err := h(ctx, newTf)
-						if err != nil {
-							w.logger.Println("change hook error:", err)
-						}
-					}
+			}
+
+			// entries created or removed within tracked directories
+			if event.Op&(fsnotify.Create|fsnotify.Remove|fsnotify.Rename) != 0 {
+				dir := filepath.Dir(event.Name)
+				if _, ok := w.trackedFiles[dir]; ok {
+					w.logger.Printf("detected %s of %s", event.Op, event.Name)
+					w.checkForChange(ctx, dir)
 				}
 			}
 		case err, ok := <-w.fw.Errors:
@@ -103,6 +100,27 @@ func (w *watcher) run(ctx context.Context) {
 	}
 }
 
+// checkForChange calls change hooks if the content
+// of the tracked file (or directory) actually changed
+func (w *watcher) checkForChange(ctx context.Context, path string) {
+	oldTf := w.trackedFiles[path]
+	newTf, err := trackedFileFromPath(path)
+	if err != nil {
+		w.logger.Println("failed to track file, ignoring", err)
+		return
+	}
+	w.trackedFiles[path] = newTf
+
+	if oldTf.Sha256Sum() != newTf.Sha256Sum() {
+		for _, h := range w.changeHooks {
+			err := h(ctx, newTf)
+			if err != nil {
+				w.logger.Println("change hook error:", err)
+			}
+		}
+	}
+}
+
 // StartWatching starts to watch for changes that were added
 // via AddPath(s) until Stop() is called
 func (w *watcher) Start() error {
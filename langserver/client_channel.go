@@ -0,0 +1,199 @@
+package langserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/creachadair/jrpc2/channel"
+)
+
+// clientChannel wraps a channel to allow the server to make requests
+// to the client (e.g. window/workDoneProgress/create), which jrpc2
+// does not support yet.
+//
+// Responses to such requests are intercepted
+// and never reach the server.
+type clientChannel struct {
+	channel.Channel
+
+	sendMu *sync.Mutex
+
+	nextID    int
+	pending   map[string]chan *clientResponse
+	pendingMu *sync.Mutex
+	closed    bool
+}
+
+func newClientChannel(ch channel.Channel) *clientChannel {
+	return &clientChannel{
+		Channel:   ch,
+		sendMu:    &sync.Mutex{},
+		pending:   make(map[string]chan *clientResponse, 0),
+		pendingMu: &sync.Mutex{},
+	}
+}
+
+type clientRequest struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      string      `json:"id,omitempty"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+type clientResponse struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Result json.RawMessage `json:"result"`
+	Error  *clientError    `json:"error"`
+}
+
+type clientError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *clientError) Error() string {
+	return fmt.Sprintf("[%d] %s", e.Code, e.Message)
+}
+
+var errClientChannelClosed = errors.New("client channel closed")
+
+func (c *clientChannel) Send(msg []byte) error {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+	return c.Channel.Send(msg)
+}
+
+func (c *clientChannel) Recv() ([]byte, error) {
+	for {
+		msg, err := c.Channel.Recv()
+		if err != nil {
+			c.closePending()
+			return msg, err
+		}
+
+		if c.deliver(msg) {
+			continue
+		}
+
+		return msg, nil
+	}
+}
+
+// Call sends a request to the client and waits for its response
+func (c *clientChannel) Call(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
+	id, respCh, err := c.newPending()
+	if err != nil {
+		return nil, err
+	}
+	defer c.removePending(id)
+
+	msg, err := json.Marshal(clientRequest{
+		JSONRPC: "2.0",
+		ID:      id,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = c.Send(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case resp, ok := <-respCh:
+		if !ok {
+			return nil, errClientChannelClosed
+		}
+		if resp.Error != nil {
+			return nil, resp.Error
+		}
+		return resp.Result, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Notify sends a notification to the client
+func (c *clientChannel) Notify(method string, params interface{}) error {
+	msg, err := json.Marshal(clientRequest{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return err
+	}
+	return c.Send(msg)
+}
+
+func (c *clientChannel) newPending() (string, chan *clientResponse, error) {
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+
+	if c.closed {
+		return "", nil, errClientChannelClosed
+	}
+
+	c.nextID++
+	id := fmt.Sprintf("terraform-ls-%d", c.nextID)
+	respCh := make(chan *clientResponse, 1)
+	c.pending[id] = respCh
+
+	return id, respCh, nil
+}
+
+func (c *clientChannel) removePending(id string) {
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+	delete(c.pending, id)
+}
+
+func (c *clientChannel) closePending() {
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+
+	c.closed = true
+	for id, respCh := range c.pending {
+		close(respCh)
+		delete(c.pending, id)
+	}
+}
+
+// deliver passes the message to a pending request if it is a response.
+// The server itself never makes requests, so any response received
+// must be for a request made via Call and responses to requests
+// which are no longer pending (e.g. timed out) are discarded.
+func (c *clientChannel) deliver(msg []byte) bool {
+	if !bytes.HasPrefix(bytes.TrimSpace(msg), []byte("{")) {
+		// batches are never responses to our requests
+		return false
+	}
+
+	var resp clientResponse
+	err := json.Unmarshal(msg, &resp)
+	if err != nil || resp.Method != "" || len(resp.ID) == 0 {
+		return false
+	}
+
+	var id string
+	_ = json.Unmarshal(resp.ID, &id)
+
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+
+	respCh, ok := c.pending[id]
+	if !ok {
+		return true
+	}
+	respCh <- &resp
+	delete(c.pending, id)
+
+	return true
+}
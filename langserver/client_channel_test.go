@@ -0,0 +1,92 @@
+package langserver
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/creachadair/jrpc2/channel"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClientChannel_call(t *testing.T) {
+	srvReader, clientWriter := io.Pipe()
+	clientReader, srvWriter := io.Pipe()
+
+	cc := newClientChannel(channel.LSP(srvReader, srvWriter))
+	defer cc.Close()
+	clientCh := channel.LSP(clientReader, clientWriter)
+	defer clientCh.Close()
+
+	// mimic the server reading all incoming messages
+	srvMsgs := make(chan string, 1)
+	go func() {
+		for {
+			msg, err := cc.Recv()
+			if err != nil {
+				return
+			}
+			srvMsgs <- string(msg)
+		}
+	}()
+
+	go func() {
+		msg, err := clientCh.Recv()
+		if err != nil {
+			return
+		}
+		var req clientRequest
+		err = json.Unmarshal(msg, &req)
+		if err != nil {
+			return
+		}
+		id, _ := json.Marshal(req.ID)
+
+		// response to the server's request
+		clientCh.Send([]byte(`{"jsonrpc":"2.0","id":` + string(id) + `,"result":{"ok":true}}`))
+		// request which should pass through to the server
+		clientCh.Send([]byte(`{"jsonrpc":"2.0","id":1,"method":"shutdown"}`))
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result, err := cc.Call(ctx, "window/workDoneProgress/create", map[string]string{
+		"token": "test",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(`{"ok":true}`, string(result)); diff != "" {
+		t.Fatalf("result doesn't match: %s", diff)
+	}
+
+	select {
+	case msg := <-srvMsgs:
+		expectedMsg := `{"jsonrpc":"2.0","id":1,"method":"shutdown"}`
+		if diff := cmp.Diff(expectedMsg, msg); diff != "" {
+			t.Fatalf("message doesn't match: %s", diff)
+		}
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for client request")
+	}
+}
+
+func TestClientChannel_callClosed(t *testing.T) {
+	srvReader, clientWriter := io.Pipe()
+	_, srvWriter := io.Pipe()
+
+	cc := newClientChannel(channel.LSP(srvReader, srvWriter))
+	clientWriter.Close()
+
+	_, err := cc.Recv()
+	if err == nil {
+		t.Fatal("expected error after closing")
+	}
+
+	_, err = cc.Call(context.Background(), "window/workDoneProgress/create", nil)
+	if err != errClientChannelClosed {
+		t.Fatalf("expected closed channel error, given: %v", err)
+	}
+}
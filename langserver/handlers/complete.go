@@ -10,8 +10,8 @@ import (
 	lsp "github.com/sourcegraph/go-lsp"
 )
 
-func (h *logHandler) TextDocumentComplete(ctx context.Context, params lsp.CompletionParams) (lsp.CompletionList, error) {
-	var list lsp.CompletionList
+func (h *logHandler) TextDocumentComplete(ctx context.Context, params lsp.CompletionParams) (ilsp.CompletionListResult, error) {
+	var list ilsp.CompletionListResult
 
 	fs, err := lsctx.Filesystem(ctx)
 	if err != nil {
@@ -28,6 +28,11 @@ func (h *logHandler) TextDocumentComplete(ctx context.Context, params lsp.Comple
 		return list, err
 	}
 
+	mif, err := lsctx.ModuleIndexFinder(ctx)
+	if err != nil {
+		return list, err
+	}
+
 	h.logger.Printf("Finding block at position %#v", params.TextDocumentPositionParams)
 
 	file, err := fs.GetFile(ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI))
@@ -67,7 +72,12 @@ func (h *logHandler) TextDocumentComplete(ctx context.Context, params lsp.Comple
 		return list, fmt.Errorf("finding compatible parser failed: %w", err)
 	}
 
-	candidates, err := p.CompletionCandidatesAtPos(hclFile, pos)
+	mi, err := mif.ModuleIndexForDir(file.Dir())
+	if err != nil {
+		return list, fmt.Errorf("finding module index failed: %w", err)
+	}
+
+	candidates, err := p.CompletionCandidatesAtPos(hclFile, mi, pos)
 	if err != nil {
 		return list, fmt.Errorf("finding completion items failed: %w", err)
 	}
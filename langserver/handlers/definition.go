@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"context"
+
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/sourcegraph/go-lsp"
+)
+
+func (h *logHandler) TextDocumentDefinition(ctx context.Context, params lsp.TextDocumentPositionParams) ([]lsp.Location, error) {
+	locations := []lsp.Location{}
+
+	fs, err := lsctx.Filesystem(ctx)
+	if err != nil {
+		return locations, err
+	}
+
+	mif, err := lsctx.ModuleIndexFinder(ctx)
+	if err != nil {
+		return locations, err
+	}
+
+	file, err := fs.GetFile(ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI))
+	if err != nil {
+		return locations, err
+	}
+
+	fPos, err := ilsp.FilePositionFromDocumentPosition(params, file)
+	if err != nil {
+		return locations, err
+	}
+
+	mi, err := mif.ModuleIndexForDir(file.Dir())
+	if err != nil {
+		return locations, err
+	}
+
+	ref, ok := mi.ReferenceAtPos(file.FullPath(), fPos.Position())
+	if !ok {
+		h.logger.Printf("no reference found at %#v", fPos.Position())
+		return locations, nil
+	}
+
+	decl, ok := mi.Declaration(ref.Address)
+	if !ok {
+		h.logger.Printf("no declaration found for %q", ref.Address)
+		return locations, nil
+	}
+
+	return append(locations, ilsp.HCLRangeToLocation(decl.Range)), nil
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-ls/internal/terraform/rootmodule"
+	"github.com/hashicorp/terraform-ls/langserver"
+	"github.com/hashicorp/terraform-ls/langserver/session"
+)
+
+func TestDefinition_withoutInitialization(t *testing.T) {
+	ls := langserver.NewLangServerMock(t, NewMockSession(nil))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.CallAndExpectError(t, &langserver.CallRequest{
+		Method: "textDocument/definition",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {
+				"character": 0,
+				"line": 1
+			}
+		}`, TempDir(t).URI())}, session.SessionNotInitialized.Err())
+}
+
+func TestDefinition_acrossFiles(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	err := ioutil.WriteFile(filepath.Join(tmpDir.Dir(), "variables.tf"),
+		[]byte("variable \"region\" {}\n"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		RootModules: map[string]*rootmodule.RootModuleMock{
+			tmpDir.Dir(): {
+				TerraformExecQueue: validTfMockCalls(),
+			},
+		}}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+	    "processId": 12345
+	}`, tmpDir.URI())})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "output \"region\" {\n  value = var.region\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI())})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/definition",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {
+				"character": 14,
+				"line": 1
+			}
+		}`, tmpDir.URI())}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 3,
+			"result": [
+				{
+					"uri": "%s/variables.tf",
+					"range": {
+						"start": {
+							"line": 0,
+							"character": 0
+						},
+						"end": {
+							"line": 0,
+							"character": 20
+						}
+					}
+				}
+			]
+		}`, tmpDir.URI()))
+}
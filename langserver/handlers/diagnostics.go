@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/hashicorp/hcl/v2"
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+	"github.com/hashicorp/terraform-ls/internal/terraform/lang"
+	lsp "github.com/sourcegraph/go-lsp"
+)
+
+const (
+	syntaxDiagsSource = "HCL"
+	schemaDiagsSource = "Terraform schema"
+)
+
+// publishDiagnostics publishes syntax errors found in the file
+// and, if the file is syntactically valid and schema is available,
+// any problems found by validating the file against the schema
+func (lh *logHandler) publishDiagnostics(ctx context.Context, uri lsp.DocumentURI, file filesystem.File) error {
+	notifier, err := lsctx.DiagnosticsNotifier(ctx)
+	if err != nil {
+		return err
+	}
+
+	hclFile := ihcl.NewFile(file)
+	syntaxDiags := hclFile.Diagnostics()
+
+	var schemaDiags hcl.Diagnostics
+	if !syntaxDiags.HasErrors() {
+		schemaDiags = lh.validateFile(ctx, hclFile, file.Dir())
+	}
+
+	return notifier.Publish(ctx, uri, map[string]hcl.Diagnostics{
+		syntaxDiagsSource: syntaxDiags,
+		schemaDiagsSource: schemaDiags,
+	})
+}
+
+// validateFile validates the file against the schema of the root module.
+// Syntax diagnostics are published regardless of the outcome,
+// so any error (e.g. parser unavailable due to missing Terraform)
+// is only logged and results in no schema diagnostics.
+func (lh *logHandler) validateFile(ctx context.Context, file ihcl.TokenizedFile, dir string) hcl.Diagnostics {
+	pf, err := lsctx.ParserFinder(ctx)
+	if err != nil {
+		lh.logger.Printf("unable to validate %s: %s", dir, err)
+		return nil
+	}
+
+	// Files outside of any known root module or modules
+	// which are still loading just have no schema diagnostics
+	isParserLoaded, err := pf.IsParserLoaded(dir)
+	if err != nil || !isParserLoaded {
+		return nil
+	}
+	isSchemaLoaded, err := pf.IsSchemaLoaded(dir)
+	if err != nil || !isSchemaLoaded {
+		return nil
+	}
+
+	p, err := pf.ParserForDir(dir)
+	if err != nil {
+		lh.logger.Printf("unable to validate %s: %s", dir, err)
+		return nil
+	}
+
+	// Module inputs are only validated if the module index is available
+	var mi *lang.ModuleIndex
+	mif, err := lsctx.ModuleIndexFinder(ctx)
+	if err == nil {
+		mi, err = mif.ModuleIndexForDir(dir)
+		if err != nil {
+			lh.logger.Printf("unable to find module index for %s: %s", dir, err)
+		}
+	}
+
+	diags, err := p.ValidateFile(file, mi)
+	if err != nil {
+		lh.logger.Printf("failed to validate %s: %s", dir, err)
+		return nil
+	}
+
+	return diags
+}
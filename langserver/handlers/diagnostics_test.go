@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+	"github.com/hashicorp/terraform-ls/internal/terraform/lang"
+)
+
+type mockParserFinder struct {
+	parser    lang.Parser
+	parserErr error
+}
+
+func (pf *mockParserFinder) ParserForDir(path string) (lang.Parser, error) {
+	return pf.parser, pf.parserErr
+}
+
+func (pf *mockParserFinder) IsParserLoaded(path string) (bool, error) {
+	return true, nil
+}
+
+func (pf *mockParserFinder) IsSchemaLoaded(path string) (bool, error) {
+	return true, nil
+}
+
+func TestValidateFile_parserUnavailable(t *testing.T) {
+	// e.g. Terraform not found or unsupported version
+	pf := &mockParserFinder{parserErr: errors.New("no parser available")}
+	ctx := lsctx.WithParserFinder(pf, context.Background())
+	file := ihcl.NewFile(filesystem.NewFile("/test/main.tf",
+		[]byte(`provider "test" {}`)))
+
+	lh := LogHandler(testLogger())
+	diags := lh.validateFile(ctx, file, "/test")
+	if len(diags) != 0 {
+		t.Fatalf("expected no schema diagnostics, given: %#v", diags)
+	}
+}
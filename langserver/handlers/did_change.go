@@ -10,7 +10,7 @@ import (
 	lsp "github.com/sourcegraph/go-lsp"
 )
 
-func TextDocumentDidChange(ctx context.Context, params DidChangeTextDocumentParams) error {
+func (lh *logHandler) TextDocumentDidChange(ctx context.Context, params DidChangeTextDocumentParams) error {
 	p := lsp.DidChangeTextDocumentParams{
 		TextDocument: lsp.VersionedTextDocumentIdentifier{
 			TextDocumentIdentifier: lsp.TextDocumentIdentifier{
@@ -44,7 +44,24 @@ func TextDocumentDidChange(ctx context.Context, params DidChangeTextDocumentPara
 	if err != nil {
 		return err
 	}
-	return fs.Change(fh, changes)
+	err = fs.Change(fh, changes)
+	if err != nil {
+		return err
+	}
+
+	mif, err := lsctx.ModuleIndexFinder(ctx)
+	if err != nil {
+		return err
+	}
+	mif.InvalidateModuleIndex(f.Dir())
+
+	// obtain the file again as the change produced new content
+	f, err = fs.GetFile(fh)
+	if err != nil {
+		return err
+	}
+
+	return lh.publishDiagnostics(ctx, p.TextDocument.URI, f)
 }
 
 // TODO: Revisit after https://github.com/hashicorp/terraform-ls/issues/118 is addressed
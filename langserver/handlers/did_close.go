@@ -15,5 +15,23 @@ func TextDocumentDidClose(ctx context.Context, params lsp.DidCloseTextDocumentPa
 	}
 
 	fh := ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI)
-	return fs.Close(fh)
+	err = fs.Close(fh)
+	if err != nil {
+		return err
+	}
+
+	mif, err := lsctx.ModuleIndexFinder(ctx)
+	if err != nil {
+		return err
+	}
+	// unsaved changes are discarded when closing
+	// so the file on disk needs to be indexed again
+	mif.InvalidateModuleIndex(fh.Dir())
+
+	diags, err := lsctx.DiagnosticsNotifier(ctx)
+	if err != nil {
+		return err
+	}
+
+	return diags.Clear(ctx, params.TextDocument.URI)
 }
@@ -25,6 +25,22 @@ func (lh *logHandler) TextDocumentDidOpen(ctx context.Context, params lsp.DidOpe
 		return err
 	}
 
+	mif, err := lsctx.ModuleIndexFinder(ctx)
+	if err != nil {
+		return err
+	}
+	// content of the file as opened may differ from what was indexed
+	mif.InvalidateModuleIndex(f.Dir())
+
+	file, err := fs.GetFile(ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI))
+	if err != nil {
+		return err
+	}
+	err = lh.publishDiagnostics(ctx, params.TextDocument.URI, file)
+	if err != nil {
+		return err
+	}
+
 	cf, err := lsctx.RootModuleCandidateFinder(ctx)
 	if err != nil {
 		return err
@@ -39,10 +55,28 @@ func (lh *logHandler) TextDocumentDidOpen(ctx context.Context, params lsp.DidOpe
 
 	candidates := cf.RootModuleCandidatesByPath(f.Dir())
 
+	if rootDir == "" {
+		// In single-file mode the directory of the file
+		// is treated as an ad-hoc root module
+		if len(candidates) == 0 {
+			return lh.addAdHocRootModule(ctx, f.Dir())
+		}
+		return nil
+	}
+
 	if walker.IsWalking() {
 		// avoid raising false warnings if walker hasn't finished yet
 		lh.logger.Printf("walker has not finished walking yet, data may be inaccurate for %s", f.FullPath())
 	} else if len(candidates) == 0 {
+		rmm, err := lsctx.RootModuleManager(ctx)
+		if err != nil {
+			return err
+		}
+		if rmm.HasFallbackSchemas() {
+			// Uninitialized module can still make use of fallback schemas
+			return lh.addAdHocRootModule(ctx, f.Dir())
+		}
+
 		msg := fmt.Sprintf("No root module found for %s."+
 			" Functionality may be limited."+
 			// Unfortunately we can't be any more specific wrt where
@@ -68,6 +102,40 @@ func (lh *logHandler) TextDocumentDidOpen(ctx context.Context, params lsp.DidOpe
 	return nil
 }
 
+// addAdHocRootModule adds root module for a directory
+// which was not discovered as part of any root directory
+func (lh *logHandler) addAdHocRootModule(ctx context.Context, dir string) error {
+	addAndLoadRootModule, err := lsctx.RootModuleLoader(ctx)
+	if err != nil {
+		return err
+	}
+
+	w, err := lsctx.Watcher(ctx)
+	if err != nil {
+		return err
+	}
+
+	pn, err := lsctx.ProgressNotifier(ctx)
+	if err != nil {
+		return err
+	}
+
+	lh.logger.Printf("Adding ad-hoc root module: %s", dir)
+	rm, err := addAndLoadRootModule(dir)
+	if err != nil {
+		if rm == nil {
+			return err
+		}
+		// load error is reported to the user below
+		lh.logger.Printf("failed to load ad-hoc root module %s: %s", dir, err)
+	}
+	lh.reportLoading(ctx, pn, rm)
+
+	paths := rm.PathsToWatch()
+	lh.logger.Printf("Adding %d paths of root module for watching (%s)", len(paths), dir)
+	return w.AddPaths(paths)
+}
+
 func candidatePaths(rootDir string, candidates []rootmodule.RootModule) string {
 	paths := make([]string, len(candidates))
 	for i, rm := range candidates {
@@ -2,8 +2,11 @@ package handlers
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/hashicorp/terraform-ls/internal/terraform/rootmodule"
 	"github.com/hashicorp/terraform-ls/langserver"
 	"github.com/hashicorp/terraform-ls/langserver/session"
 )
@@ -24,3 +27,66 @@ func TestLangServer_didOpenWithoutInitialization(t *testing.T) {
 		}
 	}`, TempDir(t).URI())}, session.SessionNotInitialized.Err())
 }
+
+func TestLangServer_didOpenInSingleFileMode(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		RootModules: map[string]*rootmodule.RootModuleMock{
+			tmpDir.Dir(): {
+				TerraformExecQueue: &exec.MockQueue{
+					Q: []*exec.MockItem{
+						{
+							Args:   []string{"version"},
+							Stdout: "Terraform v0.12.0\n",
+						},
+						{
+							Args:   []string{"providers", "schema", "-json"},
+							Stdout: testSchemaOutput,
+						},
+					},
+				},
+			},
+		}}))
+	stop := ls.Start(t)
+	defer stop()
+
+	// no root URI is provided when a single file is opened
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: `{
+	    "capabilities": {},
+	    "processId": 12345
+	}`})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "provider \"test\" {\n\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI())})
+
+	// schema is available as the directory was added as a root module
+	resp := ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/completion",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {
+				"character": 0,
+				"line": 1
+			}
+		}`, tmpDir.URI())})
+	if !strings.Contains(string(resp.Result), `"label":"base_url"`) {
+		t.Fatalf("expected completion from schema, given: %s", resp.Result)
+	}
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	"github.com/hashicorp/terraform-ls/internal/terraform/rootmodule"
+	lsp "github.com/sourcegraph/go-lsp"
+)
+
+const validateDiagsSource = "Terraform"
+
+// validateDelay is how long to wait for further saves
+// before running terraform validate in a root module
+var validateDelay = 500 * time.Millisecond
+
+func (lh *logHandler) TextDocumentDidSave(ctx context.Context, params lsp.DidSaveTextDocumentParams) error {
+	cf, err := lsctx.RootModuleCandidateFinder(ctx)
+	if err != nil {
+		return err
+	}
+
+	scheduler, err := lsctx.ValidationScheduler(ctx)
+	if err != nil {
+		return err
+	}
+
+	notifier, err := lsctx.DiagnosticsNotifier(ctx)
+	if err != nil {
+		return err
+	}
+
+	fh := ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI)
+	candidates := cf.RootModuleCandidatesByPath(fh.Dir())
+	if len(candidates) == 0 {
+		lh.logger.Printf("no root module found for %s, skipping validation", fh.FullPath())
+		return nil
+	}
+	rm := candidates[0]
+
+	scheduler.Schedule(ctx, rm.Path(), func(ctx context.Context) {
+		diags, rmDiags, err := validateRootModule(ctx, rm)
+		if err != nil {
+			if ctx.Err() == nil {
+				lh.logger.Printf("failed to validate %s: %s", rm.Path(), err)
+			}
+			return
+		}
+		// diagnostics not related to any file are attached to the saved document
+		if len(rmDiags) > 0 {
+			uri := params.TextDocument.URI
+			diags[uri] = append(diags[uri], rmDiags...)
+		}
+
+		err = notifier.PublishForDir(ctx, rm.Path(), validateDiagsSource, diags)
+		if err != nil {
+			lh.logger.Printf("failed to publish validation diagnostics for %s: %s", rm.Path(), err)
+		}
+	})
+
+	return nil
+}
+
+// validateRootModule runs terraform validate and returns diagnostics
+// per document, along with diagnostics not related to any file
+func validateRootModule(ctx context.Context, rm rootmodule.RootModule) (
+	map[lsp.DocumentURI]hcl.Diagnostics, hcl.Diagnostics, error) {
+	fileDiags, err := rm.ExecuteTerraformValidate(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	diags := make(map[lsp.DocumentURI]hcl.Diagnostics, len(fileDiags))
+	var rmDiags hcl.Diagnostics
+	for path, d := range fileDiags {
+		if path == "" {
+			rmDiags = append(rmDiags, d...)
+			continue
+		}
+		uri := lsp.DocumentURI(filesystem.URIFromPath(path))
+		diags[uri] = append(diags[uri], d...)
+	}
+
+	return diags, rmDiags, nil
+}
@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-ls/langserver"
+	"github.com/hashicorp/terraform-ls/langserver/session"
+)
+
+func TestLangServer_didSaveWithoutInitialization(t *testing.T) {
+	ls := langserver.NewLangServerMock(t, NewMockSession(nil))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.CallAndExpectError(t, &langserver.CallRequest{
+		Method: "textDocument/didSave",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"uri": "%s/main.tf"
+		}
+	}`, TempDir(t).URI())}, session.SessionNotInitialized.Err())
+}
@@ -34,10 +34,29 @@ func TestInitalizeAndShutdown(t *testing.T) {
 			"capabilities": {
 				"textDocumentSync": {
 					"openClose": true,
-					"change": 2
+					"change": 2,
+					"save": {
+						"includeText": false
+					}
 				},
+				"hoverProvider": true,
 				"completionProvider": {},
-				"documentFormattingProvider":true
+				"signatureHelpProvider": {
+					"triggerCharacters": ["(", ","]
+				},
+				"definitionProvider": true,
+				"referencesProvider": true,
+				"documentSymbolProvider": true,
+				"workspaceSymbolProvider": true,
+				"documentFormattingProvider":true,
+				"executeCommandProvider": {
+					"commands": [
+						"terraform-ls.terraform.init",
+						"terraform-ls.terraform.validate",
+						"terraform-ls.rootmodules.reload"
+					]
+				},
+				"renameProvider":true
 			}
 		}
 	}`)
@@ -72,10 +91,29 @@ func TestEOF(t *testing.T) {
 			"capabilities": {
 				"textDocumentSync": {
 					"openClose": true,
-					"change": 2
+					"change": 2,
+					"save": {
+						"includeText": false
+					}
 				},
+				"hoverProvider": true,
 				"completionProvider": {},
-				"documentFormattingProvider":true
+				"signatureHelpProvider": {
+					"triggerCharacters": ["(", ","]
+				},
+				"definitionProvider": true,
+				"referencesProvider": true,
+				"documentSymbolProvider": true,
+				"workspaceSymbolProvider": true,
+				"documentFormattingProvider":true,
+				"executeCommandProvider": {
+					"commands": [
+						"terraform-ls.terraform.init",
+						"terraform-ls.terraform.validate",
+						"terraform-ls.rootmodules.reload"
+					]
+				},
+				"renameProvider":true
 			}
 		}
 	}`)
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/sourcegraph/go-lsp"
+)
+
+func (h *logHandler) TextDocumentHover(ctx context.Context, params lsp.TextDocumentPositionParams) (ilsp.HoverResult, error) {
+	var hover ilsp.HoverResult
+
+	fs, err := lsctx.Filesystem(ctx)
+	if err != nil {
+		return hover, err
+	}
+
+	cc, err := lsctx.ClientCapabilities(ctx)
+	if err != nil {
+		return hover, err
+	}
+
+	pf, err := lsctx.ParserFinder(ctx)
+	if err != nil {
+		return hover, err
+	}
+
+	mif, err := lsctx.ModuleIndexFinder(ctx)
+	if err != nil {
+		return hover, err
+	}
+
+	h.logger.Printf("Finding hover data at position %#v", params)
+
+	file, err := fs.GetFile(ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI))
+	if err != nil {
+		return hover, err
+	}
+
+	hclFile := ihcl.NewFile(file)
+	fPos, err := ilsp.FilePositionFromDocumentPosition(params, file)
+	if err != nil {
+		return hover, err
+	}
+
+	isParserLoaded, err := pf.IsParserLoaded(file.Dir())
+	if err != nil {
+		return hover, err
+	}
+	if !isParserLoaded {
+		return hover, fmt.Errorf("parser is not available yet for %s", file.Dir())
+	}
+
+	isSchemaLoaded, err := pf.IsSchemaLoaded(file.Dir())
+	if err != nil {
+		return hover, err
+	}
+	if !isSchemaLoaded {
+		return hover, fmt.Errorf("schema is not available yet for %s", file.Dir())
+	}
+
+	p, err := pf.ParserForDir(file.Dir())
+	if err != nil {
+		return hover, fmt.Errorf("finding compatible parser failed: %w", err)
+	}
+
+	mi, err := mif.ModuleIndexForDir(file.Dir())
+	if err != nil {
+		return hover, fmt.Errorf("finding module index failed: %w", err)
+	}
+
+	data, err := p.HoverAtPos(hclFile, mi, fPos.Position())
+	if err != nil {
+		return hover, fmt.Errorf("finding hover data failed: %w", err)
+	}
+
+	return ilsp.Hover(data, cc.TextDocument), nil
+}
@@ -8,40 +8,65 @@ import (
 	lsctx "github.com/hashicorp/terraform-ls/internal/context"
 	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
 	"github.com/hashicorp/terraform-ls/internal/settings"
+	"github.com/hashicorp/terraform-ls/internal/terraform/schema"
 	lsp "github.com/sourcegraph/go-lsp"
 )
 
-func (lh *logHandler) Initialize(ctx context.Context, params lsp.InitializeParams) (lsp.InitializeResult, error) {
-	serverCaps := lsp.InitializeResult{
-		Capabilities: lsp.ServerCapabilities{
-			TextDocumentSync: &lsp.TextDocumentSyncOptionsOrKind{
-				Options: &lsp.TextDocumentSyncOptions{
-					OpenClose: true,
-					Change:    lsp.TDSKIncremental,
+func (lh *logHandler) Initialize(ctx context.Context, params lsp.InitializeParams) (InitializeResult, error) {
+	serverCaps := InitializeResult{
+		Capabilities: ServerCapabilities{
+			ServerCapabilities: lsp.ServerCapabilities{
+				TextDocumentSync: &lsp.TextDocumentSyncOptionsOrKind{
+					Options: &lsp.TextDocumentSyncOptions{
+						OpenClose: true,
+						Change:    lsp.TDSKIncremental,
+						Save:      &lsp.SaveOptions{},
+					},
+				},
+				HoverProvider: true,
+				CompletionProvider: &lsp.CompletionOptions{
+					ResolveProvider: false,
+				},
+				SignatureHelpProvider: &lsp.SignatureHelpOptions{
+					TriggerCharacters: []string{"(", ","},
+				},
+				DefinitionProvider:         true,
+				ReferencesProvider:         true,
+				DocumentSymbolProvider:     true,
+				WorkspaceSymbolProvider:    true,
+				DocumentFormattingProvider: true,
+				ExecuteCommandProvider: &lsp.ExecuteCommandOptions{
+					Commands: supportedCommands,
 				},
 			},
-			CompletionProvider: &lsp.CompletionOptions{
-				ResolveProvider: false,
-			},
-			DocumentFormattingProvider: true,
+			RenameProvider: true,
 		},
 	}
 
-	fh := ilsp.FileHandlerFromDirURI(params.RootURI)
-	if fh.URI() == "" || !fh.IsDir() {
-		return serverCaps, fmt.Errorf("Editing a single file is not yet supported." +
-			" Please open a directory.")
-	}
-	if !fh.Valid() {
-		return serverCaps, fmt.Errorf("URI %q is not valid", params.RootURI)
+	// Rename options may only be sent if the client supports prepareRename
+	if rename := params.Capabilities.TextDocument.Rename; rename != nil && rename.PrepareSupport {
+		serverCaps.Capabilities.RenameProvider = &RenameOptions{
+			PrepareProvider: true,
+		}
 	}
 
-	err := lsctx.SetRootDirectory(ctx, fh.FullPath())
-	if err != nil {
-		return serverCaps, err
+	// Clients opening a single file (rather than a directory)
+	// don't provide any root URI, in which case root modules
+	// are added ad-hoc as files are opened
+	fh := ilsp.FileHandlerFromDirURI(params.RootURI)
+	isSingleFileMode := fh.URI() == ""
+	if !isSingleFileMode {
+		if !fh.Valid() {
+			return serverCaps, fmt.Errorf("URI %q is not valid", params.RootURI)
+		}
+
+		err := lsctx.SetRootDirectory(ctx, fh.FullPath())
+		if err != nil {
+			return serverCaps, err
+		}
 	}
 
-	err = lsctx.SetClientCapabilities(ctx, &params.Capabilities)
+	err := lsctx.SetClientCapabilities(ctx, &params.Capabilities)
 	if err != nil {
 		return serverCaps, err
 	}
@@ -61,6 +86,12 @@ func (lh *logHandler) Initialize(ctx context.Context, params lsp.InitializeParam
 		return serverCaps, err
 	}
 
+	pn, err := lsctx.ProgressNotifier(ctx)
+	if err != nil {
+		return serverCaps, err
+	}
+	pn.SetEnabled(params.Capabilities.Window.WorkDoneProgress)
+
 	out, err := settings.DecodeOptions(params.InitializationOptions)
 	if err != nil {
 		return serverCaps, err
@@ -77,6 +108,22 @@ func (lh *logHandler) Initialize(ctx context.Context, params lsp.InitializeParam
 	}
 	cfgOpts := out.Options
 
+	if len(cfgOpts.ProviderSchemaFiles) > 0 {
+		idx, err := schema.ReadProviderSchemasFiles(cfgOpts.ProviderSchemaFiles)
+		if err != nil {
+			// Fallback schemas are optional, so we carry on without them
+			lh.logger.Printf("Failed to load fallback provider schemas: %s", err)
+			jrpc2.ServerPush(ctx, "window/showMessage", &lsp.ShowMessageParams{
+				Type:    lsp.MTWarning,
+				Message: fmt.Sprintf("Unable to load provider schema files: %s", err),
+			})
+		} else {
+			lh.logger.Printf("Loaded %d provider schemas from %d files as fallback",
+				len(idx.Addresses()), len(cfgOpts.ProviderSchemaFiles))
+			rmm.SetFallbackSchemas(idx)
+		}
+	}
+
 	// Static user-provided paths take precedence over dynamic discovery
 	if len(cfgOpts.RootModulePaths) > 0 {
 		lh.logger.Printf("Attempting to add %d static root module paths", len(cfgOpts.RootModulePaths))
@@ -85,6 +132,7 @@ func (lh *logHandler) Initialize(ctx context.Context, params lsp.InitializeParam
 			if err != nil {
 				return serverCaps, err
 			}
+			lh.reportLoading(ctx, pn, rm)
 
 			paths := rm.PathsToWatch()
 			lh.logger.Printf("Adding %d paths of root module for watching (%s)", len(paths), rmPath)
@@ -97,18 +145,42 @@ func (lh *logHandler) Initialize(ctx context.Context, params lsp.InitializeParam
 		return serverCaps, nil
 	}
 
+	if isSingleFileMode {
+		lh.logger.Printf("No root URI provided, running in single-file mode")
+		return serverCaps, nil
+	}
+
 	walker, err := lsctx.RootModuleWalker(ctx)
 	if err != nil {
 		return serverCaps, err
 	}
 
 	walker.SetLogger(lh.logger)
+
+	// the walker runs with its own context, which doesn't allow
+	// notifying the client, unlike the context of this request
+	reqCtx := ctx
+
+	walkProgress := pn.Begin("Discovering root modules", fh.Dir())
+	rmCount := 0
+	walker.AddDoneHook(func(err error) {
+		if err != nil {
+			walkProgress.End(fmt.Sprintf("Discovery failed: %s", err))
+			return
+		}
+		walkProgress.End(fmt.Sprintf("Found %d root modules", rmCount))
+	})
+
 	err = walker.StartWalking(fh.Dir(), func(ctx context.Context, dir string) error {
 		lh.logger.Printf("Adding root module: %s", dir)
+		rmCount++
+		walkProgress.Report(dir)
+
 		rm, err := rmm.AddAndStartLoadingRootModule(ctx, dir)
 		if err != nil {
 			return err
 		}
+		lh.reportLoading(reqCtx, pn, rm)
 
 		paths := rm.PathsToWatch()
 		lh.logger.Printf("Adding %d paths of root module for watching (%s)", len(paths), dir)
@@ -119,6 +191,29 @@ func (lh *logHandler) Initialize(ctx context.Context, params lsp.InitializeParam
 
 		return nil
 	})
+	if err != nil {
+		// walking may have not even started
+		walkProgress.End(fmt.Sprintf("Discovery failed: %s", err))
+	}
 
 	return serverCaps, err
 }
+
+// TODO: Revisit after https://github.com/hashicorp/terraform-ls/issues/118 is addressed
+// Then we could switch back to upstream go-lsp
+type InitializeResult struct {
+	Capabilities ServerCapabilities `json:"capabilities"`
+}
+
+// ServerCapabilities overrides capabilities which go-lsp
+// is not able to represent fully
+type ServerCapabilities struct {
+	lsp.ServerCapabilities
+
+	// RenameProvider is either bool or *RenameOptions
+	RenameProvider interface{} `json:"renameProvider,omitempty"`
+}
+
+type RenameOptions struct {
+	PrepareProvider bool `json:"prepareProvider,omitempty"`
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/creachadair/jrpc2/code"
@@ -73,3 +74,24 @@ func TestInitialize_withInvalidRootURI(t *testing.T) {
 	    "rootUri": "meh"
 	}`}, code.SystemError.Err())
 }
+
+func TestInitialize_withInvalidProviderSchemaFile(t *testing.T) {
+	tmpDir := TempDir(t)
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		RootModules: map[string]*rootmodule.RootModuleMock{
+			tmpDir.Dir(): {TerraformExecQueue: validTfMockCalls()},
+		}}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "processId": 12345,
+	    "rootUri": %q,
+	    "initializationOptions": {
+	        "providerSchemaFiles": [%q]
+	    }
+	}`, tmpDir.URI(), filepath.Join(tmpDir.Dir(), "nonexistent.json"))})
+}
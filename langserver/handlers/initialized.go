@@ -3,9 +3,19 @@ package handlers
 import (
 	"context"
 
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
 	lsp "github.com/sourcegraph/go-lsp"
 )
 
 func Initialized(ctx context.Context, params lsp.None) error {
+	pn, err := lsctx.ProgressNotifier(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Requests to the client (such as creation of progress tokens)
+	// are only allowed once the client received initialize response
+	pn.Start()
+
 	return nil
 }
@@ -2,13 +2,24 @@ package handlers
 
 import (
 	"log"
+	"sync"
 )
 
 // logHandler provides handlers logger
 type logHandler struct {
 	logger *log.Logger
+
+	// shownLoadErrs keeps track of causes of load errors already
+	// shown to the user per root module path, so that the same
+	// failure isn't shown repeatedly, e.g. on every reload
+	shownLoadErrs   map[string]string
+	shownLoadErrsMu *sync.Mutex
 }
 
 func LogHandler(logger *log.Logger) *logHandler {
-	return &logHandler{logger}
+	return &logHandler{
+		logger:          logger,
+		shownLoadErrs:   make(map[string]string, 0),
+		shownLoadErrsMu: &sync.Mutex{},
+	}
 }
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	"github.com/hashicorp/terraform-ls/internal/terraform/lang"
+	"github.com/hashicorp/terraform-ls/internal/terraform/rootmodule"
+	lsp "github.com/sourcegraph/go-lsp"
+)
+
+func (h *logHandler) TextDocumentReferences(ctx context.Context, params ReferenceParams) ([]lsp.Location, error) {
+	locations := []lsp.Location{}
+
+	fs, err := lsctx.Filesystem(ctx)
+	if err != nil {
+		return locations, err
+	}
+
+	mif, err := lsctx.ModuleIndexFinder(ctx)
+	if err != nil {
+		return locations, err
+	}
+
+	file, err := fs.GetFile(ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI))
+	if err != nil {
+		return locations, err
+	}
+
+	fPos, err := ilsp.FilePositionFromDocumentPosition(lsp.TextDocumentPositionParams{
+		TextDocument: params.TextDocument,
+		Position:     params.Position,
+	}, file)
+	if err != nil {
+		return locations, err
+	}
+
+	mi, err := mif.ModuleIndexForDir(file.Dir())
+	if err != nil {
+		return locations, err
+	}
+
+	var address string
+	if ref, ok := mi.ReferenceAtPos(file.FullPath(), fPos.Position()); ok {
+		address = ref.Address
+	} else if decl, ok := mi.DeclarationAtPos(file.FullPath(), fPos.Position()); ok {
+		address = decl.Address
+	} else {
+		h.logger.Printf("no reference or declaration found at %#v", fPos.Position())
+		return locations, nil
+	}
+
+	if params.Context.IncludeDeclaration {
+		if decl, ok := mi.Declaration(address); ok {
+			locations = append(locations, ilsp.HCLRangeToLocation(decl.NameRange))
+		}
+	}
+
+	refs, err := findReferences(mif, mi, file.Dir(), address)
+	if err != nil {
+		return locations, err
+	}
+	for _, ref := range refs {
+		locations = append(locations, ilsp.HCLRangeToLocation(ref.Range))
+	}
+
+	return locations, nil
+}
+
+// findReferences finds all references to the object with the given address
+// declared in the module dir. Outputs are referenced from modules calling
+// the module, all other objects are referenced from within the module.
+func findReferences(mif rootmodule.ModuleIndexFinder, mi *lang.ModuleIndex, dir, address string) ([]*lang.Reference, error) {
+	if !strings.HasPrefix(address, "output.") {
+		return mi.References(address), nil
+	}
+
+	outputName := strings.TrimPrefix(address, "output.")
+
+	calls, err := mif.ModuleCallsForDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]*lang.Reference, 0)
+	for _, call := range calls {
+		callerIndex, err := mif.ModuleIndexForDir(call.Dir)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, callerIndex.References("module."+call.Name+"."+outputName)...)
+	}
+
+	return refs, nil
+}
+
+// ReferenceParams mirrors lsp.ReferenceParams without embedding
+// lsp.TextDocumentPositionParams, whose promoted UnmarshalJSON
+// would otherwise cause the context to be ignored
+type ReferenceParams struct {
+	TextDocument lsp.TextDocumentIdentifier `json:"textDocument"`
+	Position     lsp.Position               `json:"position"`
+	Context      lsp.ReferenceContext       `json:"context"`
+}
+
+// UnmarshalJSON implements non-strict json.Unmarshaler.
+func (v *ReferenceParams) UnmarshalJSON(b []byte) error {
+	type t ReferenceParams
+	return json.Unmarshal(b, (*t)(v))
+}
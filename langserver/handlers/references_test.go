@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-ls/internal/terraform/rootmodule"
+	"github.com/hashicorp/terraform-ls/langserver"
+	"github.com/hashicorp/terraform-ls/langserver/session"
+)
+
+func TestReferences_withoutInitialization(t *testing.T) {
+	ls := langserver.NewLangServerMock(t, NewMockSession(nil))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.CallAndExpectError(t, &langserver.CallRequest{
+		Method: "textDocument/references",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {
+				"character": 0,
+				"line": 1
+			},
+			"context": {
+				"includeDeclaration": true
+			}
+		}`, TempDir(t).URI())}, session.SessionNotInitialized.Err())
+}
+
+func TestReferences_acrossFiles(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	err := ioutil.WriteFile(filepath.Join(tmpDir.Dir(), "variables.tf"),
+		[]byte("variable \"region\" {}\n"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		RootModules: map[string]*rootmodule.RootModuleMock{
+			tmpDir.Dir(): {
+				TerraformExecQueue: validTfMockCalls(),
+			},
+		}}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+	    "processId": 12345
+	}`, tmpDir.URI())})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "output \"one\" {\n  value = var.region\n}\noutput \"two\" {\n  value = var.region\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI())})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/references",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {
+				"character": 14,
+				"line": 1
+			},
+			"context": {
+				"includeDeclaration": true
+			}
+		}`, tmpDir.URI())}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 3,
+			"result": [
+				{
+					"uri": "%s/variables.tf",
+					"range": {
+						"start": {
+							"line": 0,
+							"character": 10
+						},
+						"end": {
+							"line": 0,
+							"character": 16
+						}
+					}
+				},
+				{
+					"uri": "%s/main.tf",
+					"range": {
+						"start": {
+							"line": 1,
+							"character": 10
+						},
+						"end": {
+							"line": 1,
+							"character": 20
+						}
+					}
+				},
+				{
+					"uri": "%s/main.tf",
+					"range": {
+						"start": {
+							"line": 4,
+							"character": 10
+						},
+						"end": {
+							"line": 4,
+							"character": 20
+						}
+					}
+				}
+			]
+		}`, tmpDir.URI(), tmpDir.URI(), tmpDir.URI()))
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	"github.com/hashicorp/terraform-ls/internal/terraform/lang"
+	"github.com/hashicorp/terraform-ls/internal/terraform/rootmodule"
+	lsp "github.com/sourcegraph/go-lsp"
+)
+
+func (h *logHandler) TextDocumentPrepareRename(ctx context.Context, params lsp.TextDocumentPositionParams) (*ilsp.PrepareRenameResult, error) {
+	target, err := h.findRenameTarget(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, nil
+	}
+
+	return ilsp.PrepareRename(target.nameRange, target.name()), nil
+}
+
+func (h *logHandler) TextDocumentRename(ctx context.Context, params lsp.RenameParams) (lsp.WorkspaceEdit, error) {
+	edit := lsp.WorkspaceEdit{}
+
+	if !hclsyntax.ValidIdentifier(params.NewName) {
+		return edit, fmt.Errorf("%q is not a valid name", params.NewName)
+	}
+
+	target, err := h.findRenameTarget(ctx, lsp.TextDocumentPositionParams{
+		TextDocument: params.TextDocument,
+		Position:     params.Position,
+	})
+	if err != nil {
+		return edit, err
+	}
+	if target == nil {
+		return edit, fmt.Errorf("no declared object found at the given position")
+	}
+
+	rngs := []hcl.Range{target.decl.NameRange}
+
+	refs, err := findReferences(target.mif, target.mi, target.dir, target.decl.Address)
+	if err != nil {
+		return edit, err
+	}
+	for _, ref := range refs {
+		rngs = append(rngs, ref.NameRange)
+	}
+
+	if target.decl.BlockType == "variable" {
+		for _, assignment := range target.mi.Assignments(target.decl.Address) {
+			rngs = append(rngs, assignment.NameRange)
+		}
+	}
+
+	return ilsp.RenameEdit(rngs, params.NewName), nil
+}
+
+// renameTarget represents a declared object to be renamed
+type renameTarget struct {
+	decl *lang.Declaration
+
+	// nameRange covers the name of the object
+	// found at the requested position
+	nameRange hcl.Range
+
+	mif rootmodule.ModuleIndexFinder
+	mi  *lang.ModuleIndex
+	dir string
+}
+
+func (t *renameTarget) name() string {
+	parts := strings.Split(t.decl.Address, ".")
+	return parts[len(parts)-1]
+}
+
+// findRenameTarget finds a declared object whose declaration
+// or reference to it is found at the given position, if any
+func (h *logHandler) findRenameTarget(ctx context.Context, params lsp.TextDocumentPositionParams) (*renameTarget, error) {
+	fs, err := lsctx.Filesystem(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mif, err := lsctx.ModuleIndexFinder(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := fs.GetFile(ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI))
+	if err != nil {
+		return nil, err
+	}
+
+	fPos, err := ilsp.FilePositionFromDocumentPosition(params, file)
+	if err != nil {
+		return nil, err
+	}
+
+	mi, err := mif.ModuleIndexForDir(file.Dir())
+	if err != nil {
+		return nil, err
+	}
+
+	target := &renameTarget{
+		mif: mif,
+		mi:  mi,
+		dir: file.Dir(),
+	}
+
+	if ref, ok := mi.ReferenceAtPos(file.FullPath(), fPos.Position()); ok {
+		decl, ok := mi.Declaration(ref.Address)
+		if !ok {
+			h.logger.Printf("no declaration found for %q", ref.Address)
+			return nil, nil
+		}
+		target.decl = decl
+		target.nameRange = ref.NameRange
+		return target, nil
+	}
+
+	if decl, ok := mi.DeclarationAtPos(file.FullPath(), fPos.Position()); ok {
+		target.decl = decl
+		target.nameRange = decl.NameRange
+		return target, nil
+	}
+
+	h.logger.Printf("no reference or declaration found at %#v", fPos.Position())
+	return nil, nil
+}
@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-ls/internal/terraform/rootmodule"
+	"github.com/hashicorp/terraform-ls/langserver"
+	"github.com/hashicorp/terraform-ls/langserver/session"
+)
+
+func TestRename_withoutInitialization(t *testing.T) {
+	ls := langserver.NewLangServerMock(t, NewMockSession(nil))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.CallAndExpectError(t, &langserver.CallRequest{
+		Method: "textDocument/rename",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {
+				"character": 0,
+				"line": 1
+			},
+			"newName": "foo"
+		}`, TempDir(t).URI())}, session.SessionNotInitialized.Err())
+}
+
+func TestRename_variable(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	err := ioutil.WriteFile(filepath.Join(tmpDir.Dir(), "variables.tf"),
+		[]byte("variable \"region\" {}\n"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(tmpDir.Dir(), "terraform.tfvars"),
+		[]byte("region = \"eu-west-1\"\n"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		RootModules: map[string]*rootmodule.RootModuleMock{
+			tmpDir.Dir(): {
+				TerraformExecQueue: validTfMockCalls(),
+			},
+		}}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+	    "processId": 12345
+	}`, tmpDir.URI())})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "output \"zone\" {\n  value = \"${var.region}a\"\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI())})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/rename",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {
+				"character": 18,
+				"line": 1
+			},
+			"newName": "aws_region"
+		}`, tmpDir.URI())}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 3,
+			"result": {
+				"changes": {
+					"%s/main.tf": [
+						{
+							"range": {
+								"start": {"line": 1, "character": 17},
+								"end": {"line": 1, "character": 23}
+							},
+							"newText": "aws_region"
+						}
+					],
+					"%s/terraform.tfvars": [
+						{
+							"range": {
+								"start": {"line": 0, "character": 0},
+								"end": {"line": 0, "character": 6}
+							},
+							"newText": "aws_region"
+						}
+					],
+					"%s/variables.tf": [
+						{
+							"range": {
+								"start": {"line": 0, "character": 10},
+								"end": {"line": 0, "character": 16}
+							},
+							"newText": "aws_region"
+						}
+					]
+				}
+			}
+		}`, tmpDir.URI(), tmpDir.URI(), tmpDir.URI()))
+}
+
+func TestPrepareRename_resource(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		RootModules: map[string]*rootmodule.RootModuleMock{
+			tmpDir.Dir(): {
+				TerraformExecQueue: validTfMockCalls(),
+			},
+		}}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {
+	    	"textDocument": {
+	    		"rename": {
+	    			"prepareSupport": true
+	    		}
+	    	}
+	    },
+	    "rootUri": %q,
+	    "processId": 12345
+	}`, tmpDir.URI())}, `{
+		"jsonrpc": "2.0",
+		"id": 1,
+		"result": {
+			"capabilities": {
+				"textDocumentSync": {
+					"openClose": true,
+					"change": 2,
+					"save": {
+						"includeText": false
+					}
+				},
+				"hoverProvider": true,
+				"completionProvider": {},
+				"signatureHelpProvider": {
+					"triggerCharacters": ["(", ","]
+				},
+				"definitionProvider": true,
+				"referencesProvider": true,
+				"documentSymbolProvider": true,
+				"workspaceSymbolProvider": true,
+				"documentFormattingProvider":true,
+				"executeCommandProvider": {
+					"commands": [
+						"terraform-ls.terraform.init",
+						"terraform-ls.terraform.validate",
+						"terraform-ls.rootmodules.reload"
+					]
+				},
+				"renameProvider": {
+					"prepareProvider": true
+				}
+			}
+		}
+	}`)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "resource \"aws_instance\" \"web\" {\n}\n\noutput \"id\" {\n  value = aws_instance.web.id\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI())})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/prepareRename",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {
+				"character": 12,
+				"line": 4
+			}
+		}`, tmpDir.URI())}, `{
+			"jsonrpc": "2.0",
+			"id": 3,
+			"result": {
+				"range": {
+					"start": {"line": 4, "character": 23},
+					"end": {"line": 4, "character": 26}
+				},
+				"placeholder": "web"
+			}
+		}`)
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/hcl/v2"
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
+	"github.com/hashicorp/terraform-ls/internal/progress"
+	"github.com/hashicorp/terraform-ls/internal/terraform/discovery"
+	tferr "github.com/hashicorp/terraform-ls/internal/terraform/errors"
+	"github.com/hashicorp/terraform-ls/internal/terraform/rootmodule"
+	lsp "github.com/sourcegraph/go-lsp"
+)
+
+const loadDiagsSource = "terraform-ls"
+
+// reportLoading reports progress of loading the root module
+// (incl. obtaining schemas) and once it's done, reports
+// any load error to the user
+func (lh *logHandler) reportLoading(ctx context.Context, pn *progress.Notifier, rm rootmodule.RootModule) {
+	p := pn.Begin("Loading root module", rm.Path())
+
+	go func() {
+		<-rm.LoadingDone()
+
+		err := rm.LoadError()
+		if err != nil {
+			p.End(err.Error())
+		} else {
+			p.End("Loaded")
+		}
+
+		lh.reportLoadError(ctx, rm, true)
+	}()
+}
+
+// reportLoadError publishes diagnostics for files of the root module
+// explaining why functionality is limited if it failed to load
+// (or clears such diagnostics otherwise) and optionally
+// also notifies the user via window/showMessage,
+// once per root module and cause of the load error
+func (lh *logHandler) reportLoadError(ctx context.Context, rm rootmodule.RootModule, showMessage bool) {
+	notifier, err := lsctx.DiagnosticsNotifier(ctx)
+	if err != nil {
+		lh.logger.Printf("unable to report load error of %s: %s", rm.Path(), err)
+		return
+	}
+
+	diags := make(map[lsp.DocumentURI]hcl.Diagnostics, 0)
+
+	loadErr := rm.LoadError()
+	if loadErr != nil {
+		cause := loadErrorCause(loadErr)
+		hint := loadErrorHint(cause)
+		lh.logger.Printf("root module %s failed to load: %s", rm.Path(), loadErr)
+
+		for _, path := range configFilePaths(rm.Path()) {
+			uri := lsp.DocumentURI(filesystem.URIFromPath(path))
+			diags[uri] = hcl.Diagnostics{
+				{
+					Severity: hcl.DiagWarning,
+					Summary:  "Root module failed to load, functionality is limited",
+					Detail:   fmt.Sprintf("%s. %s", cause, hint),
+				},
+			}
+		}
+
+		if showMessage && lh.markLoadErrShown(rm.Path(), cause) {
+			err := jrpc2.ServerPush(ctx, "window/showMessage", lsp.ShowMessageParams{
+				Type: lsp.MTWarning,
+				Message: fmt.Sprintf("Failed to load root module %s: %s. %s",
+					rm.Path(), cause, hint),
+			})
+			if err != nil {
+				lh.logger.Printf("failed to show load error of %s: %s", rm.Path(), err)
+			}
+		}
+	}
+
+	if loadErr == nil {
+		// any future failure is worth showing again
+		lh.clearLoadErrShown(rm.Path())
+	}
+
+	err = notifier.PublishForDir(ctx, rm.Path(), loadDiagsSource, diags)
+	if err != nil {
+		lh.logger.Printf("failed to publish load diagnostics for %s: %s", rm.Path(), err)
+	}
+}
+
+// markLoadErrShown records the cause as shown to the user
+// for the given root module and reports whether
+// it was not shown for that module before
+func (lh *logHandler) markLoadErrShown(rmPath string, cause error) bool {
+	lh.shownLoadErrsMu.Lock()
+	defer lh.shownLoadErrsMu.Unlock()
+
+	msg := cause.Error()
+	if shownMsg, ok := lh.shownLoadErrs[rmPath]; ok && shownMsg == msg {
+		return false
+	}
+	lh.shownLoadErrs[rmPath] = msg
+	return true
+}
+
+// clearLoadErrShown forgets any cause shown for the given root module,
+// e.g. after it loaded successfully
+func (lh *logHandler) clearLoadErrShown(rmPath string) {
+	lh.shownLoadErrsMu.Lock()
+	defer lh.shownLoadErrsMu.Unlock()
+
+	delete(lh.shownLoadErrs, rmPath)
+}
+
+// loadErrorCause returns the first of errors which occurred
+// during loading, as the following ones are usually caused by it
+func loadErrorCause(err error) error {
+	var mErr *multierror.Error
+	if errors.As(err, &mErr) && len(mErr.Errors) > 0 {
+		return mErr.Errors[0]
+	}
+	return err
+}
+
+// loadErrorHint suggests how the user may resolve the load error
+func loadErrorHint(err error) string {
+	var notFoundErr *discovery.ExecutableNotFoundErr
+	if errors.As(err, &notFoundErr) {
+		return "Install Terraform or provide path to it via -tf-exec"
+	}
+
+	var versionErr *tferr.UnsupportedTerraformVersion
+	if errors.As(err, &versionErr) {
+		if versionErr.Constraints != nil {
+			return fmt.Sprintf("Use a supported version of Terraform (%s)",
+				versionErr.Constraints)
+		}
+		return "Use a supported version of Terraform"
+	}
+
+	var notInitErr *rootmodule.NotInitializedErr
+	if errors.As(err, &notInitErr) {
+		return "Run terraform init to install providers"
+	}
+
+	return "You may need to run terraform init"
+}
+
+// configFilePaths returns paths of Terraform
+// configuration files in the given directory
+func configFilePaths(dir string) []string {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return []string{}
+	}
+
+	paths := make([]string, 0)
+	for _, info := range infos {
+		if info.IsDir() || filepath.Ext(info.Name()) != ".tf" {
+			continue
+		}
+		paths = append(paths, filepath.Join(dir, info.Name()))
+	}
+	return paths
+}
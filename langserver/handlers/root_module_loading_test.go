@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-ls/internal/terraform/discovery"
+	tferr "github.com/hashicorp/terraform-ls/internal/terraform/errors"
+	"github.com/hashicorp/terraform-ls/internal/terraform/rootmodule"
+)
+
+func TestLoadErrorHint(t *testing.T) {
+	constraints, err := version.NewConstraint(">= 0.12.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		err          error
+		expectedHint string
+	}{
+		{
+			&discovery.ExecutableNotFoundErr{Name: "terraform", Err: exec.ErrNotFound},
+			"Install Terraform or provide path to it via -tf-exec",
+		},
+		{
+			&tferr.UnsupportedTerraformVersion{Version: "0.11.0", Constraints: constraints},
+			"Use a supported version of Terraform (>= 0.12.0)",
+		},
+		{
+			&rootmodule.NotInitializedErr{Dir: "/test"},
+			"Run terraform init to install providers",
+		},
+		{
+			errors.New("failed to parse module manifest"),
+			"You may need to run terraform init",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			// errors are usually wrapped in a multierror by the loader
+			err := multierror.Append(nil, tc.err,
+				errors.New("unknown terraform version - unable to find parser"))
+
+			cause := loadErrorCause(err)
+			if cause != tc.err {
+				t.Fatalf("cause doesn't match.\nexpected: %#v\ngiven: %#v", tc.err, cause)
+			}
+
+			hint := loadErrorHint(cause)
+			if diff := cmp.Diff(tc.expectedHint, hint); diff != "" {
+				t.Fatalf("hint doesn't match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestLogHandler_markLoadErrShown(t *testing.T) {
+	lh := LogHandler(testLogger())
+	notFoundErr := &discovery.ExecutableNotFoundErr{Name: "terraform", Err: exec.ErrNotFound}
+
+	if !lh.markLoadErrShown("/first", notFoundErr) {
+		t.Fatal("expected first occurrence of the cause to be shown")
+	}
+	// e.g. the same root module reloaded
+	if lh.markLoadErrShown("/first", &discovery.ExecutableNotFoundErr{Name: "terraform", Err: exec.ErrNotFound}) {
+		t.Fatal("expected repeated cause not to be shown")
+	}
+	if !lh.markLoadErrShown("/second", notFoundErr) {
+		t.Fatal("expected cause to be shown for another root module")
+	}
+	if !lh.markLoadErrShown("/first", errors.New("failed to parse module manifest")) {
+		t.Fatal("expected different cause to be shown")
+	}
+
+	// e.g. the root module loaded successfully before failing again
+	lh.clearLoadErrShown("/second")
+	if !lh.markLoadErrShown("/second", notFoundErr) {
+		t.Fatal("expected cause to be shown again after successful load")
+	}
+}
+
+func TestConfigFilePaths(t *testing.T) {
+	dir, err := ioutil.TempDir("", "handlers")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{"main.tf", "variables.tf", "README.md", "terraform.tfvars"} {
+		err := ioutil.WriteFile(filepath.Join(dir, name), []byte{}, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = os.Mkdir(filepath.Join(dir, "modules.tf"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPaths := []string{
+		filepath.Join(dir, "main.tf"),
+		filepath.Join(dir, "variables.tf"),
+	}
+	if diff := cmp.Diff(expectedPaths, configFilePaths(dir)); diff != "" {
+		t.Fatalf("paths don't match: %s", diff)
+	}
+}
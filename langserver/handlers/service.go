@@ -11,7 +11,9 @@ import (
 	"github.com/creachadair/jrpc2/code"
 	rpch "github.com/creachadair/jrpc2/handler"
 	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/diagnostics"
 	"github.com/hashicorp/terraform-ls/internal/filesystem"
+	"github.com/hashicorp/terraform-ls/internal/progress"
 	"github.com/hashicorp/terraform-ls/internal/terraform/rootmodule"
 	"github.com/hashicorp/terraform-ls/internal/watcher"
 	"github.com/hashicorp/terraform-ls/langserver/session"
@@ -32,6 +34,8 @@ type service struct {
 	newRootModuleManager rootmodule.RootModuleManagerFactory
 	newWatcher           watcher.WatcherFactory
 	newWalker            rootmodule.WalkerFactory
+	validator            *diagnostics.Scheduler
+	progress             *progress.Notifier
 }
 
 var discardLogs = log.New(ioutil.Discard, "", 0)
@@ -70,7 +74,19 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 	lh := LogHandler(svc.logger)
 	cc := &lsp.ClientCapabilities{}
 
-	svc.modMgr = svc.newRootModuleManager()
+	diags := diagnostics.NewNotifier()
+	diags.SetLogger(svc.logger)
+
+	svc.validator = diagnostics.NewScheduler(validateDelay)
+	svc.validator.SetLogger(svc.logger)
+
+	// Progress can only be reported if the server is able
+	// to make requests to the client, which depends on the transport
+	progressClient, _ := lsctx.ProgressClient(svc.srvCtx)
+	svc.progress = progress.NewNotifier(progressClient)
+	svc.progress.SetLogger(svc.logger)
+
+	svc.modMgr = svc.newRootModuleManager(fs)
 	svc.modMgr.SetLogger(svc.logger)
 
 	svc.walker = svc.newWalker()
@@ -85,6 +101,9 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 	if timeout, ok := lsctx.TerraformExecTimeout(svc.srvCtx); ok {
 		svc.modMgr.SetTerraformExecTimeout(timeout)
 	}
+	if dir, ok := lsctx.SchemaCacheDir(svc.srvCtx); ok {
+		svc.modMgr.SetSchemaCacheDir(dir)
+	}
 
 	ww, err := svc.newWatcher()
 	if err != nil {
@@ -101,6 +120,10 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 			svc.logger.Printf("detected plugin cache change, updating schema ...")
 			return w.UpdateSchemaCache(ctx, file)
 		}
+		if w.IsKnownPluginDir(file.Path()) {
+			svc.logger.Printf("detected plugin installation, updating schema ...")
+			return w.ReloadSchemaCache(ctx)
+		}
 
 		return nil
 	})
@@ -138,6 +161,8 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 			ctx = lsctx.WithRootDirectory(&rootDir, ctx)
 			ctx = lsctx.WithRootModuleManager(svc.modMgr, ctx)
 			ctx = lsctx.WithRootModuleLoader(rmLoader, ctx)
+			ctx = lsctx.WithProgressNotifier(svc.progress, ctx)
+			ctx = lsctx.WithDiagnosticsNotifier(diags, ctx)
 
 			return handle(ctx, req, lh.Initialize)
 		},
@@ -147,6 +172,7 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 				return nil, err
 			}
 			ctx = lsctx.WithFilesystem(fs, ctx)
+			ctx = lsctx.WithProgressNotifier(svc.progress, ctx)
 
 			return handle(ctx, req, Initialized)
 		},
@@ -156,7 +182,10 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 				return nil, err
 			}
 			ctx = lsctx.WithFilesystem(fs, ctx)
-			return handle(ctx, req, TextDocumentDidChange)
+			ctx = lsctx.WithDiagnosticsNotifier(diags, ctx)
+			ctx = lsctx.WithParserFinder(svc.modMgr, ctx)
+			ctx = lsctx.WithModuleIndexFinder(svc.modMgr, ctx)
+			return handle(ctx, req, lh.TextDocumentDidChange)
 		},
 		"textDocument/didOpen": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
 			err := session.CheckInitializationIsConfirmed()
@@ -166,15 +195,34 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 			ctx = lsctx.WithFilesystem(fs, ctx)
 			ctx = lsctx.WithRootDirectory(&rootDir, ctx)
 			ctx = lsctx.WithRootModuleCandidateFinder(svc.modMgr, ctx)
+			ctx = lsctx.WithRootModuleManager(svc.modMgr, ctx)
 			ctx = lsctx.WithRootModuleWalker(svc.walker, ctx)
+			ctx = lsctx.WithDiagnosticsNotifier(diags, ctx)
+			ctx = lsctx.WithParserFinder(svc.modMgr, ctx)
+			ctx = lsctx.WithModuleIndexFinder(svc.modMgr, ctx)
+			ctx = lsctx.WithRootModuleLoader(rmLoader, ctx)
+			ctx = lsctx.WithWatcher(ww, ctx)
+			ctx = lsctx.WithProgressNotifier(svc.progress, ctx)
 			return handle(ctx, req, lh.TextDocumentDidOpen)
 		},
+		"textDocument/didSave": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+			ctx = lsctx.WithRootModuleCandidateFinder(svc.modMgr, ctx)
+			ctx = lsctx.WithDiagnosticsNotifier(diags, ctx)
+			ctx = lsctx.WithValidationScheduler(svc.validator, ctx)
+			return handle(ctx, req, lh.TextDocumentDidSave)
+		},
 		"textDocument/didClose": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
 			err := session.CheckInitializationIsConfirmed()
 			if err != nil {
 				return nil, err
 			}
 			ctx = lsctx.WithFilesystem(fs, ctx)
+			ctx = lsctx.WithDiagnosticsNotifier(diags, ctx)
+			ctx = lsctx.WithModuleIndexFinder(svc.modMgr, ctx)
 			return handle(ctx, req, TextDocumentDidClose)
 		},
 		"textDocument/completion": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
@@ -186,9 +234,111 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 			ctx = lsctx.WithFilesystem(fs, ctx) // TODO: Read-only FS
 			ctx = lsctx.WithClientCapabilities(cc, ctx)
 			ctx = lsctx.WithParserFinder(svc.modMgr, ctx)
+			ctx = lsctx.WithModuleIndexFinder(svc.modMgr, ctx)
 
 			return handle(ctx, req, lh.TextDocumentComplete)
 		},
+		"textDocument/hover": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithFilesystem(fs, ctx)
+			ctx = lsctx.WithClientCapabilities(cc, ctx)
+			ctx = lsctx.WithParserFinder(svc.modMgr, ctx)
+			ctx = lsctx.WithModuleIndexFinder(svc.modMgr, ctx)
+
+			return handle(ctx, req, lh.TextDocumentHover)
+		},
+		"textDocument/signatureHelp": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithFilesystem(fs, ctx)
+			ctx = lsctx.WithParserFinder(svc.modMgr, ctx)
+
+			return handle(ctx, req, lh.TextDocumentSignatureHelp)
+		},
+		"textDocument/definition": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithFilesystem(fs, ctx)
+			ctx = lsctx.WithModuleIndexFinder(svc.modMgr, ctx)
+
+			return handle(ctx, req, lh.TextDocumentDefinition)
+		},
+		"textDocument/references": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithFilesystem(fs, ctx)
+			ctx = lsctx.WithModuleIndexFinder(svc.modMgr, ctx)
+
+			return handle(ctx, req, lh.TextDocumentReferences)
+		},
+		"textDocument/prepareRename": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithFilesystem(fs, ctx)
+			ctx = lsctx.WithModuleIndexFinder(svc.modMgr, ctx)
+
+			return handle(ctx, req, lh.TextDocumentPrepareRename)
+		},
+		"textDocument/rename": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithFilesystem(fs, ctx)
+			ctx = lsctx.WithModuleIndexFinder(svc.modMgr, ctx)
+
+			return handle(ctx, req, lh.TextDocumentRename)
+		},
+		"textDocument/documentSymbol": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithFilesystem(fs, ctx)
+			ctx = lsctx.WithClientCapabilities(cc, ctx)
+
+			return handle(ctx, req, lh.TextDocumentSymbol)
+		},
+		"workspace/executeCommand": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithRootModuleManager(svc.modMgr, ctx)
+			ctx = lsctx.WithWatcher(ww, ctx)
+			ctx = lsctx.WithDiagnosticsNotifier(diags, ctx)
+
+			return handle(ctx, req, lh.WorkspaceExecuteCommand)
+		},
+		"workspace/symbol": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
+			err := session.CheckInitializationIsConfirmed()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = lsctx.WithRootModuleManager(svc.modMgr, ctx)
+
+			return handle(ctx, req, lh.WorkspaceSymbol)
+		},
 		"textDocument/formatting": func(ctx context.Context, req *jrpc2.Request) (interface{}, error) {
 			err := session.CheckInitializationIsConfirmed()
 			if err != nil {
@@ -258,6 +408,15 @@ func (svc *service) shutdown() {
 		}
 	}
 
+	if svc.validator != nil {
+		svc.logger.Println("cancelling any validation ...")
+		svc.validator.Stop()
+	}
+
+	if svc.progress != nil {
+		svc.progress.Stop()
+	}
+
 	if svc.modMgr != nil {
 		svc.logger.Println("cancelling any root module loading ...")
 		svc.modMgr.CancelLoading()
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/sourcegraph/go-lsp"
+)
+
+func (h *logHandler) TextDocumentSignatureHelp(ctx context.Context, params lsp.TextDocumentPositionParams) (*lsp.SignatureHelp, error) {
+	fs, err := lsctx.Filesystem(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pf, err := lsctx.ParserFinder(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	h.logger.Printf("Finding signature at position %#v", params)
+
+	file, err := fs.GetFile(ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI))
+	if err != nil {
+		return nil, err
+	}
+
+	hclFile := ihcl.NewFile(file)
+	fPos, err := ilsp.FilePositionFromDocumentPosition(params, file)
+	if err != nil {
+		return nil, err
+	}
+
+	isParserLoaded, err := pf.IsParserLoaded(file.Dir())
+	if err != nil {
+		return nil, err
+	}
+	if !isParserLoaded {
+		return nil, fmt.Errorf("parser is not available yet for %s", file.Dir())
+	}
+
+	p, err := pf.ParserForDir(file.Dir())
+	if err != nil {
+		return nil, fmt.Errorf("finding compatible parser failed: %w", err)
+	}
+
+	data, err := p.SignatureAtPos(hclFile, fPos.Position())
+	if err != nil {
+		return nil, fmt.Errorf("finding signature failed: %w", err)
+	}
+
+	return ilsp.SignatureHelp(data), nil
+}
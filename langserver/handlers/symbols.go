@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"context"
+
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	"github.com/hashicorp/terraform-ls/internal/terraform/lang"
+	lsp "github.com/sourcegraph/go-lsp"
+)
+
+func (h *logHandler) TextDocumentSymbol(ctx context.Context, params lsp.DocumentSymbolParams) (interface{}, error) {
+	var symbols []ilsp.DocumentSymbol
+
+	fs, err := lsctx.Filesystem(ctx)
+	if err != nil {
+		return symbols, err
+	}
+
+	cc, err := lsctx.ClientCapabilities(ctx)
+	if err != nil {
+		return symbols, err
+	}
+
+	file, err := fs.GetFile(ilsp.FileHandlerFromDocumentURI(params.TextDocument.URI))
+	if err != nil {
+		return symbols, err
+	}
+
+	sbs, err := lang.SymbolsInFile(ihcl.NewFile(file))
+	if err != nil {
+		return symbols, err
+	}
+
+	if !cc.TextDocument.DocumentSymbol.HierarchicalDocumentSymbolSupport {
+		return ilsp.SymbolInformation(sbs, params.TextDocument.URI), nil
+	}
+
+	return ilsp.DocumentSymbols(sbs), nil
+}
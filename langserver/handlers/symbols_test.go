@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-ls/internal/terraform/rootmodule"
+	"github.com/hashicorp/terraform-ls/langserver"
+	"github.com/hashicorp/terraform-ls/langserver/session"
+)
+
+func TestDocumentSymbol_withoutInitialization(t *testing.T) {
+	ls := langserver.NewLangServerMock(t, NewMockSession(nil))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.CallAndExpectError(t, &langserver.CallRequest{
+		Method: "textDocument/documentSymbol",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			}
+		}`, TempDir(t).URI())}, session.SessionNotInitialized.Err())
+}
+
+func TestDocumentSymbol_hierarchical(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		RootModules: map[string]*rootmodule.RootModuleMock{
+			tmpDir.Dir(): {
+				TerraformExecQueue: validTfMockCalls(),
+			},
+		}}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {
+	    	"textDocument": {
+	    		"documentSymbol": {
+	    			"hierarchicalDocumentSymbolSupport": true
+	    		}
+	    	}
+	    },
+	    "rootUri": %q,
+	    "processId": 12345
+	}`, tmpDir.URI())})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "resource \"aws_vpc\" \"main\" {\n  timeouts {}\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI())})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/documentSymbol",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			}
+		}`, tmpDir.URI())}, `{
+			"jsonrpc": "2.0",
+			"id": 3,
+			"result": [
+				{
+					"name": "aws_vpc.main",
+					"detail": "resource",
+					"kind": 5,
+					"range": {
+						"start": {"line": 0, "character": 0},
+						"end": {"line": 2, "character": 1}
+					},
+					"selectionRange": {
+						"start": {"line": 0, "character": 0},
+						"end": {"line": 0, "character": 27}
+					},
+					"children": [
+						{
+							"name": "timeouts",
+							"kind": 23,
+							"range": {
+								"start": {"line": 1, "character": 2},
+								"end": {"line": 1, "character": 13}
+							},
+							"selectionRange": {
+								"start": {"line": 1, "character": 2},
+								"end": {"line": 1, "character": 12}
+							},
+							"children": []
+						}
+					]
+				}
+			]
+		}`)
+}
+
+func TestDocumentSymbol_flat(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		RootModules: map[string]*rootmodule.RootModuleMock{
+			tmpDir.Dir(): {
+				TerraformExecQueue: validTfMockCalls(),
+			},
+		}}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+	    "processId": 12345
+	}`, tmpDir.URI())})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "locals {\n  env = \"prod\"\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI())})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/documentSymbol",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			}
+		}`, tmpDir.URI())}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 3,
+			"result": [
+				{
+					"name": "locals",
+					"kind": 3,
+					"location": {
+						"uri": "%s/main.tf",
+						"range": {
+							"start": {"line": 0, "character": 0},
+							"end": {"line": 2, "character": 1}
+						}
+					}
+				},
+				{
+					"name": "local.env",
+					"kind": 13,
+					"location": {
+						"uri": "%s/main.tf",
+						"range": {
+							"start": {"line": 1, "character": 2},
+							"end": {"line": 1, "character": 14}
+						}
+					},
+					"containerName": "locals"
+				}
+			]
+		}`, tmpDir.URI(), tmpDir.URI()))
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/creachadair/jrpc2"
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	"github.com/hashicorp/terraform-ls/internal/terraform/rootmodule"
+	lsp "github.com/sourcegraph/go-lsp"
+)
+
+const (
+	cmdTerraformInit     = "terraform-ls.terraform.init"
+	cmdTerraformValidate = "terraform-ls.terraform.validate"
+	cmdRootModulesReload = "terraform-ls.rootmodules.reload"
+)
+
+// supportedCommands lists commands advertised
+// to the client via ExecuteCommandProvider
+var supportedCommands = []string{
+	cmdTerraformInit,
+	cmdTerraformValidate,
+	cmdRootModulesReload,
+}
+
+func (lh *logHandler) WorkspaceExecuteCommand(ctx context.Context, params lsp.ExecuteCommandParams) (interface{}, error) {
+	rmm, err := lsctx.RootModuleManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := lsctx.Watcher(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch params.Command {
+	case cmdTerraformInit, cmdTerraformValidate, cmdRootModulesReload:
+	default:
+		return nil, fmt.Errorf("unknown command: %q", params.Command)
+	}
+
+	dir, err := rootModuleDirFromArguments(params.Arguments)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", params.Command, err)
+	}
+
+	rm, err := rmm.RootModuleByPath(dir)
+	if err != nil {
+		if !rootmodule.IsRootModuleNotFound(err) {
+			return nil, err
+		}
+		lh.logger.Printf("Adding root module %s to execute %s", dir, params.Command)
+		rm, err = rmm.AddAndLoadRootModule(ctx, dir)
+		if err != nil {
+			if rm == nil {
+				return nil, err
+			}
+			// the command itself may still succeed (e.g. init)
+			lh.logger.Printf("failed to load root module %s: %s", dir, err)
+		}
+	}
+
+	switch params.Command {
+	case cmdTerraformInit:
+		err = rm.ExecuteTerraformInit(ctx)
+	case cmdTerraformValidate:
+		err = lh.executeTerraformValidate(ctx, rm)
+	case cmdRootModulesReload:
+		err = rm.Reload(ctx)
+	}
+	if params.Command != cmdTerraformValidate {
+		// the command itself reports any error, so only
+		// diagnostics about (no longer) limited functionality are updated
+		lh.reportLoadError(ctx, rm, false)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", params.Command, err)
+	}
+
+	paths := rm.PathsToWatch()
+	lh.logger.Printf("Adding %d paths of root module for watching (%s)", len(paths), rm.Path())
+	err = w.AddPaths(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (lh *logHandler) executeTerraformValidate(ctx context.Context, rm rootmodule.RootModule) error {
+	notifier, err := lsctx.DiagnosticsNotifier(ctx)
+	if err != nil {
+		return err
+	}
+
+	diags, rmDiags, err := validateRootModule(ctx, rm)
+	if err != nil {
+		return err
+	}
+
+	err = notifier.PublishForDir(ctx, rm.Path(), validateDiagsSource, diags)
+	if err != nil {
+		return err
+	}
+
+	if len(rmDiags) == 0 {
+		return nil
+	}
+
+	// there is no document to attach these diagnostics to
+	msgs := make([]string, len(rmDiags))
+	for i, diag := range rmDiags {
+		msgs[i] = diag.Error()
+	}
+	return jrpc2.ServerPush(ctx, "window/showMessage", lsp.ShowMessageParams{
+		Type:    lsp.MTError,
+		Message: fmt.Sprintf("Validation of %s failed:\n%s", rm.Path(), strings.Join(msgs, "\n")),
+	})
+}
+
+// rootModuleDirFromArguments parses path of the root module
+// from the first argument, which is expected to be a directory URI
+func rootModuleDirFromArguments(args []interface{}) (string, error) {
+	if len(args) == 0 {
+		return "", fmt.Errorf("expected root module URI as first argument")
+	}
+
+	uri, ok := args[0].(string)
+	if !ok {
+		return "", fmt.Errorf("expected root module URI as first argument, given: %#v", args[0])
+	}
+
+	fh := ilsp.FileHandlerFromDirURI(lsp.DocumentURI(uri))
+	if !fh.Valid() {
+		return "", fmt.Errorf("invalid root module URI: %q", uri)
+	}
+
+	return fh.Dir(), nil
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/fuzzy"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/sourcegraph/go-lsp"
+)
+
+// maxWorkspaceSymbols is the maximum number of symbols
+// to send in one workspace/symbol response
+var maxWorkspaceSymbols = 100
+
+type scoredSymbol struct {
+	symbol lsp.SymbolInformation
+	score  int
+}
+
+func (h *logHandler) WorkspaceSymbol(ctx context.Context, params lsp.WorkspaceSymbolParams) ([]lsp.SymbolInformation, error) {
+	symbols := []lsp.SymbolInformation{}
+
+	rmm, err := lsctx.RootModuleManager(ctx)
+	if err != nil {
+		return symbols, err
+	}
+
+	matches := make([]scoredSymbol, 0)
+
+	// modules shared by multiple root modules (or called
+	// multiple times) are only searched once
+	seenDirs := make(map[string]bool, 0)
+
+	for _, rm := range rmm.ListRootModules() {
+		for _, mod := range rm.Modules() {
+			dir := filepath.Clean(mod.Dir)
+			if seenDirs[dir] {
+				continue
+			}
+			seenDirs[dir] = true
+
+			mi, err := rm.ModuleIndex(mod.Dir)
+			if err != nil {
+				h.logger.Printf("skipping symbols of %s: %s", mod.Dir, err)
+				continue
+			}
+
+			for _, decl := range mi.Declarations() {
+				name := decl.Address
+				if mod.Address != "" {
+					name = mod.Address + "." + name
+				}
+
+				score, ok := fuzzy.Match(params.Query, name)
+				if !ok {
+					continue
+				}
+
+				matches = append(matches, scoredSymbol{
+					symbol: lsp.SymbolInformation{
+						Name:          name,
+						Kind:          ilsp.SymbolKind(decl.SymbolKind()),
+						Location:      ilsp.HCLRangeToLocation(decl.Range),
+						ContainerName: rm.Path(),
+					},
+					score: score,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		if matches[i].symbol.Name != matches[j].symbol.Name {
+			return matches[i].symbol.Name < matches[j].symbol.Name
+		}
+		return matches[i].symbol.ContainerName < matches[j].symbol.ContainerName
+	})
+
+	for _, m := range matches {
+		if len(symbols) >= maxWorkspaceSymbols {
+			break
+		}
+		symbols = append(symbols, m.symbol)
+	}
+
+	return symbols, nil
+}
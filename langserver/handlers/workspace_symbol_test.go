@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-ls/internal/terraform/rootmodule"
+	"github.com/hashicorp/terraform-ls/langserver"
+	"github.com/hashicorp/terraform-ls/langserver/session"
+)
+
+func TestWorkspaceSymbol_withoutInitialization(t *testing.T) {
+	ls := langserver.NewLangServerMock(t, NewMockSession(nil))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.CallAndExpectError(t, &langserver.CallRequest{
+		Method:    "workspace/symbol",
+		ReqParams: `{"query": "vpc"}`,
+	}, session.SessionNotInitialized.Err())
+}
+
+func TestWorkspaceSymbol_moduleAddress(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Dir())
+
+	files := map[string]string{
+		"main.tf": "module \"vpc\" {\n  source = \"./vpc\"\n}\n\n" +
+			"resource \"aws_subnet\" \"public\" {}\n",
+		filepath.Join("vpc", "main.tf"): "resource \"aws_subnet\" \"private\" {}\n",
+		filepath.Join(".terraform", "modules", "modules.json"): `{"Modules":[` +
+			`{"Key":"","Source":"","Dir":"."},` +
+			`{"Key":"vpc","Source":"./vpc","Dir":"vpc"}]}`,
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir.Dir(), name)
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ioutil.WriteFile(path, []byte(content), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		RootModules: map[string]*rootmodule.RootModuleMock{
+			tmpDir.Dir(): {
+				TerraformExecQueue: validTfMockCalls(),
+			},
+		}}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+	    "processId": 12345
+	}`, tmpDir.URI())})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method:    "workspace/symbol",
+		ReqParams: `{"query": "vpc.subnet.priv"}`,
+	}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 2,
+			"result": [
+				{
+					"name": "module.vpc.aws_subnet.private",
+					"kind": 5,
+					"location": {
+						"uri": "%s/vpc/main.tf",
+						"range": {
+							"start": {"line": 0, "character": 0},
+							"end": {"line": 0, "character": 34}
+						}
+					},
+					"containerName": %q
+				}
+			]
+		}`, tmpDir.URI(), tmpDir.Dir()))
+}
+
+func TestWorkspaceSymbol_sharedModule(t *testing.T) {
+	tmpDir := TempDir(t)
+
+	manifest := `{"Modules":[` +
+		`{"Key":"","Source":"","Dir":"."},` +
+		`{"Key":"shared","Source":"../shared","Dir":"../shared"}]}`
+	moduleCall := "module \"shared\" {\n  source = \"../shared\"\n}\n"
+	files := map[string]string{
+		filepath.Join("first", "main.tf"):  moduleCall,
+		filepath.Join("second", "main.tf"): moduleCall,
+		filepath.Join("shared", "main.tf"): "resource \"aws_vpc\" \"main\" {}\n",
+
+		filepath.Join("first", ".terraform", "modules", "modules.json"):  manifest,
+		filepath.Join("second", ".terraform", "modules", "modules.json"): manifest,
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir.Dir(), name)
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ioutil.WriteFile(path, []byte(content), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	firstDir := filepath.Join(tmpDir.Dir(), "first")
+	secondDir := filepath.Join(tmpDir.Dir(), "second")
+	InitPluginCache(t, firstDir)
+	InitPluginCache(t, secondDir)
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		RootModules: map[string]*rootmodule.RootModuleMock{
+			firstDir: {
+				TerraformExecQueue: validTfMockCalls(),
+			},
+			secondDir: {
+				TerraformExecQueue: validTfMockCalls(),
+			},
+		}}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+	    "processId": 12345
+	}`, tmpDir.URI())})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method:    "workspace/symbol",
+		ReqParams: `{"query": "aws_vpc.main"}`,
+	}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 2,
+			"result": [
+				{
+					"name": "module.shared.aws_vpc.main",
+					"kind": 5,
+					"location": {
+						"uri": "%s/shared/main.tf",
+						"range": {
+							"start": {"line": 0, "character": 0},
+							"end": {"line": 0, "character": 28}
+						}
+					},
+					"containerName": %q
+				}
+			]
+		}`, tmpDir.URI(), firstDir))
+}
@@ -8,10 +8,12 @@ import (
 	"log"
 	"net"
 	"os"
+	"sync"
 
 	"github.com/creachadair/jrpc2"
 	"github.com/creachadair/jrpc2/channel"
 	"github.com/creachadair/jrpc2/server"
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
 	"github.com/hashicorp/terraform-ls/langserver/session"
 )
 
@@ -45,18 +47,23 @@ func (ls *langServer) SetLogger(logger *log.Logger) {
 	ls.logger = logger
 }
 
-func (ls *langServer) newService() server.Service {
-	svc := ls.newSession(ls.srvCtx)
+func (ls *langServer) newServiceWithContext(ctx context.Context) server.Service {
+	svc := ls.newSession(ctx)
 	svc.SetLogger(ls.logger)
 	return svc
 }
 
 func (ls *langServer) startServer(reader io.Reader, writer io.WriteCloser) (*singleServer, error) {
-	srv, err := Server(ls.newService(), ls.srvOptions)
+	// The channel is wrapped to allow requests to the client
+	// (e.g. for progress reporting)
+	ch := newClientChannel(channel.LSP(reader, writer))
+	ctx := lsctx.WithProgressClient(ch, ls.srvCtx)
+
+	srv, err := Server(ls.newServiceWithContext(ctx), ls.srvOptions)
 	if err != nil {
 		return nil, err
 	}
-	srv.Start(channel.LSP(reader, writer))
+	srv.Start(ch)
 
 	return srv, nil
 }
@@ -97,10 +104,7 @@ func (ls *langServer) StartTCP(address string) error {
 
 	go func() {
 		ls.logger.Println("Starting loop server ...")
-		err = server.Loop(lst, ls.newService, &server.LoopOptions{
-			Framing:       channel.LSP,
-			ServerOptions: ls.srvOptions,
-		})
+		err = ls.serveConnections(lst)
 		if err != nil {
 			ls.logger.Printf("Loop server failed to start: %s", err)
 		}
@@ -120,6 +124,39 @@ func (ls *langServer) StartTCP(address string) error {
 	return nil
 }
 
+// serveConnections starts a server for each connection accepted
+// from the listener, similar to server.Loop, except that each
+// server is started via startServer, which wraps its channel
+// to allow progress reporting
+func (ls *langServer) serveConnections(lst net.Listener) error {
+	var wg sync.WaitGroup
+	for {
+		conn, err := lst.Accept()
+		if err != nil {
+			if channel.IsErrClosing(err) {
+				err = nil
+			} else {
+				ls.logger.Printf("Error accepting new connection: %s", err)
+			}
+			wg.Wait()
+			return err
+		}
+
+		srv, err := ls.startServer(conn, conn)
+		if err != nil {
+			ls.logger.Printf("Service initialization failed: %s", err)
+			conn.Close()
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			srv.Wait()
+		}()
+	}
+}
+
 // singleServer is a wrapper around jrpc2.NewServer providing support
 // for server.Service (Assigner/Finish interface)
 type singleServer struct {